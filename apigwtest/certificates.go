@@ -0,0 +1,138 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apigwtest
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"net/http"
+
+	"github.com/google/uuid"
+	v1 "github.com/sacloud/apigw-api-go/apis/v1"
+)
+
+func (s *Server) lookupCertificate(w http.ResponseWriter, r *http.Request) (*v1.Certificate, bool) {
+	id, ok := pathID(w, r, "certificateId")
+	if !ok {
+		return nil, false
+	}
+	cert, ok := s.certificates.get(id)
+	if !ok {
+		writeError(w, http.StatusNotFound, "certificate not found: %s", id)
+		return nil, false
+	}
+	return cert, true
+}
+
+func (s *Server) certificateNameConflicts(name v1.OptName, self uuid.UUID) bool {
+	_, ok := s.certificates.find(func(c *v1.Certificate) bool {
+		return c.Name == name && c.ID.Value != self
+	})
+	return ok
+}
+
+// fillExpiredAt 証明書の有効期限をPEMから読み取って設定する
+func fillExpiredAt(w http.ResponseWriter, details *v1.OptCertificateDetails) bool {
+	if !details.Set || !details.Value.Cert.Set {
+		return true
+	}
+	block, _ := pem.Decode([]byte(details.Value.Cert.Value))
+	if block == nil {
+		writeError(w, http.StatusUnprocessableEntity, "invalid certificate")
+		return false
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, "invalid certificate: %s", err)
+		return false
+	}
+	details.Value.ExpiredAt = v1.NewOptDateTime(cert.NotAfter.UTC())
+	return true
+}
+
+func (s *Server) addCertificate(w http.ResponseWriter, r *http.Request) {
+	var req v1.Certificate
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	if s.certificateNameConflicts(req.Name, uuid.Nil) {
+		writeError(w, http.StatusConflict, "certificate already exists: %s", req.Name.Value)
+		return
+	}
+	if !fillExpiredAt(w, &req.Rsa) || !fillExpiredAt(w, &req.Ecdsa) {
+		return
+	}
+
+	ts := now()
+	req.ID = v1.NewOptUUID(uuid.New())
+	req.CreatedAt = v1.NewOptDateTime(ts)
+	req.UpdatedAt = v1.NewOptDateTime(ts)
+	s.certificates.put(req.ID.Value, &req)
+
+	writeJSON(w, http.StatusCreated, &v1.AddCertificateCreated{Apigw: v1.AddCertificateCreatedApigw{
+		Certificate: v1.NewOptCertificate(req),
+	}})
+}
+
+func (s *Server) getCertificates(w http.ResponseWriter, r *http.Request) {
+	certs := []v1.Certificate{}
+	for _, c := range s.certificates.list() {
+		certs = append(certs, *c)
+	}
+	writeJSON(w, http.StatusOK, &v1.GetCertificatesOK{Apigw: v1.CertificateDTO{Certificates: certs}})
+}
+
+func (s *Server) updateCertificate(w http.ResponseWriter, r *http.Request) {
+	cert, ok := s.lookupCertificate(w, r)
+	if !ok {
+		return
+	}
+	var req v1.Certificate
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	if s.certificateNameConflicts(req.Name, cert.ID.Value) {
+		writeError(w, http.StatusConflict, "certificate already exists: %s", req.Name.Value)
+		return
+	}
+	if !fillExpiredAt(w, &req.Rsa) || !fillExpiredAt(w, &req.Ecdsa) {
+		return
+	}
+
+	cert.Name = req.Name
+	cert.Rsa = req.Rsa
+	cert.Ecdsa = req.Ecdsa
+	cert.UpdatedAt = v1.NewOptDateTime(now())
+
+	for _, d := range s.domains.list() {
+		if d.CertificateId == cert.ID {
+			d.CertificateName = v1.NewOptString(string(cert.Name.Value))
+		}
+	}
+	writeNoContent(w)
+}
+
+func (s *Server) deleteCertificate(w http.ResponseWriter, r *http.Request) {
+	cert, ok := s.lookupCertificate(w, r)
+	if !ok {
+		return
+	}
+	if d, ok := s.domains.find(func(d *v1.Domain) bool { return d.CertificateId == cert.ID }); ok {
+		writeError(w, http.StatusBadRequest, "certificate is used by domain: %s", d.DomainName)
+		return
+	}
+	s.certificates.delete(cert.ID.Value)
+	writeNoContent(w)
+}
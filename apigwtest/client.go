@@ -0,0 +1,46 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apigwtest
+
+import (
+	"testing"
+
+	apigw "github.com/sacloud/apigw-api-go"
+	v1 "github.com/sacloud/apigw-api-go/apis/v1"
+	"github.com/sacloud/saclient-go"
+)
+
+// NewClient フェイクサーバを起動し、それに接続するクライアントを返す。
+// テストが遅くならないようレート制限を緩め、再試行を無効にする。layersはクライアントに渡され、
+// フェイクサーバはテストの終了時に停止する
+func NewClient(t testing.TB, layers ...apigw.Layer) (*Server, *v1.Client) {
+	t.Helper()
+
+	fake := NewServer()
+	t.Cleanup(fake.Close)
+
+	var theClient saclient.Client
+	if err := theClient.SetEnviron([]string{"SAKURA_RATE_LIMIT=1000"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := theClient.SetWith(saclient.WithoutRetry()); err != nil {
+		t.Fatal(err)
+	}
+	client, err := apigw.NewClientWithAPIRootURL(&theClient, fake.URL, layers...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return fake, client
+}
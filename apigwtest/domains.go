@@ -0,0 +1,107 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apigwtest
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	v1 "github.com/sacloud/apigw-api-go/apis/v1"
+)
+
+func (s *Server) lookupDomain(w http.ResponseWriter, r *http.Request) (*v1.Domain, bool) {
+	id, ok := pathID(w, r, "domainId")
+	if !ok {
+		return nil, false
+	}
+	domain, ok := s.domains.get(id)
+	if !ok {
+		writeError(w, http.StatusNotFound, "domain not found: %s", id)
+		return nil, false
+	}
+	return domain, true
+}
+
+// resolveCertificate Domainに指定された証明書を検証し、証明書名を補完する
+func (s *Server) resolveCertificate(w http.ResponseWriter, domain *v1.Domain, certificateId v1.OptUUID) bool {
+	if !certificateId.Set {
+		domain.CertificateId = v1.OptUUID{}
+		domain.CertificateName = v1.OptString{}
+		return true
+	}
+	cert, ok := s.certificates.get(certificateId.Value)
+	if !ok {
+		writeError(w, http.StatusBadRequest, "certificate not found: %s", certificateId.Value)
+		return false
+	}
+	domain.CertificateId = cert.ID
+	domain.CertificateName = v1.NewOptString(string(cert.Name.Value))
+	return true
+}
+
+func (s *Server) addDomain(w http.ResponseWriter, r *http.Request) {
+	var req v1.Domain
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	if _, ok := s.domains.find(func(d *v1.Domain) bool { return d.DomainName == req.DomainName }); ok {
+		writeError(w, http.StatusConflict, "domain already exists: %s", req.DomainName)
+		return
+	}
+	if !s.resolveCertificate(w, &req, req.CertificateId) {
+		return
+	}
+
+	ts := now()
+	req.ID = v1.NewOptUUID(uuid.New())
+	req.CreatedAt = v1.NewOptDateTime(ts)
+	req.UpdatedAt = v1.NewOptDateTime(ts)
+	s.domains.put(req.ID.Value, &req)
+
+	writeJSON(w, http.StatusCreated, &v1.AddDomainCreated{Apigw: v1.AddDomainCreatedApigw{Domain: v1.NewOptDomain(req)}})
+}
+
+func (s *Server) getDomains(w http.ResponseWriter, r *http.Request) {
+	domains := []v1.Domain{}
+	for _, d := range s.domains.list() {
+		domains = append(domains, *d)
+	}
+	writeJSON(w, http.StatusOK, &v1.GetDomainsOK{Apigw: v1.DomainDTO{Domains: domains}})
+}
+
+func (s *Server) updateDomain(w http.ResponseWriter, r *http.Request) {
+	domain, ok := s.lookupDomain(w, r)
+	if !ok {
+		return
+	}
+	var req v1.DomainPUT
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	if !s.resolveCertificate(w, domain, req.CertificateId) {
+		return
+	}
+	domain.UpdatedAt = v1.NewOptDateTime(now())
+	writeNoContent(w)
+}
+
+func (s *Server) deleteDomain(w http.ResponseWriter, r *http.Request) {
+	domain, ok := s.lookupDomain(w, r)
+	if !ok {
+		return
+	}
+	s.domains.delete(domain.ID.Value)
+	writeNoContent(w)
+}
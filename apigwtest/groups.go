@@ -0,0 +1,160 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apigwtest
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	v1 "github.com/sacloud/apigw-api-go/apis/v1"
+)
+
+func (s *Server) lookupGroup(w http.ResponseWriter, r *http.Request) (*v1.Group, bool) {
+	id, ok := pathID(w, r, "groupId")
+	if !ok {
+		return nil, false
+	}
+	group, ok := s.groups.get(id)
+	if !ok {
+		writeError(w, http.StatusNotFound, "group not found: %s", id)
+		return nil, false
+	}
+	return group, true
+}
+
+// findGroup IDまたは名前でGroupを検索する。IDが指定されている場合はIDを優先する
+func (s *Server) findGroup(id v1.OptUUID, name v1.OptName) (*v1.Group, bool) {
+	if id.Set {
+		return s.groups.get(id.Value)
+	}
+	if name.Set {
+		return s.groups.find(func(g *v1.Group) bool { return g.Name == name })
+	}
+	return nil, false
+}
+
+func groupRef(id v1.OptUUID, name v1.OptName) string {
+	if id.Set {
+		return id.Value.String()
+	}
+	return string(name.Value)
+}
+
+func (s *Server) groupNameConflicts(name v1.OptName, self uuid.UUID) bool {
+	_, ok := s.groups.find(func(g *v1.Group) bool {
+		return g.Name == name && g.ID.Value != self
+	})
+	return ok
+}
+
+func (s *Server) addGroup(w http.ResponseWriter, r *http.Request) {
+	var req v1.Group
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	if !req.Name.Set {
+		writeError(w, http.StatusBadRequest, "name is required")
+		return
+	}
+	if s.groupNameConflicts(req.Name, uuid.Nil) {
+		writeError(w, http.StatusConflict, "group already exists: %s", req.Name.Value)
+		return
+	}
+
+	ts := now()
+	req.ID = v1.NewOptUUID(uuid.New())
+	req.CreatedAt = v1.NewOptDateTime(ts)
+	req.UpdatedAt = v1.NewOptDateTime(ts)
+	s.groups.put(req.ID.Value, &req)
+
+	writeJSON(w, http.StatusCreated, &v1.AddGroupCreated{Apigw: v1.AddGroupCreatedApigw{Group: v1.NewOptGroup(req)}})
+}
+
+func (s *Server) getGroups(w http.ResponseWriter, r *http.Request) {
+	groups := []v1.Group{}
+	for _, g := range s.groups.list() {
+		groups = append(groups, *g)
+	}
+	writeJSON(w, http.StatusOK, &v1.GetGroupsOK{Apigw: v1.GetGroupsOKApigw{Groups: groups}})
+}
+
+func (s *Server) getGroup(w http.ResponseWriter, r *http.Request) {
+	group, ok := s.lookupGroup(w, r)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, &v1.GetGroupOK{Apigw: v1.GetGroupOKApigw{Group: v1.NewOptGroup(*group)}})
+}
+
+func (s *Server) updateGroup(w http.ResponseWriter, r *http.Request) {
+	group, ok := s.lookupGroup(w, r)
+	if !ok {
+		return
+	}
+	var req v1.Group
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	if req.Name.Set && s.groupNameConflicts(req.Name, group.ID.Value) {
+		writeError(w, http.StatusConflict, "group already exists: %s", req.Name.Value)
+		return
+	}
+
+	if req.Name.Set {
+		group.Name = req.Name
+	}
+	group.Tags = req.Tags
+	group.UpdatedAt = v1.NewOptDateTime(now())
+
+	// Route認可設定が保持しているGroup名も追従させる
+	for _, svc := range s.services.list() {
+		for _, rt := range svc.routes.list() {
+			for i := range rt.authorization.Groups {
+				if rt.authorization.Groups[i].ID == group.ID {
+					rt.authorization.Groups[i].Name = group.Name
+				}
+			}
+		}
+	}
+	writeNoContent(w)
+}
+
+func (s *Server) deleteGroup(w http.ResponseWriter, r *http.Request) {
+	group, ok := s.lookupGroup(w, r)
+	if !ok {
+		return
+	}
+	id := group.ID.Value
+	s.groups.delete(id)
+
+	for _, u := range s.users.list() {
+		delete(u.groups, id)
+	}
+	for _, svc := range s.services.list() {
+		for _, rt := range svc.routes.list() {
+			groups := rt.authorization.Groups[:0]
+			for _, g := range rt.authorization.Groups {
+				if g.ID.Value != id {
+					groups = append(groups, g)
+				}
+			}
+			rt.authorization.Groups = groups
+			if len(groups) == 0 {
+				rt.authorization = v1.RouteAuthorizationDetailResponse{}
+			}
+		}
+	}
+	writeNoContent(w)
+}
@@ -0,0 +1,130 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apigwtest
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	v1 "github.com/sacloud/apigw-api-go/apis/v1"
+)
+
+func (s *Server) lookupOidc(w http.ResponseWriter, r *http.Request) (*v1.Oidc, bool) {
+	id, ok := pathID(w, r, "oidcId")
+	if !ok {
+		return nil, false
+	}
+	oidc, ok := s.oidcs.get(id)
+	if !ok {
+		writeError(w, http.StatusNotFound, "oidc not found: %s", id)
+		return nil, false
+	}
+	return oidc, true
+}
+
+func (s *Server) oidcNameConflicts(name v1.Name, self uuid.UUID) bool {
+	_, ok := s.oidcs.find(func(o *v1.Oidc) bool {
+		return o.Name == name && o.ID.Value != self
+	})
+	return ok
+}
+
+// oidcServices OIDC認証を使用しているServiceの一覧
+func (s *Server) oidcServices(id uuid.UUID) []v1.ServiceSummary {
+	services := []v1.ServiceSummary{}
+	for _, svc := range s.services.list() {
+		if svc.detail.Oidc.Set && svc.detail.Oidc.Value.ID.Value == id {
+			services = append(services, v1.ServiceSummary{ID: svc.detail.ID, Name: v1.NewOptName(svc.detail.Name)})
+		}
+	}
+	return services
+}
+
+func (s *Server) addOidc(w http.ResponseWriter, r *http.Request) {
+	var req v1.Oidc
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	if s.oidcNameConflicts(req.Name, uuid.Nil) {
+		writeError(w, http.StatusConflict, "oidc already exists: %s", req.Name)
+		return
+	}
+
+	ts := now()
+	req.ID = v1.NewOptUUID(uuid.New())
+	req.CreatedAt = v1.NewOptDateTime(ts)
+	req.UpdatedAt = v1.NewOptDateTime(ts)
+	s.oidcs.put(req.ID.Value, &req)
+
+	writeJSON(w, http.StatusCreated, &v1.AddOidcCreated{Apigw: v1.AddOidcCreatedApigw{Oidc: v1.NewOptOidc(req)}})
+}
+
+func (s *Server) getOidc(w http.ResponseWriter, r *http.Request) {
+	oidcs := []v1.Oidc{}
+	for _, o := range s.oidcs.list() {
+		oidcs = append(oidcs, *o)
+	}
+	writeJSON(w, http.StatusOK, &v1.GetOidcOK{Apigw: v1.GetOidcOKApigw{Oidcs: oidcs}})
+}
+
+func (s *Server) getOidcById(w http.ResponseWriter, r *http.Request) {
+	oidc, ok := s.lookupOidc(w, r)
+	if !ok {
+		return
+	}
+	detail := convert[v1.OidcDetail](oidc)
+	detail.Services = s.oidcServices(oidc.ID.Value)
+	writeJSON(w, http.StatusOK, &v1.GetOidcByIdOK{Apigw: v1.GetOidcByIdOKApigw{Oidc: v1.NewOptOidcDetail(detail)}})
+}
+
+func (s *Server) updateOidc(w http.ResponseWriter, r *http.Request) {
+	oidc, ok := s.lookupOidc(w, r)
+	if !ok {
+		return
+	}
+	var req v1.Oidc
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	if s.oidcNameConflicts(req.Name, oidc.ID.Value) {
+		writeError(w, http.StatusConflict, "oidc already exists: %s", req.Name)
+		return
+	}
+
+	req.ID = oidc.ID
+	req.CreatedAt = oidc.CreatedAt
+	req.UpdatedAt = v1.NewOptDateTime(now())
+	*oidc = req
+
+	for _, svc := range s.services.list() {
+		if svc.detail.Oidc.Set && svc.detail.Oidc.Value.ID == oidc.ID {
+			svc.detail.Oidc.Value.Name = v1.NewOptString(string(oidc.Name))
+		}
+	}
+	writeNoContent(w)
+}
+
+func (s *Server) deleteOidc(w http.ResponseWriter, r *http.Request) {
+	oidc, ok := s.lookupOidc(w, r)
+	if !ok {
+		return
+	}
+	if services := s.oidcServices(oidc.ID.Value); len(services) > 0 {
+		writeError(w, http.StatusConflict, "oidc is used by service: %s", services[0].Name.Value)
+		return
+	}
+	s.oidcs.delete(oidc.ID.Value)
+	writeNoContent(w)
+}
@@ -0,0 +1,293 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apigwtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+
+	apigw "github.com/sacloud/apigw-api-go"
+	"github.com/sacloud/apigw-api-go/internal/wire"
+	"github.com/sacloud/saclient-go"
+)
+
+// RecorderMode Recorderの動作モード
+type RecorderMode int
+
+const (
+	// ModeReplay カセットに記録されたレスポンスを返す。実際のAPIにはリクエストを送信しない
+	ModeReplay RecorderMode = iota
+	// ModeRecord 実際のAPIにリクエストを送信し、その内容をカセットに記録する
+	ModeRecord
+)
+
+// cassetteVersion カセットファイルの形式のバージョン
+const cassetteVersion = 1
+
+// Doer HTTPリクエストを送信するクライアント。apigw.Doerと同じもので、v1.WithClientに渡せる
+type Doer = apigw.Doer
+
+// Cassette 記録されたリクエスト・レスポンスの組
+type Cassette struct {
+	Version      int           `json:"version"`
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction 1回の操作で送受信したリクエストとレスポンス
+type Interaction struct {
+	Operation string           `json:"operation"`
+	Request   RecordedRequest  `json:"request"`
+	Response  RecordedResponse `json:"response"`
+}
+
+// RecordedRequest 記録されたリクエスト。PathはAPIルートURLを除いた部分
+type RecordedRequest struct {
+	Method string          `json:"method"`
+	Path   string          `json:"path"`
+	Body   json.RawMessage `json:"body,omitempty"`
+}
+
+// RecordedResponse 記録されたレスポンス
+type RecordedResponse struct {
+	StatusCode  int             `json:"statusCode"`
+	ContentType string          `json:"contentType,omitempty"`
+	Body        json.RawMessage `json:"body,omitempty"`
+}
+
+// Recorder APIとの通信をカセットファイルに記録・再生するHTTPクライアント。
+// v1.WithClientに渡すか、Middlewareをsaclient.WithMiddlewareに渡して利用する。
+// 記録時には`mask:"true"`タグが付いたフィールド(パスワードや秘密鍵など)の値を置き換える。
+type Recorder struct {
+	path string
+	mode RecorderMode
+	next Doer
+
+	mu       sync.Mutex
+	cassette Cassette
+	pos      int
+}
+
+// NewRecorder pathのカセットファイルを記録・再生するRecorderを生成する。
+// ModeRecordの場合、nextで実際にリクエストを送信する。nextがnilの場合はhttp.DefaultClientを使う
+func NewRecorder(path string, mode RecorderMode, next Doer) (*Recorder, error) {
+	r := &Recorder{path: path, mode: mode, next: next}
+
+	switch mode {
+	case ModeRecord:
+		if r.next == nil {
+			r.next = http.DefaultClient
+		}
+		r.cassette = Cassette{Version: cassetteVersion, Interactions: []Interaction{}}
+	case ModeReplay:
+		data, err := os.ReadFile(filepath.Clean(path))
+		if err != nil {
+			return nil, fmt.Errorf("apigwtest: unable to load cassette: %w", err)
+		}
+		if err := json.Unmarshal(data, &r.cassette); err != nil {
+			return nil, fmt.Errorf("apigwtest: invalid cassette %s: %w", path, err)
+		}
+		if r.cassette.Version != cassetteVersion {
+			return nil, fmt.Errorf("apigwtest: unsupported cassette version %d: %s", r.cassette.Version, path)
+		}
+	default:
+		return nil, fmt.Errorf("apigwtest: unknown recorder mode: %d", mode)
+	}
+	return r, nil
+}
+
+// Do Doerの実装
+func (r *Recorder) Do(req *http.Request) (*http.Response, error) {
+	return r.roundTrip(req, r.next.Do)
+}
+
+// Middleware saclient.WithMiddlewareに渡すためのミドルウェア。
+// 再生時は後続のミドルウェア(認証など)を呼び出さずにレスポンスを返す
+func (r *Recorder) Middleware() saclient.Middleware {
+	return func(req *http.Request, pull func() (saclient.Middleware, bool)) (*http.Response, error) {
+		return r.roundTrip(req, func(req *http.Request) (*http.Response, error) {
+			next, ok := pull()
+			if !ok {
+				return nil, errors.New("apigwtest: no next middleware to pull")
+			}
+			return next(req, pull)
+		})
+	}
+}
+
+// Close 記録時はカセットをファイルに書き出す。
+// 再生時は記録された全てのやり取りが消費されたかを検証する
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.mode == ModeReplay {
+		if rest := len(r.cassette.Interactions) - r.pos; rest > 0 {
+			return fmt.Errorf("apigwtest: %d recorded interaction(s) were not replayed, next: %s",
+				rest, r.cassette.Interactions[r.pos].Operation)
+		}
+		return nil
+	}
+
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o750); err != nil {
+		return err
+	}
+	return os.WriteFile(r.path, append(data, '\n'), 0o600)
+}
+
+func (r *Recorder) roundTrip(req *http.Request, send func(*http.Request) (*http.Response, error)) (*http.Response, error) {
+	op, ok := wire.Lookup(req.Method, req.URL.Path)
+	if !ok {
+		return nil, fmt.Errorf("apigwtest: unknown operation: %s %s", req.Method, req.URL.Path)
+	}
+
+	reqBody, err := readBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+	recorded := RecordedRequest{
+		Method: req.Method,
+		Path:   operationPath(op, req.URL.Path),
+		Body:   rawJSON(wire.Redact(op.Request, reqBody)),
+	}
+
+	if r.mode == ModeReplay {
+		return r.replay(req, string(op.Name), recorded)
+	}
+
+	res, err := send(req)
+	if err != nil {
+		return nil, err
+	}
+	resBody, err := readBody(&res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var resType reflect.Type
+	if res.StatusCode < 300 {
+		resType = op.Response
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Operation: string(op.Name),
+		Request:   recorded,
+		Response: RecordedResponse{
+			StatusCode:  res.StatusCode,
+			ContentType: res.Header.Get("Content-Type"),
+			Body:        rawJSON(wire.Redact(resType, resBody)),
+		},
+	})
+	return res, nil
+}
+
+func (r *Recorder) replay(req *http.Request, op string, got RecordedRequest) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.pos >= len(r.cassette.Interactions) {
+		return nil, fmt.Errorf("apigwtest: no more recorded interactions for %s %s", got.Method, got.Path)
+	}
+	want := r.cassette.Interactions[r.pos]
+	if err := matchRequest(op, got, want); err != nil {
+		return nil, fmt.Errorf("apigwtest: interaction #%d mismatch: %w", r.pos, err)
+	}
+	r.pos++
+
+	header := make(http.Header)
+	if want.Response.ContentType != "" {
+		header.Set("Content-Type", want.Response.ContentType)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", want.Response.StatusCode, http.StatusText(want.Response.StatusCode)),
+		StatusCode:    want.Response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(want.Response.Body)),
+		ContentLength: int64(len(want.Response.Body)),
+		Request:       req,
+	}, nil
+}
+
+func matchRequest(op string, got RecordedRequest, want Interaction) error {
+	if op != want.Operation {
+		return fmt.Errorf("operation: want %s, got %s", want.Operation, op)
+	}
+	if got.Method != want.Request.Method || got.Path != want.Request.Path {
+		return fmt.Errorf("%s: want %s %s, got %s %s", op, want.Request.Method, want.Request.Path, got.Method, got.Path)
+	}
+	if !jsonEqual(got.Body, want.Request.Body) {
+		return fmt.Errorf("%s: request body differs:\n want: %s\n  got: %s", op, want.Request.Body, got.Body)
+	}
+	return nil
+}
+
+// operationPath APIルートURLを取り除いた、操作のパスに対応する部分を返す
+func operationPath(op *wire.Operation, path string) string {
+	n := strings.Count(strings.Trim(op.Path, "/"), "/") + 1
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	return "/" + strings.Join(segments[len(segments)-n:], "/")
+}
+
+func readBody(body *io.ReadCloser) ([]byte, error) {
+	if *body == nil || *body == http.NoBody {
+		return nil, nil
+	}
+	data, err := io.ReadAll(*body)
+	_ = (*body).Close()
+	if err != nil {
+		return nil, err
+	}
+	*body = io.NopCloser(bytes.NewReader(data))
+	return data, nil
+}
+
+func rawJSON(data []byte) json.RawMessage {
+	if len(bytes.TrimSpace(data)) == 0 || !json.Valid(data) {
+		return nil
+	}
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, data); err != nil {
+		return nil
+	}
+	return buf.Bytes()
+}
+
+func jsonEqual(a, b json.RawMessage) bool {
+	if len(a) == 0 || len(b) == 0 {
+		return len(a) == len(b)
+	}
+	var va, vb any
+	if json.Unmarshal(a, &va) != nil || json.Unmarshal(b, &vb) != nil {
+		return bytes.Equal(a, b)
+	}
+	return reflect.DeepEqual(va, vb)
+}
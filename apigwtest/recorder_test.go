@@ -0,0 +1,100 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apigwtest_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	apigw "github.com/sacloud/apigw-api-go"
+	"github.com/sacloud/apigw-api-go/apigwtest"
+	v1 "github.com/sacloud/apigw-api-go/apis/v1"
+	"github.com/sacloud/saclient-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRecorderClient(t *testing.T, rootURL string, rec *apigwtest.Recorder) *v1.Client {
+	t.Helper()
+
+	var theClient saclient.Client
+	require.NoError(t, theClient.SetEnviron([]string{"SAKURA_RATE_LIMIT=1000"}))
+	require.NoError(t, theClient.SetWith(saclient.WithoutRetry(), saclient.WithMiddleware(rec.Middleware())))
+	client, err := apigw.NewClientWithAPIRootURL(&theClient, rootURL)
+	require.NoError(t, err)
+	return client
+}
+
+func recorderScenario(ctx context.Context, t *testing.T, client *v1.Client) {
+	userOp := apigw.NewUserOp(client)
+	user, err := userOp.Create(ctx, &v1.UserDetail{Name: "test-user"})
+	require.NoError(t, err)
+
+	userExtraOp := apigw.NewUserExtraOp(client, user.ID.Value)
+	require.NoError(t, userExtraOp.UpdateAuth(ctx, v1.UserAuthentication{
+		BasicAuth: v1.NewOptBasicAuth(v1.BasicAuth{UserName: "test-user", Password: "p@ssw0rd"}),
+	}))
+	auth, err := userExtraOp.ReadAuth(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "test-user", auth.BasicAuth.Value.UserName)
+
+	require.NoError(t, userOp.Delete(ctx, user.ID.Value))
+	_, err = userOp.Read(ctx, user.ID.Value)
+	assert.True(t, apigw.IsNotFound(err))
+}
+
+func TestRecorder(t *testing.T) {
+	cassette := filepath.Join(t.TempDir(), "cassette.json")
+
+	fake := apigwtest.NewServer()
+	rec, err := apigwtest.NewRecorder(cassette, apigwtest.ModeRecord, nil)
+	require.NoError(t, err)
+	recorderScenario(t.Context(), t, newRecorderClient(t, fake.URL, rec))
+	require.NoError(t, rec.Close())
+	fake.Close()
+
+	data, err := os.ReadFile(cassette)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "p@ssw0rd")
+	assert.Contains(t, string(data), `"UpsertUserAuthentication"`)
+
+	// 記録したカセットのみでサーバなしに再生できる
+	rec, err = apigwtest.NewRecorder(cassette, apigwtest.ModeReplay, nil)
+	require.NoError(t, err)
+	recorderScenario(t.Context(), t, newRecorderClient(t, "http://localhost:1/api", rec))
+	require.NoError(t, rec.Close())
+}
+
+func TestRecorder_Mismatch(t *testing.T) {
+	cassette := filepath.Join(t.TempDir(), "cassette.json")
+
+	fake := apigwtest.NewServer()
+	t.Cleanup(fake.Close)
+	rec, err := apigwtest.NewRecorder(cassette, apigwtest.ModeRecord, nil)
+	require.NoError(t, err)
+	_, err = apigw.NewGroupOp(newRecorderClient(t, fake.URL, rec)).Create(t.Context(), &v1.Group{Name: v1.NewOptName("group1")})
+	require.NoError(t, err)
+	require.NoError(t, rec.Close())
+
+	rec, err = apigwtest.NewRecorder(cassette, apigwtest.ModeReplay, nil)
+	require.NoError(t, err)
+	groupOp := apigw.NewGroupOp(newRecorderClient(t, fake.URL, rec))
+
+	_, err = groupOp.Create(t.Context(), &v1.Group{Name: v1.NewOptName("group2")})
+	assert.ErrorContains(t, err, "request body differs")
+	assert.ErrorContains(t, rec.Close(), "1 recorded interaction(s) were not replayed")
+}
@@ -0,0 +1,233 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apigwtest
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	v1 "github.com/sacloud/apigw-api-go/apis/v1"
+)
+
+type route struct {
+	detail v1.RouteDetail
+	// authorization 認可設定。認可設定のないルートではゼロ値となる
+	authorization v1.RouteAuthorizationDetailResponse
+	request       v1.RequestTransformation
+	response      v1.ResponseTransformation
+}
+
+func (s *Server) lookupRoute(w http.ResponseWriter, r *http.Request) (*service, *route, bool) {
+	svc, ok := s.lookupService(w, r)
+	if !ok {
+		return nil, nil, false
+	}
+	id, ok := pathID(w, r, "routeId")
+	if !ok {
+		return nil, nil, false
+	}
+	rt, ok := svc.routes.get(id)
+	if !ok {
+		writeError(w, http.StatusNotFound, "route not found: %s", id)
+		return nil, nil, false
+	}
+	return svc, rt, true
+}
+
+func routeNameConflicts(svc *service, name v1.OptName, self uuid.UUID) bool {
+	if !name.Set {
+		return false
+	}
+	_, ok := svc.routes.find(func(rt *route) bool {
+		return rt.detail.Name == name && rt.detail.ID.Value != self
+	})
+	return ok
+}
+
+func (s *Server) addRoute(w http.ResponseWriter, r *http.Request) {
+	svc, ok := s.lookupService(w, r)
+	if !ok {
+		return
+	}
+	var req v1.RouteDetail
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	if routeNameConflicts(svc, req.Name, uuid.Nil) {
+		writeError(w, http.StatusConflict, "route already exists: %s", req.Name.Value)
+		return
+	}
+
+	ts := now()
+	req.ID = v1.NewOptUUID(uuid.New())
+	req.CreatedAt = v1.NewOptDateTime(ts)
+	req.UpdatedAt = v1.NewOptDateTime(ts)
+	req.ServiceId = svc.detail.ID
+	req.Host = svc.detail.RouteHost
+
+	rt := &route{detail: req}
+	svc.routes.put(req.ID.Value, rt)
+
+	writeJSON(w, http.StatusCreated, &v1.AddRouteCreated{Apigw: v1.AddRouteCreatedApigw{
+		Route: v1.NewOptRouteDetail(rt.detail),
+	}})
+}
+
+func (s *Server) getServiceRoutes(w http.ResponseWriter, r *http.Request) {
+	svc, ok := s.lookupService(w, r)
+	if !ok {
+		return
+	}
+	routes := []v1.Route{}
+	for _, rt := range svc.routes.list() {
+		routes = append(routes, convert[v1.Route](&rt.detail))
+	}
+	writeJSON(w, http.StatusOK, &v1.GetServiceRoutesOK{Apigw: v1.GetServiceRoutesOKApigw{Routes: routes}})
+}
+
+func (s *Server) getRoute(w http.ResponseWriter, r *http.Request) {
+	_, rt, ok := s.lookupRoute(w, r)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, &v1.GetRouteOK{Apigw: v1.GetRouteOKApigw{Route: v1.NewOptRouteDetail(rt.detail)}})
+}
+
+func (s *Server) updateRoute(w http.ResponseWriter, r *http.Request) {
+	svc, rt, ok := s.lookupRoute(w, r)
+	if !ok {
+		return
+	}
+	var req v1.RouteDetail
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	if routeNameConflicts(svc, req.Name, rt.detail.ID.Value) {
+		writeError(w, http.StatusConflict, "route already exists: %s", req.Name.Value)
+		return
+	}
+
+	req.ID = rt.detail.ID
+	req.CreatedAt = rt.detail.CreatedAt
+	req.UpdatedAt = v1.NewOptDateTime(now())
+	req.ServiceId = rt.detail.ServiceId
+	req.Host = rt.detail.Host
+	rt.detail = req
+	writeNoContent(w)
+}
+
+func (s *Server) deleteRoute(w http.ResponseWriter, r *http.Request) {
+	svc, rt, ok := s.lookupRoute(w, r)
+	if !ok {
+		return
+	}
+	svc.routes.delete(rt.detail.ID.Value)
+	writeNoContent(w)
+}
+
+// getRouteAuthorization 認可設定を返す。
+// スキーマはgroupsに1件以上を要求し、認可設定のない状態を表せないため、認可設定のないルートでは404を返す
+func (s *Server) getRouteAuthorization(w http.ResponseWriter, r *http.Request) {
+	_, rt, ok := s.lookupRoute(w, r)
+	if !ok {
+		return
+	}
+	if !rt.authorization.IsACLEnabled {
+		writeError(w, http.StatusNotFound, "route authorization not found: %s", rt.detail.ID.Value)
+		return
+	}
+	writeJSON(w, http.StatusOK, &v1.GetRouteAuthorizationOK{Apigw: v1.GetRouteAuthorizationOKApigw{
+		RouteAuthorization: v1.NewOptRouteAuthorizationDetailResponse(rt.authorization),
+	}})
+}
+
+func (s *Server) upsertRouteAuthorization(w http.ResponseWriter, r *http.Request) {
+	_, rt, ok := s.lookupRoute(w, r)
+	if !ok {
+		return
+	}
+	var req v1.RouteAuthorizationDetail
+	if !decodeBody(w, r, &req) {
+		return
+	}
+
+	if req.Type == v1.RouteAuthorizationDetail0RouteAuthorizationDetail {
+		rt.authorization = v1.RouteAuthorizationDetailResponse{}
+		writeNoContent(w)
+		return
+	}
+
+	groups := make([]v1.RouteAuthorization, 0, len(req.RouteAuthorizationDetail1.Groups))
+	for _, g := range req.RouteAuthorizationDetail1.Groups {
+		group, ok := s.findGroup(g.ID, g.Name)
+		if !ok {
+			writeError(w, http.StatusNotFound, "group not found: %s", groupRef(g.ID, g.Name))
+			return
+		}
+		enabled := g.Enabled
+		if !enabled.Set {
+			enabled = v1.NewOptBool(true)
+		}
+		groups = append(groups, v1.RouteAuthorization{ID: group.ID, Name: group.Name, Enabled: enabled})
+	}
+	rt.authorization = v1.RouteAuthorizationDetailResponse{IsACLEnabled: true, Groups: groups}
+	writeNoContent(w)
+}
+
+func (s *Server) getRequestTransformation(w http.ResponseWriter, r *http.Request) {
+	_, rt, ok := s.lookupRoute(w, r)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, &v1.GetRequestTransformationOK{Apigw: v1.GetRequestTransformationOKApigw{
+		RequestTransformation: v1.NewOptRequestTransformation(rt.request),
+	}})
+}
+
+func (s *Server) upsertRequestTransformation(w http.ResponseWriter, r *http.Request) {
+	_, rt, ok := s.lookupRoute(w, r)
+	if !ok {
+		return
+	}
+	var req v1.RequestTransformation
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	rt.request = req
+	writeNoContent(w)
+}
+
+func (s *Server) getResponseTransformation(w http.ResponseWriter, r *http.Request) {
+	_, rt, ok := s.lookupRoute(w, r)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, &v1.GetResponseTransformationOK{Apigw: v1.GetResponseTransformationOKApigw{
+		ResponseTransformation: v1.NewOptResponseTransformation(rt.response),
+	}})
+}
+
+func (s *Server) upsertResponseTransformation(w http.ResponseWriter, r *http.Request) {
+	_, rt, ok := s.lookupRoute(w, r)
+	if !ok {
+		return
+	}
+	var req v1.ResponseTransformation
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	rt.response = req
+	writeNoContent(w)
+}
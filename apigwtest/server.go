@@ -0,0 +1,273 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package apigwtest APIゲートウェイ APIのインメモリなフェイクサーバ。
+// apigw.NewClientWithAPIRootURLにServer.URLを渡すことで、実際のAPIを使わずにテストできる。
+//
+// 認可設定のないルートのRouteExtraAPI.ReadAuthorizationは、404(apigw.IsNotFoundで判定できる)を返す。
+package apigwtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	"github.com/go-faster/jx"
+	"github.com/google/uuid"
+	v1 "github.com/sacloud/apigw-api-go/apis/v1"
+)
+
+// Server APIゲートウェイ APIの全操作をインメモリの状態に対して実装したhttptest.Server
+type Server struct {
+	*httptest.Server
+
+	mu            sync.Mutex
+	plans         []v1.Plan
+	subscriptions *store[*v1.Subscription]
+	services      *store[*service]
+	users         *store[*user]
+	groups        *store[*v1.Group]
+	domains       *store[*v1.Domain]
+	certificates  *store[*v1.Certificate]
+	oidcs         *store[*v1.Oidc]
+	resourceId    int64
+}
+
+// NewServer フェイクサーバを起動する。利用後はCloseを呼び出すこと
+func NewServer() *Server {
+	s := &Server{
+		plans:         defaultPlans(),
+		subscriptions: newStore[*v1.Subscription](),
+		services:      newStore[*service](),
+		users:         newStore[*user](),
+		groups:        newStore[*v1.Group](),
+		domains:       newStore[*v1.Domain](),
+		certificates:  newStore[*v1.Certificate](),
+		oidcs:         newStore[*v1.Oidc](),
+		resourceId:    113600000000,
+	}
+	s.Server = httptest.NewServer(s.handler())
+	return s
+}
+
+// Plans フェイクサーバが提供するプランの一覧
+func (s *Server) Plans() []v1.Plan {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]v1.Plan(nil), s.plans...)
+}
+
+func (s *Server) handler() http.Handler {
+	mux := http.NewServeMux()
+	handle := func(pattern string, h http.HandlerFunc) {
+		mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			h(w, r)
+		})
+	}
+
+	handle("POST /services", s.addService)
+	handle("GET /services", s.getServices)
+	handle("GET /services/{serviceId}", s.getServiceById)
+	handle("PUT /services/{serviceId}", s.updateService)
+	handle("DELETE /services/{serviceId}", s.deleteService)
+
+	handle("POST /services/{serviceId}/routes", s.addRoute)
+	handle("GET /services/{serviceId}/routes", s.getServiceRoutes)
+	handle("GET /services/{serviceId}/routes/{routeId}", s.getRoute)
+	handle("PUT /services/{serviceId}/routes/{routeId}", s.updateRoute)
+	handle("DELETE /services/{serviceId}/routes/{routeId}", s.deleteRoute)
+	handle("GET /services/{serviceId}/routes/{routeId}/authorization", s.getRouteAuthorization)
+	handle("PUT /services/{serviceId}/routes/{routeId}/authorization", s.upsertRouteAuthorization)
+	handle("GET /services/{serviceId}/routes/{routeId}/request", s.getRequestTransformation)
+	handle("PUT /services/{serviceId}/routes/{routeId}/request", s.upsertRequestTransformation)
+	handle("GET /services/{serviceId}/routes/{routeId}/response", s.getResponseTransformation)
+	handle("PUT /services/{serviceId}/routes/{routeId}/response", s.upsertResponseTransformation)
+
+	handle("POST /users", s.addUser)
+	handle("GET /users", s.getUsers)
+	handle("GET /users/{userId}", s.getUser)
+	handle("PUT /users/{userId}", s.updateUser)
+	handle("DELETE /users/{userId}", s.deleteUser)
+	handle("GET /users/{userId}/groups", s.getUserGroup)
+	handle("PUT /users/{userId}/groups", s.updateUserGroup)
+	handle("GET /users/{userId}/authentication", s.getUserAuthentication)
+	handle("PUT /users/{userId}/authentication", s.upsertUserAuthentication)
+
+	handle("POST /groups", s.addGroup)
+	handle("GET /groups", s.getGroups)
+	handle("GET /groups/{groupId}", s.getGroup)
+	handle("PUT /groups/{groupId}", s.updateGroup)
+	handle("DELETE /groups/{groupId}", s.deleteGroup)
+
+	handle("POST /domains", s.addDomain)
+	handle("GET /domains", s.getDomains)
+	handle("PUT /domains/{domainId}", s.updateDomain)
+	handle("DELETE /domains/{domainId}", s.deleteDomain)
+
+	handle("POST /certificates", s.addCertificate)
+	handle("GET /certificates", s.getCertificates)
+	handle("PUT /certificates/{certificateId}", s.updateCertificate)
+	handle("DELETE /certificates/{certificateId}", s.deleteCertificate)
+
+	handle("GET /plans", s.getPlans)
+	handle("POST /subscriptions", s.subscribe)
+	handle("GET /subscriptions", s.getSubscriptions)
+	handle("GET /subscriptions/{subscriptionId}", s.getSubscriptionById)
+	handle("PUT /subscriptions/{subscriptionId}", s.updateSubscription)
+	handle("DELETE /subscriptions/{subscriptionId}", s.unsubscribe)
+
+	handle("POST /oidc", s.addOidc)
+	handle("GET /oidc", s.getOidc)
+	handle("GET /oidc/{oidcId}", s.getOidcById)
+	handle("PUT /oidc/{oidcId}", s.updateOidc)
+	handle("DELETE /oidc/{oidcId}", s.deleteOidc)
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, "no such endpoint: %s %s", r.Method, r.URL.Path)
+	})
+	return mux
+}
+
+// store 登録順を保持するIDをキーとしたコレクション
+type store[T any] struct {
+	order []uuid.UUID
+	items map[uuid.UUID]T
+}
+
+func newStore[T any]() *store[T] {
+	return &store[T]{items: make(map[uuid.UUID]T)}
+}
+
+func (st *store[T]) get(id uuid.UUID) (T, bool) {
+	v, ok := st.items[id]
+	return v, ok
+}
+
+func (st *store[T]) put(id uuid.UUID, v T) {
+	if _, ok := st.items[id]; !ok {
+		st.order = append(st.order, id)
+	}
+	st.items[id] = v
+}
+
+func (st *store[T]) delete(id uuid.UUID) {
+	if _, ok := st.items[id]; !ok {
+		return
+	}
+	delete(st.items, id)
+	for i, o := range st.order {
+		if o == id {
+			st.order = append(st.order[:i], st.order[i+1:]...)
+			break
+		}
+	}
+}
+
+func (st *store[T]) list() []T {
+	ret := make([]T, 0, len(st.order))
+	for _, id := range st.order {
+		ret = append(ret, st.items[id])
+	}
+	return ret
+}
+
+func (st *store[T]) find(match func(T) bool) (T, bool) {
+	for _, id := range st.order {
+		if v := st.items[id]; match(v) {
+			return v, true
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+func now() time.Time {
+	return time.Now().UTC().Truncate(time.Millisecond)
+}
+
+func pathID(w http.ResponseWriter, r *http.Request, name string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(r.PathValue(name))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid %s: %s", name, r.PathValue(name))
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+type validator interface {
+	Validate() error
+}
+
+// decodeBody リクエストボディをogenの生成した型としてデコードし、定義されていればValidateも行う
+func decodeBody(w http.ResponseWriter, r *http.Request, v json.Unmarshaler) bool {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "failed to read request body: %s", err)
+		return false
+	}
+	if err := v.UnmarshalJSON(body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body: %s", err)
+		return false
+	}
+	if vv, ok := v.(validator); ok {
+		if err := vv.Validate(); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid request body: %s", err)
+			return false
+		}
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, code int, v json.Marshaler) {
+	body, err := v.MarshalJSON()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to encode response: %s", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_, _ = w.Write(body)
+}
+
+func writeError(w http.ResponseWriter, code int, format string, args ...any) {
+	body, _ := (&v1.ErrorSchema{Message: v1.NewOptString(fmt.Sprintf(format, args...))}).MarshalJSON()
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_, _ = w.Write(body)
+}
+
+func writeNoContent(w http.ResponseWriter) {
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// convert 同じJSON表現を持つogenの生成した型同士を変換する
+func convert[T any, PT interface {
+	*T
+	Decode(d *jx.Decoder) error
+}](src interface{ Encode(e *jx.Encoder) }) T {
+	e := new(jx.Encoder)
+	src.Encode(e)
+
+	var dst T
+	if err := PT(&dst).Decode(jx.DecodeBytes(e.Bytes())); err != nil {
+		panic(fmt.Sprintf("apigwtest: conversion to %T failed: %s", dst, err))
+	}
+	return dst
+}
@@ -0,0 +1,214 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apigwtest_test
+
+import (
+	"os"
+	"testing"
+
+	apigw "github.com/sacloud/apigw-api-go"
+	"github.com/sacloud/apigw-api-go/apigwtest"
+	v1 "github.com/sacloud/apigw-api-go/apis/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer_ServiceAndRoute(t *testing.T) {
+	fake, client := apigwtest.NewClient(t)
+	ctx := t.Context()
+
+	subOp := apigw.NewSubscriptionOp(client)
+	plans, err := subOp.ListPlans(ctx)
+	require.NoError(t, err)
+	require.Len(t, plans, len(fake.Plans()))
+	require.NoError(t, subOp.Create(ctx, plans[0].ID.Value, "test-sub"))
+	subs, err := subOp.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, subs, 1)
+
+	serviceOp := apigw.NewServiceOp(client)
+	service, err := serviceOp.Create(ctx, &v1.ServiceDetailRequest{
+		Name:         "test-service",
+		Host:         "example.com",
+		Protocol:     "https",
+		Subscription: v1.ServiceSubscriptionRequest{ID: subs[0].ID.Value},
+	})
+	require.NoError(t, err)
+	assert.NotEmpty(t, service.RouteHost.Value)
+	assert.Equal(t, 443, service.Port.Value)
+
+	_, err = serviceOp.Create(ctx, &v1.ServiceDetailRequest{
+		Name:         "test-service",
+		Host:         "example.com",
+		Protocol:     "https",
+		Subscription: v1.ServiceSubscriptionRequest{ID: subs[0].ID.Value},
+	})
+	assert.True(t, apigw.IsConflict(err))
+
+	sub, err := subOp.Read(ctx, subs[0].ID.Value)
+	require.NoError(t, err)
+	assert.Equal(t, service.ID.Value, sub.Service.Value.ID)
+
+	routeOp := apigw.NewRouteOp(client, service.ID.Value)
+	route, err := routeOp.Create(ctx, &v1.RouteDetail{
+		Name:  v1.NewOptName("test-route"),
+		Path:  v1.NewOptString("/v1"),
+		Hosts: []string{service.RouteHost.Value},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, service.ID.Value, route.ServiceId.Value)
+	assert.Len(t, route.Methods, len(v1.HTTPMethodGET.AllValues()))
+
+	route.StripPath = v1.NewOptBool(true)
+	require.NoError(t, routeOp.Update(ctx, route, route.ID.Value))
+	got, err := routeOp.Read(ctx, route.ID.Value)
+	require.NoError(t, err)
+	assert.True(t, got.StripPath.Value)
+	assert.Equal(t, route.CreatedAt.Value, got.CreatedAt.Value)
+
+	groupOp := apigw.NewGroupOp(client)
+	group, err := groupOp.Create(ctx, &v1.Group{Name: v1.NewOptName("test-group")})
+	require.NoError(t, err)
+
+	// 作成直後のルートには認可設定がない
+	routeExtraOp := apigw.NewRouteExtraOp(client, service.ID.Value, route.ID.Value)
+	_, err = routeExtraOp.ReadAuthorization(ctx)
+	assert.True(t, apigw.IsNotFound(err))
+	require.NoError(t, routeExtraOp.EnableAuthorization(ctx, []v1.RouteAuthorization{{ID: group.ID}}))
+	authz, err := routeExtraOp.ReadAuthorization(ctx)
+	require.NoError(t, err)
+	assert.True(t, authz.IsACLEnabled)
+	require.Len(t, authz.Groups, 1)
+	assert.Equal(t, group.Name.Value, authz.Groups[0].Name.Value)
+
+	// 認可するグループが削除されると認可設定はなくなる
+	require.NoError(t, groupOp.Delete(ctx, group.ID.Value))
+	_, err = routeExtraOp.ReadAuthorization(ctx)
+	assert.True(t, apigw.IsNotFound(err))
+
+	require.NoError(t, routeExtraOp.UpdateRequestTransformation(ctx, &v1.RequestTransformation{
+		HttpMethod: v1.NewOptHTTPMethod(v1.HTTPMethodPOST),
+	}))
+	reqTrans, err := routeExtraOp.ReadRequestTransformation(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, v1.HTTPMethodPOST, reqTrans.HttpMethod.Value)
+
+	// Serviceを削除するとRouteも削除され、サブスクリプションは解放される
+	require.NoError(t, serviceOp.Delete(ctx, service.ID.Value))
+	_, err = routeOp.Read(ctx, route.ID.Value)
+	assert.True(t, apigw.IsNotFound(err))
+	require.NoError(t, subOp.Delete(ctx, subs[0].ID.Value))
+}
+
+func TestServer_UserAndGroup(t *testing.T) {
+	_, client := apigwtest.NewClient(t)
+	ctx := t.Context()
+
+	groupOp := apigw.NewGroupOp(client)
+	group, err := groupOp.Create(ctx, &v1.Group{Name: v1.NewOptName("test-group"), Tags: []string{"Test"}})
+	require.NoError(t, err)
+	_, err = groupOp.Create(ctx, &v1.Group{Name: v1.NewOptName("test-group")})
+	assert.True(t, apigw.IsConflict(err))
+
+	userOp := apigw.NewUserOp(client)
+	user, err := userOp.Create(ctx, &v1.UserDetail{Name: "test-user"})
+	require.NoError(t, err)
+
+	userExtraOp := apigw.NewUserExtraOp(client, user.ID.Value)
+	require.NoError(t, userExtraOp.UpdateGroup(ctx, "test-group", true))
+	groups, err := userExtraOp.ListGroup(ctx)
+	require.NoError(t, err)
+	require.Len(t, groups, 1)
+	assert.True(t, groups[0].IsAssigned)
+
+	require.NoError(t, userExtraOp.UpdateAuth(ctx, v1.UserAuthentication{
+		BasicAuth: v1.NewOptBasicAuth(v1.BasicAuth{UserName: "test-user", Password: "password"}),
+	}))
+	auth, err := userExtraOp.ReadAuth(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "test-user", auth.BasicAuth.Value.UserName)
+
+	// Groupを削除すると所属も外れる
+	require.NoError(t, groupOp.Delete(ctx, group.ID.Value))
+	got, err := userOp.Read(ctx, user.ID.Value)
+	require.NoError(t, err)
+	assert.Empty(t, got.Groups)
+
+	require.NoError(t, userOp.Delete(ctx, user.ID.Value))
+	_, err = userOp.Read(ctx, user.ID.Value)
+	assert.True(t, apigw.IsNotFound(err))
+}
+
+func TestServer_DomainAndCertificate(t *testing.T) {
+	_, client := apigwtest.NewClient(t)
+	ctx := t.Context()
+
+	crt, err := os.ReadFile("../testdata/rsa.crt")
+	require.NoError(t, err)
+	key, err := os.ReadFile("../testdata/rsa.key")
+	require.NoError(t, err)
+
+	certOp := apigw.NewCertificateOp(client)
+	cert, err := certOp.Create(ctx, &v1.Certificate{
+		Name: v1.NewOptName("test-cert"),
+		Rsa: v1.NewOptCertificateDetails(v1.CertificateDetails{
+			Cert: v1.NewOptString(string(crt)),
+			Key:  v1.NewOptString(string(key)),
+		}),
+	})
+	require.NoError(t, err)
+	assert.True(t, cert.Rsa.Value.ExpiredAt.Set)
+
+	domainOp := apigw.NewDomainOp(client)
+	domain, err := domainOp.Create(ctx, &v1.Domain{DomainName: "api.example.com", CertificateId: cert.ID})
+	require.NoError(t, err)
+	assert.Equal(t, "test-cert", domain.CertificateName.Value)
+
+	// Domainから参照されている証明書は削除できない
+	assert.Error(t, certOp.Delete(ctx, cert.ID.Value))
+	require.NoError(t, domainOp.Update(ctx, &v1.DomainPUT{}, domain.ID.Value))
+	require.NoError(t, certOp.Delete(ctx, cert.ID.Value))
+
+	domains, err := domainOp.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, domains, 1)
+	assert.False(t, domains[0].CertificateId.Set)
+	require.NoError(t, domainOp.Delete(ctx, domain.ID.Value))
+}
+
+func TestServer_Oidc(t *testing.T) {
+	_, client := apigwtest.NewClient(t)
+	ctx := t.Context()
+
+	oidcOp := apigw.NewOidcOp(client)
+	oidc, err := oidcOp.Create(ctx, &v1.Oidc{
+		Name:                  "test_oidc",
+		AuthenticationMethods: v1.AuthenticationMethods{v1.AuthenticationMethodsItemAccessToken},
+		Issuer:                "https://idp.example.com",
+		ClientId:              "client",
+		ClientSecret:          "secret",
+		Scopes:                []string{"openid"},
+	})
+	require.NoError(t, err)
+
+	got, err := oidcOp.Read(ctx, oidc.ID.Value)
+	require.NoError(t, err)
+	assert.Equal(t, oidc.Name, got.Name)
+	assert.Empty(t, got.Services)
+
+	require.NoError(t, oidcOp.Delete(ctx, oidc.ID.Value))
+	_, err = oidcOp.Read(ctx, oidc.ID.Value)
+	assert.True(t, apigw.IsNotFound(err))
+}
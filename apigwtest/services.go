@@ -0,0 +1,199 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apigwtest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+
+	"github.com/google/uuid"
+	v1 "github.com/sacloud/apigw-api-go/apis/v1"
+)
+
+type service struct {
+	detail v1.ServiceDetailResponse
+	routes *store[*route]
+}
+
+func (s *Server) lookupService(w http.ResponseWriter, r *http.Request) (*service, bool) {
+	id, ok := pathID(w, r, "serviceId")
+	if !ok {
+		return nil, false
+	}
+	svc, ok := s.services.get(id)
+	if !ok {
+		writeError(w, http.StatusNotFound, "service not found: %s", id)
+		return nil, false
+	}
+	return svc, true
+}
+
+func (s *Server) serviceNameConflicts(name v1.Name, self uuid.UUID) bool {
+	_, ok := s.services.find(func(svc *service) bool {
+		return svc.detail.Name == name && svc.detail.ID.Value != self
+	})
+	return ok
+}
+
+// resolveOidc Serviceに指定されたOIDC認証を検証し、名前を補完する
+func (s *Server) resolveOidc(w http.ResponseWriter, summary *v1.OptOidcSummary) bool {
+	if !summary.Set || !summary.Value.ID.Set {
+		return true
+	}
+	oidc, ok := s.oidcs.get(summary.Value.ID.Value)
+	if !ok {
+		writeError(w, http.StatusNotFound, "oidc not found: %s", summary.Value.ID.Value)
+		return false
+	}
+	summary.Value.Name = v1.NewOptString(string(oidc.Name))
+	return true
+}
+
+func (s *Server) addService(w http.ResponseWriter, r *http.Request) {
+	var req v1.ServiceDetailRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	if s.serviceNameConflicts(req.Name, uuid.Nil) {
+		writeError(w, http.StatusConflict, "service already exists: %s", req.Name)
+		return
+	}
+	sub, ok := s.subscriptions.get(req.Subscription.ID)
+	if !ok {
+		writeError(w, http.StatusNotFound, "subscription not found: %s", req.Subscription.ID)
+		return
+	}
+	if sub.Service.Set {
+		writeError(w, http.StatusConflict, "subscription is already used by service: %s", sub.Service.Value.Name)
+		return
+	}
+	if !s.resolveOidc(w, &req.Oidc) {
+		return
+	}
+
+	ts := now()
+	svc := &service{routes: newStore[*route]()}
+	svc.detail.ID = v1.NewOptUUID(uuid.New())
+	svc.detail.CreatedAt = v1.NewOptDateTime(ts)
+	svc.detail.UpdatedAt = v1.NewOptDateTime(ts)
+	svc.detail.RouteHost = v1.NewOptString(newRouteHost())
+	svc.detail.Subscription = v1.ServiceSubscriptionResponse{ID: sub.ID.Value, Name: string(sub.Name.Value)}
+	applyService(&svc.detail, convert[v1.ServiceDetail](&req))
+	if !svc.detail.Port.Set {
+		svc.detail.Port = v1.NewOptInt(defaultPort(svc.detail.Protocol))
+	}
+
+	s.services.put(svc.detail.ID.Value, svc)
+	sub.Service = v1.NewOptSubscriptionService(v1.SubscriptionService{ID: svc.detail.ID.Value, Name: string(svc.detail.Name)})
+	sub.UpdatedAt = v1.NewOptDateTime(ts)
+
+	writeJSON(w, http.StatusCreated, &v1.AddServiceCreated{Apigw: v1.AddServiceCreatedApigw{
+		Service: v1.NewOptServiceDetailRequest(convert[v1.ServiceDetailRequest](&svc.detail)),
+	}})
+}
+
+func (s *Server) getServices(w http.ResponseWriter, r *http.Request) {
+	services := []v1.ServiceDetailResponse{}
+	for _, svc := range s.services.list() {
+		services = append(services, svc.detail)
+	}
+	writeJSON(w, http.StatusOK, &v1.GetServicesOK{Apigw: v1.GetServicesOKApigw{Services: services}})
+}
+
+func (s *Server) getServiceById(w http.ResponseWriter, r *http.Request) {
+	svc, ok := s.lookupService(w, r)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, &v1.GetServiceByIdOK{Apigw: v1.GetServiceByIdOKApigw{
+		Service: v1.NewOptServiceDetailResponse(svc.detail),
+	}})
+}
+
+func (s *Server) updateService(w http.ResponseWriter, r *http.Request) {
+	svc, ok := s.lookupService(w, r)
+	if !ok {
+		return
+	}
+	var req v1.ServiceDetail
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	if s.serviceNameConflicts(req.Name, svc.detail.ID.Value) {
+		writeError(w, http.StatusConflict, "service already exists: %s", req.Name)
+		return
+	}
+	if !s.resolveOidc(w, &req.Oidc) {
+		return
+	}
+
+	applyService(&svc.detail, req)
+	if !svc.detail.Port.Set {
+		svc.detail.Port = v1.NewOptInt(defaultPort(svc.detail.Protocol))
+	}
+	svc.detail.UpdatedAt = v1.NewOptDateTime(now())
+	if sub, ok := s.subscriptions.get(svc.detail.Subscription.ID); ok {
+		sub.Service = v1.NewOptSubscriptionService(v1.SubscriptionService{ID: svc.detail.ID.Value, Name: string(svc.detail.Name)})
+	}
+	writeNoContent(w)
+}
+
+func (s *Server) deleteService(w http.ResponseWriter, r *http.Request) {
+	svc, ok := s.lookupService(w, r)
+	if !ok {
+		return
+	}
+	s.services.delete(svc.detail.ID.Value)
+	if sub, ok := s.subscriptions.get(svc.detail.Subscription.ID); ok {
+		sub.Service = v1.OptSubscriptionService{}
+		sub.UpdatedAt = v1.NewOptDateTime(now())
+	}
+	writeNoContent(w)
+}
+
+// applyService ServiceDetailの設定可能な項目をdstに反映する
+func applyService(dst *v1.ServiceDetailResponse, src v1.ServiceDetail) {
+	dst.Name = src.Name
+	dst.Tags = src.Tags
+	dst.Protocol = v1.ServiceDetailResponseProtocol(src.Protocol)
+	dst.Host = src.Host
+	dst.Path = src.Path
+	dst.Port = src.Port
+	dst.Retries = src.Retries
+	dst.ConnectTimeout = src.ConnectTimeout
+	dst.WriteTimeout = src.WriteTimeout
+	dst.ReadTimeout = src.ReadTimeout
+	dst.Authentication = v1.OptServiceDetailResponseAuthentication{
+		Value: v1.ServiceDetailResponseAuthentication(src.Authentication.Value),
+		Set:   src.Authentication.Set,
+	}
+	dst.Oidc = src.Oidc
+	dst.CorsConfig = src.CorsConfig
+	dst.ObjectStorageConfig = src.ObjectStorageConfig
+}
+
+func defaultPort(protocol v1.ServiceDetailResponseProtocol) int {
+	if protocol == v1.ServiceDetailResponseProtocolHTTPS {
+		return 443
+	}
+	return 80
+}
+
+func newRouteHost() string {
+	var b [8]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:]) + ".apigw.example.com"
+}
@@ -0,0 +1,185 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apigwtest
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	v1 "github.com/sacloud/apigw-api-go/apis/v1"
+)
+
+// maxSubscriptions フェイクサーバで契約できるサブスクリプションの最大数
+const maxSubscriptions = 10
+
+func defaultPlans() []v1.Plan {
+	ts := now()
+	plan := func(name, price string, maxServices, maxRequests int) v1.Plan {
+		return v1.Plan{
+			ID:              v1.NewOptUUID(uuid.New()),
+			CreatedAt:       v1.NewOptDateTime(ts),
+			UpdatedAt:       v1.NewOptDateTime(ts),
+			Name:            v1.NewOptString(name),
+			Price:           v1.NewOptString(price),
+			Description:     v1.NewOptString(name + " plan"),
+			MaxServices:     v1.NewOptInt(maxServices),
+			MaxRequests:     v1.NewOptInt(maxRequests),
+			MaxRequestsUnit: v1.NewOptPlanMaxRequestsUnit(v1.PlanMaxRequestsUnitMonth),
+			Overage: v1.NewOptOverage(v1.Overage{
+				UnitRequests: v1.NewOptInt(1000000),
+				UnitPrice:    v1.NewOptString("100"),
+			}),
+		}
+	}
+	return []v1.Plan{
+		plan("basic", "1000", 1, 1000000),
+		plan("standard", "5000", 1, 10000000),
+	}
+}
+
+func (s *Server) findPlan(id uuid.UUID) (v1.Plan, bool) {
+	for _, p := range s.plans {
+		if p.ID.Value == id {
+			return p, true
+		}
+	}
+	return v1.Plan{}, false
+}
+
+func (s *Server) lookupSubscription(w http.ResponseWriter, r *http.Request) (*v1.Subscription, bool) {
+	id, ok := pathID(w, r, "subscriptionId")
+	if !ok {
+		return nil, false
+	}
+	sub, ok := s.subscriptions.get(id)
+	if !ok {
+		writeError(w, http.StatusNotFound, "subscription not found: %s", id)
+		return nil, false
+	}
+	return sub, true
+}
+
+func (s *Server) getPlans(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, &v1.GetPlansOK{Apigw: v1.GetPlansOKApigw{Plans: s.plans}})
+}
+
+func (s *Server) subscribe(w http.ResponseWriter, r *http.Request) {
+	var req v1.SubscriptionCreate
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	if _, ok := s.findPlan(req.PlanId); !ok {
+		writeError(w, http.StatusBadRequest, "plan not found: %s", req.PlanId)
+		return
+	}
+	if len(s.subscriptions.list()) >= maxSubscriptions {
+		writeError(w, http.StatusBadRequest, "maximum number of subscriptions reached")
+		return
+	}
+
+	ts := now()
+	s.resourceId++
+	sub := &v1.Subscription{
+		ID:             v1.NewOptUUID(uuid.New()),
+		CreatedAt:      v1.NewOptDateTime(ts),
+		UpdatedAt:      v1.NewOptDateTime(ts),
+		Name:           v1.NewOptName(v1.Name(req.Name)),
+		PlanId:         v1.NewOptUUID(req.PlanId),
+		ResourceId:     v1.NewOptInt64(s.resourceId),
+		MonthlyRequest: v1.NewOptInt(0),
+	}
+	s.subscriptions.put(sub.ID.Value, sub)
+	writeNoContent(w)
+}
+
+func (s *Server) getSubscriptions(w http.ResponseWriter, r *http.Request) {
+	subs := []v1.Subscription{}
+	for _, sub := range s.subscriptions.list() {
+		subs = append(subs, *sub)
+	}
+	writeJSON(w, http.StatusOK, &v1.GetSubscriptionsOK{Apigw: v1.SubscriptionList{
+		Subscriptions:   subs,
+		MaxSubscription: v1.NewOptInt(maxSubscriptions),
+	}})
+}
+
+func (s *Server) getSubscriptionById(w http.ResponseWriter, r *http.Request) {
+	sub, ok := s.lookupSubscription(w, r)
+	if !ok {
+		return
+	}
+	detail := v1.SubscriptionDetailResponse{
+		ID:             sub.ID,
+		CreatedAt:      sub.CreatedAt,
+		UpdatedAt:      sub.UpdatedAt,
+		Name:           sub.Name,
+		ResourceId:     sub.ResourceId,
+		MonthlyRequest: sub.MonthlyRequest,
+		Service:        sub.Service,
+	}
+	if plan, ok := s.findPlan(sub.PlanId.Value); ok {
+		detail.Plan = v1.NewOptSubscriptionPlanResponse(v1.SubscriptionPlanResponse{
+			PlanID:      plan.ID,
+			PlanName:    plan.Name,
+			Price:       plan.Price,
+			MaxServices: plan.MaxServices,
+			MaxRequests: plan.MaxRequests,
+			MaxRequestsUnit: v1.OptSubscriptionPlanResponseMaxRequestsUnit{
+				Value: v1.SubscriptionPlanResponseMaxRequestsUnit(plan.MaxRequestsUnit.Value),
+				Set:   plan.MaxRequestsUnit.Set,
+			},
+			Overage: plan.Overage,
+		})
+	}
+	writeJSON(w, http.StatusOK, &v1.GetSubscriptionByIdOK{Apigw: v1.GetSubscriptionByIdOKApigw{
+		Subscription: v1.NewOptSubscriptionDetailResponse(detail),
+	}})
+}
+
+func (s *Server) updateSubscription(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r, "subscriptionId")
+	if !ok {
+		return
+	}
+	sub, ok := s.subscriptions.get(id)
+	if !ok {
+		// updateSubscriptionには404が定義されていない
+		writeError(w, http.StatusBadRequest, "subscription not found: %s", id)
+		return
+	}
+	var req v1.SubscriptionUpdate
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	sub.Name = v1.NewOptName(v1.Name(req.Name))
+	sub.UpdatedAt = v1.NewOptDateTime(now())
+	if svc, ok := s.services.get(sub.Service.Value.ID); ok && sub.Service.Set {
+		svc.detail.Subscription.Name = req.Name
+	}
+	writeNoContent(w)
+}
+
+func (s *Server) unsubscribe(w http.ResponseWriter, r *http.Request) {
+	sub, ok := s.lookupSubscription(w, r)
+	if !ok {
+		return
+	}
+	if sub.Service.Set {
+		writeError(w, http.StatusBadRequest, "subscription is used by service: %s", sub.Service.Value.Name)
+		return
+	}
+	s.subscriptions.delete(sub.ID.Value)
+	writeNoContent(w)
+}
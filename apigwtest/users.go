@@ -0,0 +1,223 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apigwtest
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+	v1 "github.com/sacloud/apigw-api-go/apis/v1"
+)
+
+type user struct {
+	detail v1.UserDetail
+	groups map[uuid.UUID]bool
+	auth   v1.UserAuthentication
+}
+
+func (s *Server) lookupUser(w http.ResponseWriter, r *http.Request) (*user, bool) {
+	id, ok := pathID(w, r, "userId")
+	if !ok {
+		return nil, false
+	}
+	u, ok := s.users.get(id)
+	if !ok {
+		writeError(w, http.StatusNotFound, "user not found: %s", id)
+		return nil, false
+	}
+	return u, true
+}
+
+func (s *Server) userNameConflicts(name v1.Name, self uuid.UUID) bool {
+	_, ok := s.users.find(func(u *user) bool {
+		return u.detail.Name == name && u.detail.ID.Value != self
+	})
+	return ok
+}
+
+// userDetail 所属Groupを反映したUserDetailを返す
+func (s *Server) userDetail(u *user) v1.UserDetail {
+	detail := u.detail
+	detail.Groups = []v1.Group{}
+	for _, g := range s.groups.list() {
+		if u.groups[g.ID.Value] {
+			detail.Groups = append(detail.Groups, *g)
+		}
+	}
+	return detail
+}
+
+func (s *Server) addUser(w http.ResponseWriter, r *http.Request) {
+	var req v1.UserDetail
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	if s.userNameConflicts(req.Name, uuid.Nil) {
+		writeError(w, http.StatusConflict, "user already exists: %s", req.Name)
+		return
+	}
+
+	u := &user{groups: make(map[uuid.UUID]bool)}
+	for _, g := range req.Groups {
+		group, ok := s.findGroup(g.ID, g.Name)
+		if !ok {
+			writeError(w, http.StatusBadRequest, "group not found: %s", groupRef(g.ID, g.Name))
+			return
+		}
+		u.groups[group.ID.Value] = true
+	}
+
+	ts := now()
+	req.ID = v1.NewOptUUID(uuid.New())
+	req.CreatedAt = v1.NewOptDateTime(ts)
+	req.UpdatedAt = v1.NewOptDateTime(ts)
+	req.Groups = nil
+	u.detail = req
+	s.users.put(req.ID.Value, u)
+
+	writeJSON(w, http.StatusCreated, &v1.AddUserCreated{Apigw: v1.AddUserCreatedApigw{
+		User: v1.NewOptUserDetail(s.userDetail(u)),
+	}})
+}
+
+func (s *Server) getUsers(w http.ResponseWriter, r *http.Request) {
+	users := []v1.User{}
+	for _, u := range s.users.list() {
+		detail := s.userDetail(u)
+		users = append(users, convert[v1.User](&detail))
+	}
+	writeJSON(w, http.StatusOK, &v1.GetUsersOK{Apigw: v1.GetUsersOKApigw{Users: users}})
+}
+
+func (s *Server) getUser(w http.ResponseWriter, r *http.Request) {
+	u, ok := s.lookupUser(w, r)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, &v1.GetUserOK{Apigw: v1.GetUserOKApigw{User: v1.NewOptUserDetail(s.userDetail(u))}})
+}
+
+func (s *Server) updateUser(w http.ResponseWriter, r *http.Request) {
+	u, ok := s.lookupUser(w, r)
+	if !ok {
+		return
+	}
+	var req v1.UserDetail
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	if s.userNameConflicts(req.Name, u.detail.ID.Value) {
+		writeError(w, http.StatusConflict, "user already exists: %s", req.Name)
+		return
+	}
+
+	u.detail.Name = req.Name
+	u.detail.CustomID = req.CustomID
+	u.detail.Tags = req.Tags
+	u.detail.IpRestrictionConfig = req.IpRestrictionConfig
+	u.detail.UpdatedAt = v1.NewOptDateTime(now())
+	writeNoContent(w)
+}
+
+func (s *Server) deleteUser(w http.ResponseWriter, r *http.Request) {
+	u, ok := s.lookupUser(w, r)
+	if !ok {
+		return
+	}
+	s.users.delete(u.detail.ID.Value)
+	writeNoContent(w)
+}
+
+func (s *Server) getUserGroup(w http.ResponseWriter, r *http.Request) {
+	u, ok := s.lookupUser(w, r)
+	if !ok {
+		return
+	}
+	groups := []v1.UserGroupDetail{}
+	for _, g := range s.groups.list() {
+		groups = append(groups, v1.UserGroupDetail{ID: g.ID.Value, Name: g.Name.Value, IsAssigned: u.groups[g.ID.Value]})
+	}
+	writeJSON(w, http.StatusOK, &v1.GetUserGroupOK{Apigw: v1.GetUserGroupOKApigw{Groups: groups}})
+}
+
+func (s *Server) updateUserGroup(w http.ResponseWriter, r *http.Request) {
+	u, ok := s.lookupUser(w, r)
+	if !ok {
+		return
+	}
+	var req []struct {
+		IsAssigned bool       `json:"isAssigned"`
+		ID         *uuid.UUID `json:"id"`
+		Name       *string    `json:"name"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body: %s", err)
+		return
+	}
+
+	assignments := make(map[uuid.UUID]bool, len(req))
+	for _, item := range req {
+		var id v1.OptUUID
+		var name v1.OptName
+		switch {
+		case item.ID != nil:
+			id = v1.NewOptUUID(*item.ID)
+		case item.Name != nil:
+			name = v1.NewOptName(v1.Name(*item.Name))
+		default:
+			writeError(w, http.StatusBadRequest, "id or name is required")
+			return
+		}
+		group, ok := s.findGroup(id, name)
+		if !ok {
+			writeError(w, http.StatusNotFound, "group not found: %s", groupRef(id, name))
+			return
+		}
+		assignments[group.ID.Value] = item.IsAssigned
+	}
+
+	for id, assigned := range assignments {
+		if assigned {
+			u.groups[id] = true
+		} else {
+			delete(u.groups, id)
+		}
+	}
+	writeNoContent(w)
+}
+
+func (s *Server) getUserAuthentication(w http.ResponseWriter, r *http.Request) {
+	u, ok := s.lookupUser(w, r)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, &v1.GetUserAuthenticationOK{Apigw: v1.GetUserAuthenticationOKApigw{
+		UserAuthentication: v1.NewOptUserAuthentication(u.auth),
+	}})
+}
+
+func (s *Server) upsertUserAuthentication(w http.ResponseWriter, r *http.Request) {
+	u, ok := s.lookupUser(w, r)
+	if !ok {
+		return
+	}
+	var req v1.UserAuthentication
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	u.auth = req
+	writeNoContent(w)
+}
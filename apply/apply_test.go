@@ -0,0 +1,242 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apply_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	apigw "github.com/sacloud/apigw-api-go"
+	"github.com/sacloud/apigw-api-go/apigwtest"
+	v1 "github.com/sacloud/apigw-api-go/apis/v1"
+	"github.com/sacloud/apigw-api-go/apply"
+	"github.com/sacloud/apigw-api-go/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const document = `
+version: 1
+certificates:
+  - name: test-cert
+    rsa:
+      certFile: {{testdata}}/rsa.crt
+      keyFile: {{testdata}}/rsa.key
+domains:
+  - name: api.example.com
+    certificate: test-cert
+groups:
+  - name: admins
+  - name: developers
+    tags: [dev]
+users:
+  - name: alice
+    groups: [admins, developers]
+    authentication:
+      basicAuth:
+        userName: alice
+        password: secret
+services:
+  - name: backend
+    subscription: test-sub
+    protocol: https
+    host: backend.example.com
+    routes:
+      - name: api
+        path: /api
+        stripPath: true
+        authorization:
+          groups:
+            - name: admins
+        requestTransformation:
+          httpMethod: POST
+      - name: health
+        path: /health
+`
+
+func newClient(t *testing.T) *v1.Client {
+	t.Helper()
+
+	fake, client := apigwtest.NewClient(t)
+	require.NoError(t, apigw.NewSubscriptionOp(client).Create(t.Context(), fake.Plans()[0].ID.Value, "test-sub"))
+	return client
+}
+
+func loadDocument(t *testing.T, src string) *spec.Document {
+	t.Helper()
+
+	testdata, err := filepath.Abs("../testdata")
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "apigw.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(strings.ReplaceAll(src, "{{testdata}}", testdata)), 0o600))
+
+	doc, err := spec.Load(path)
+	require.NoError(t, err)
+	return doc
+}
+
+func TestPlanner(t *testing.T) {
+	client := newClient(t)
+	ctx := t.Context()
+	planner := apply.NewPlanner(client)
+
+	doc := loadDocument(t, document)
+	plan, err := planner.Plan(ctx, doc)
+	require.NoError(t, err)
+	create, update, del := plan.Summary()
+	assert.Equal(t, []int{12, 0, 0}, []int{create, update, del}, plan.String())
+	assert.Contains(t, plan.String(), `+ route "backend/api"`)
+	require.NoError(t, planner.Apply(ctx, plan))
+
+	// 適用後は変更がない
+	plan, err = planner.Plan(ctx, doc)
+	require.NoError(t, err)
+	assert.True(t, plan.Empty(), plan.String())
+
+	routes, err := apigw.NewRouteOp(client, mustServiceID(t, client, "backend")).List(ctx)
+	require.NoError(t, err)
+	require.Len(t, routes, 2)
+
+	// 設定を変更し、ルートを1つ削除する
+	doc.Users[0].Groups = []string{"developers"}
+	doc.Users[0].Authentication.BasicAuth.Value.Password = "changed"
+	doc.Services[0].Routes[0].StripPath = nil
+	doc.Services[0].Routes[0].Path = "/api/v2"
+	doc.Services[0].Routes = doc.Services[0].Routes[:1]
+	plan, err = planner.Plan(ctx, doc)
+	require.NoError(t, err)
+	out := plan.String()
+	assert.Contains(t, out, `~ user.groups "alice"`)
+	assert.Contains(t, out, `basicAuth.password: "********" => "********"`)
+	assert.NotContains(t, out, "changed")
+	assert.Contains(t, out, `path: "/api" => "/api/v2"`)
+	assert.Contains(t, out, `- route "backend/health"`)
+	require.NoError(t, planner.Apply(ctx, plan))
+
+	plan, err = planner.Plan(ctx, doc)
+	require.NoError(t, err)
+	assert.True(t, plan.Empty(), plan.String())
+
+	// 省略した項目は維持される
+	route, err := apigw.NewRouteOp(client, routes[0].ServiceId.Value).Read(ctx, routes[0].ID.Value)
+	require.NoError(t, err)
+	assert.True(t, route.StripPath.Value)
+}
+
+func TestPlanner_Prune(t *testing.T) {
+	client := newClient(t)
+	ctx := t.Context()
+	planner := apply.NewPlanner(client)
+
+	plan, err := planner.Plan(ctx, loadDocument(t, document))
+	require.NoError(t, err)
+	require.NoError(t, planner.Apply(ctx, plan))
+
+	doc := loadDocument(t, "version: 1\ngroups:\n  - name: admins\n")
+	plan, err = planner.Plan(ctx, doc)
+	require.NoError(t, err)
+	assert.True(t, plan.Empty(), plan.String())
+
+	planner.Prune = true
+	plan, err = planner.Plan(ctx, doc)
+	require.NoError(t, err)
+	_, _, del := plan.Summary()
+	assert.Equal(t, 7, del, plan.String())
+	require.NoError(t, planner.Apply(ctx, plan))
+
+	groups, err := apigw.NewGroupOp(client).List(ctx)
+	require.NoError(t, err)
+	require.Len(t, groups, 1)
+	assert.Equal(t, "admins", string(groups[0].Name.Value))
+}
+
+func TestPlanner_Authorization(t *testing.T) {
+	client := newClient(t)
+	ctx := t.Context()
+	planner := apply.NewPlanner(client)
+
+	// 認可設定のないルートの認可設定を読み込める
+	doc := loadDocument(t, document)
+	doc.Services[0].Routes[0].Authorization.Groups = nil
+	plan, err := planner.Plan(ctx, doc)
+	require.NoError(t, err)
+	require.NoError(t, planner.Apply(ctx, plan))
+	plan, err = planner.Plan(ctx, doc)
+	require.NoError(t, err)
+	assert.True(t, plan.Empty(), plan.String())
+
+	doc = loadDocument(t, document)
+	plan, err = planner.Plan(ctx, doc)
+	require.NoError(t, err)
+	assert.Contains(t, plan.String(), `~ route.authorization "backend/api"`)
+	require.NoError(t, planner.Apply(ctx, plan))
+}
+
+func TestPlanner_Order(t *testing.T) {
+	client := newClient(t)
+	ctx := t.Context()
+	planner := apply.NewPlanner(client)
+
+	doc := loadDocument(t, document)
+	doc.Groups[1].Tags = []string{"dev", "ops"}
+	doc.Services[0].Routes[0].Methods = []v1.HTTPMethod{v1.HTTPMethodGET, v1.HTTPMethodPOST}
+	plan, err := planner.Plan(ctx, doc)
+	require.NoError(t, err)
+	require.NoError(t, planner.Apply(ctx, plan))
+
+	// 文字列の配列は順序を無視して比較する
+	doc.Groups[1].Tags = []string{"ops", "dev"}
+	doc.Services[0].Routes[0].Methods = []v1.HTTPMethod{v1.HTTPMethodPOST, v1.HTTPMethodGET}
+	plan, err = planner.Plan(ctx, doc)
+	require.NoError(t, err)
+	assert.True(t, plan.Empty(), plan.String())
+
+	doc.Services[0].Routes[0].Methods = []v1.HTTPMethod{v1.HTTPMethodPOST}
+	plan, err = planner.Plan(ctx, doc)
+	require.NoError(t, err)
+	require.Len(t, plan.Changes, 1, plan.String())
+	assert.Contains(t, plan.String(), `methods: ["GET","POST"] => ["POST"]`)
+}
+
+func TestPlanner_UnknownReference(t *testing.T) {
+	client := newClient(t)
+
+	doc := loadDocument(t, document)
+	doc.Services[0].Subscription = "unknown"
+	_, err := apply.NewPlanner(client).Plan(t.Context(), doc)
+	assert.ErrorContains(t, err, `subscription "unknown" not found`)
+
+	doc = loadDocument(t, document)
+	doc.Users[0].Groups = append(doc.Users[0].Groups, "unknown")
+	_, err = apply.NewPlanner(client).Plan(t.Context(), doc)
+	assert.ErrorContains(t, err, `group "unknown" not found`)
+}
+
+func mustServiceID(t *testing.T, client *v1.Client, name string) uuid.UUID {
+	t.Helper()
+
+	services, err := apigw.NewServiceOp(client).List(t.Context())
+	require.NoError(t, err)
+	for _, s := range services {
+		if string(s.Name) == name {
+			return s.ID.Value
+		}
+	}
+	t.Fatalf("service %q not found", name)
+	return uuid.Nil
+}
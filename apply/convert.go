@@ -0,0 +1,244 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apply
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	v1 "github.com/sacloud/apigw-api-go/apis/v1"
+	"github.com/sacloud/apigw-api-go/diff"
+	"github.com/sacloud/apigw-api-go/internal/jsondiff"
+	"github.com/sacloud/apigw-api-go/internal/wire"
+	"github.com/sacloud/apigw-api-go/spec"
+)
+
+func certificateDetails(pair *spec.KeyPair) v1.OptCertificateDetails {
+	if pair == nil {
+		return v1.OptCertificateDetails{}
+	}
+	return v1.NewOptCertificateDetails(v1.CertificateDetails{
+		Cert: v1.NewOptString(pair.Cert),
+		Key:  v1.NewOptString(pair.Key),
+	})
+}
+
+// certificateExpiry PEM形式の証明書の有効期限を返す
+func certificateExpiry(cert string) (time.Time, error) {
+	block, _ := pem.Decode([]byte(cert))
+	if block == nil {
+		return time.Time{}, errors.New("no PEM encoded certificate found")
+	}
+	c, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return c.NotAfter, nil
+}
+
+func userDetail(u *spec.User) v1.UserDetail {
+	ret := v1.UserDetail{Name: v1.Name(u.Name), Tags: u.Tags}
+	if u.CustomID != "" {
+		ret.CustomID = v1.NewOptString(u.CustomID)
+	}
+	if u.IpRestrictionConfig != nil {
+		ret.IpRestrictionConfig = v1.NewOptIpRestrictionConfig(*u.IpRestrictionConfig)
+	}
+	return ret
+}
+
+func oidcDetail(o *spec.Oidc) v1.Oidc {
+	return v1.Oidc{
+		Name:                  v1.Name(o.Name),
+		AuthenticationMethods: o.AuthenticationMethods,
+		Issuer:                o.Issuer,
+		ClientId:              o.ClientID,
+		ClientSecret:          o.ClientSecret,
+		Scopes:                o.Scopes,
+		HideCredentials:       optBool(o.HideCredentials),
+		TokenAudiences:        o.TokenAudiences,
+		UseSession:            optBool(o.UseSession),
+	}
+}
+
+func serviceDetail(s *spec.Service) v1.ServiceDetail {
+	ret := v1.ServiceDetail{
+		Name:           v1.Name(s.Name),
+		Tags:           s.Tags,
+		Protocol:       v1.ServiceDetailProtocol(s.Protocol),
+		Host:           s.Host,
+		Port:           optInt(s.Port),
+		Retries:        optInt(s.Retries),
+		ConnectTimeout: optInt(s.ConnectTimeout),
+		WriteTimeout:   optInt(s.WriteTimeout),
+		ReadTimeout:    optInt(s.ReadTimeout),
+	}
+	if s.Path != "" {
+		ret.Path = v1.NewOptString(s.Path)
+	}
+	if s.Authentication != "" {
+		ret.Authentication = v1.NewOptServiceDetailAuthentication(v1.ServiceDetailAuthentication(s.Authentication))
+	}
+	if s.CorsConfig != nil {
+		ret.CorsConfig = v1.NewOptCorsConfig(*s.CorsConfig)
+	}
+	if s.ObjectStorageConfig != nil {
+		ret.ObjectStorageConfig = v1.NewOptObjectStorageConfig(*s.ObjectStorageConfig)
+	}
+	return ret
+}
+
+func routeDetail(r *spec.Route) v1.RouteDetail {
+	ret := v1.RouteDetail{
+		Name:              v1.NewOptName(v1.Name(r.Name)),
+		Tags:              r.Tags,
+		Hosts:             r.Hosts,
+		Methods:           r.Methods,
+		RegexPriority:     optInt(r.RegexPriority),
+		StripPath:         optBool(r.StripPath),
+		PreserveHost:      optBool(r.PreserveHost),
+		RequestBuffering:  optBool(r.RequestBuffering),
+		ResponseBuffering: optBool(r.ResponseBuffering),
+	}
+	if r.Protocols != "" {
+		ret.Protocols = v1.NewOptRouteDetailProtocols(v1.RouteDetailProtocols(r.Protocols))
+	}
+	if r.Path != "" {
+		ret.Path = v1.NewOptString(r.Path)
+	}
+	if r.HttpsRedirectStatusCode != nil {
+		ret.HttpsRedirectStatusCode = v1.NewOptRouteDetailHttpsRedirectStatusCode(v1.RouteDetailHttpsRedirectStatusCode(*r.HttpsRedirectStatusCode))
+	}
+	if r.IpRestrictionConfig != nil {
+		ret.IpRestrictionConfig = v1.NewOptIpRestrictionConfig(*r.IpRestrictionConfig)
+	}
+	return ret
+}
+
+func optInt(v *int) v1.OptInt {
+	if v == nil {
+		return v1.OptInt{}
+	}
+	return v1.NewOptInt(*v)
+}
+
+func optBool(v *bool) v1.OptBool {
+	if v == nil {
+		return v1.OptBool{}
+	}
+	return v1.NewOptBool(*v)
+}
+
+// compare desiredに含まれる項目のうちcurrentと異なるものを返す。
+// 比較はdiff.Compareに従い、文字列や数値の配列は順序を無視する。
+// 表示用の値はdesiredの型の`mask:"true"`タグに従って置き換える
+func compare[T any, PT interface {
+	*T
+	json.Marshaler
+	json.Unmarshaler
+}](current json.Marshaler, desired PT) ([]Difference, error) {
+	var from, to T
+	if err := jsondiff.Overlay(PT(&from), current, nil); err != nil {
+		return nil, err
+	}
+	if err := jsondiff.Overlay(PT(&to), current, desired); err != nil {
+		return nil, err
+	}
+	changes := diff.Compare(from, to)
+	if len(changes) == 0 {
+		return nil, nil
+	}
+
+	// 配列の要素の追加・削除は同じパスに複数並ぶため、配列全体の変更として1つにまとめる
+	cur, err := PT(&from).MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	merged, err := PT(&to).MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	t := reflect.TypeOf(desired)
+	var before, after any
+	if err := json.Unmarshal(wire.Redact(t, cur), &before); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(wire.Redact(t, merged), &after); err != nil {
+		return nil, err
+	}
+	diffs := make([]Difference, 0, len(changes))
+	seen := make(map[string]bool, len(changes))
+	for _, c := range changes {
+		if seen[c.Path] {
+			continue
+		}
+		seen[c.Path] = true
+		diffs = append(diffs, Difference{Path: c.Path, From: lookup(before, c.Path), To: lookup(after, c.Path)})
+	}
+	return diffs, nil
+}
+
+// lookup デコードしたJSONからdiff.Changeのパスが指す値を返す。存在しない場合はnilを返す
+func lookup(v any, path string) any {
+	if path == "" {
+		return v
+	}
+	for _, seg := range strings.Split(path, ".") {
+		name, rest, _ := strings.Cut(seg, "[")
+		if name != "" {
+			obj, ok := v.(map[string]any)
+			if !ok {
+				return nil
+			}
+			v = obj[name]
+		}
+		for rest != "" {
+			idx, after, _ := strings.Cut(rest, "]")
+			i, err := strconv.Atoi(idx)
+			arr, ok := v.([]any)
+			if err != nil || !ok || i < 0 || i >= len(arr) {
+				return nil
+			}
+			v = arr[i]
+			rest = strings.TrimPrefix(after, "[")
+		}
+	}
+	return v
+}
+
+// convert srcsを順にマージしたJSONをdstにデコードする
+func convert(dst json.Unmarshaler, srcs ...json.Marshaler) error {
+	var merged []byte
+	for _, src := range srcs {
+		data, err := src.MarshalJSON()
+		if err != nil {
+			return err
+		}
+		if merged == nil {
+			merged = data
+			continue
+		}
+		if merged, err = jsondiff.Merge(merged, data); err != nil {
+			return err
+		}
+	}
+	return dst.UnmarshalJSON(merged)
+}
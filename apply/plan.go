@@ -0,0 +1,127 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apply
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/google/uuid"
+	"github.com/sacloud/apigw-api-go/internal/jsondiff"
+	"github.com/sacloud/apigw-api-go/internal/planfmt"
+)
+
+// Action 変更の種別
+type Action = planfmt.Action
+
+const (
+	ActionCreate = planfmt.Create
+	ActionUpdate = planfmt.Update
+	ActionDelete = planfmt.Delete
+)
+
+// Kind 変更対象のリソースの種別
+type Kind string
+
+const (
+	KindCertificate            Kind = "certificate"
+	KindDomain                 Kind = "domain"
+	KindGroup                  Kind = "group"
+	KindUser                   Kind = "user"
+	KindUserGroups             Kind = "user.groups"
+	KindUserAuthentication     Kind = "user.authentication"
+	KindService                Kind = "service"
+	KindRoute                  Kind = "route"
+	KindRouteAuthorization     Kind = "route.authorization"
+	KindRequestTransformation  Kind = "route.requestTransformation"
+	KindResponseTransformation Kind = "route.responseTransformation"
+	KindSubscription           Kind = "subscription"
+	KindOidc                   Kind = "oidc"
+)
+
+// Difference 更新される項目。From/ToはJSONとしてデコードした値で、秘匿情報は置き換えられている
+type Difference struct {
+	Path string
+	From any
+	To   any
+}
+
+func (d Difference) String() string {
+	return fmt.Sprintf("%s: %s => %s", d.Path, jsondiff.Format(d.From), jsondiff.Format(d.To))
+}
+
+// Change 1つのリソースに対する変更。
+// Nameはリソースの名前で、ルートとその設定は"サービス名/ルート名"となる
+type Change struct {
+	Action Action
+	Kind   Kind
+	Name   string
+	// Diffs 更新される項目。ActionUpdateの場合のみ設定される
+	Diffs []Difference
+
+	run func(ctx context.Context, e *executor) error
+}
+
+func (c *Change) String() string {
+	return fmt.Sprintf("%s %s %q", c.Action, c.Kind, c.Name)
+}
+
+// Plan ドキュメントと現在の状態の差分から計算した変更の一覧。
+// Changesは適用する順に並んでいる
+type Plan struct {
+	Changes []*Change
+
+	// ids 計画時点で存在するリソースのID
+	ids map[ref]uuid.UUID
+}
+
+type ref struct {
+	kind Kind
+	name string
+}
+
+// Empty 変更がない場合にtrueを返す
+func (p *Plan) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+// Summary 作成・更新・削除の件数を返す
+func (p *Plan) Summary() (create, update, delete int) {
+	counts := planfmt.Count(p.Changes, func(c *Change) Action { return c.Action })
+	return counts[ActionCreate], counts[ActionUpdate], counts[ActionDelete]
+}
+
+// Print 変更の一覧を人が読める形式でwに出力する
+func (p *Plan) Print(w io.Writer) error {
+	_, err := io.WriteString(w, p.String())
+	return err
+}
+
+func (p *Plan) String() string {
+	if p.Empty() {
+		return "No changes.\n"
+	}
+
+	lines := make([]planfmt.Line, len(p.Changes))
+	for i, c := range p.Changes {
+		lines[i] = planfmt.Line{Action: c.Action, Kind: string(c.Kind), Name: c.Name}
+		for _, d := range c.Diffs {
+			lines[i].Details = append(lines[i].Details, d.String())
+		}
+	}
+	create, update, del := p.Summary()
+	return planfmt.Format(lines, fmt.Sprintf("Plan: %d to create, %d to update, %d to delete.", create, update, del))
+}
@@ -0,0 +1,323 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package apply 宣言的に記述した設定(spec.Document)とアカウントの現在の状態を比較して変更を計画し、適用する。
+//
+//	planner := apply.NewPlanner(client)
+//	plan, err := planner.Plan(ctx, doc)
+//	plan.Print(os.Stdout)
+//	err = planner.Apply(ctx, plan)
+//
+// 変更は依存関係の順(サブスクリプション、OIDC認証、証明書、ドメイン、グループ、ユーザー、サービス、ルート)に作成・更新し、削除はその逆順に行う。
+package apply
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	apigw "github.com/sacloud/apigw-api-go"
+	v1 "github.com/sacloud/apigw-api-go/apis/v1"
+	"github.com/sacloud/apigw-api-go/internal/wire"
+	"github.com/sacloud/apigw-api-go/spec"
+)
+
+// Planner 変更の計画と適用を行う
+type Planner struct {
+	client *v1.Client
+
+	// Prune trueの場合、ドキュメントに含まれないサービス・ユーザー・グループ・ドメイン・証明書を削除する。
+	// サブスクリプションとOIDC認証は削除しない。falseの場合でも、ドキュメントでroutesを指定したサービスのルートは削除の対象となる
+	Prune bool
+
+	// OnCreated Applyでリソースを作成するたびに呼び出される。Rollbackのために作成したリソースを記録する用途に使う
+	OnCreated func(Resource)
+}
+
+// NewPlanner Plannerを生成する
+func NewPlanner(client *v1.Client) *Planner {
+	return &Planner{client: client}
+}
+
+// Plan docとアカウントの現在の状態を比較し、変更を計画する
+func (p *Planner) Plan(ctx context.Context, doc *spec.Document) (*Plan, error) {
+	if err := doc.Validate(); err != nil {
+		return nil, err
+	}
+	if err := checkMasked(doc); err != nil {
+		return nil, err
+	}
+
+	cur, err := p.fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	b := &builder{
+		client: p.client,
+		doc:    doc,
+		cur:    cur,
+		prune:  p.Prune,
+		plan:   &Plan{ids: cur.ids()},
+	}
+	steps := []func(ctx context.Context) error{
+		b.subscriptions,
+		b.oidc,
+		b.certificates,
+		b.domains,
+		b.groups,
+		b.users,
+		b.services,
+	}
+	for _, step := range steps {
+		if err := step(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	// 削除はルート、サービス、ユーザー、グループ、ドメイン、証明書の順に行う
+	b.plan.Changes = append(b.plan.Changes, b.routeDeletes...)
+	if p.Prune {
+		if err := b.pruneResources(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return b.plan, nil
+}
+
+// checkMasked 秘匿情報を伏せて書き出したドキュメントをそのまま適用しないよう、マスクされた値が含まれていればエラーとする
+func checkMasked(doc *spec.Document) error {
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	if paths := wire.Masked(data); len(paths) > 0 {
+		return fmt.Errorf("apply: document contains masked values (export with secrets included to apply it): %s", strings.Join(paths, ", "))
+	}
+	return nil
+}
+
+// Apply planの変更を順に適用する。エラーが発生した場合はその時点で中断する
+func (p *Planner) Apply(ctx context.Context, plan *Plan) error {
+	e := &executor{client: p.client, ids: maps.Clone(plan.ids), onCreated: p.OnCreated}
+	for _, c := range plan.Changes {
+		if err := c.run(ctx, e); err != nil {
+			return fmt.Errorf("apply: %s: %w", c, err)
+		}
+	}
+	return nil
+}
+
+// Resource Applyで作成したリソース
+type Resource struct {
+	Kind Kind      `json:"kind"`
+	Name string    `json:"name"`
+	ID   uuid.UUID `json:"id"`
+	// Parent ルートの場合はサービスのID
+	Parent uuid.UUID `json:"parent,omitzero"`
+}
+
+// Rollback Applyで作成したリソースを作成と逆の順に削除する。既に存在しないリソースは無視する
+func (p *Planner) Rollback(ctx context.Context, created []Resource) error {
+	for _, r := range slices.Backward(created) {
+		if err := deleteResource(ctx, p.client, r); err != nil && !apigw.IsNotFound(err) {
+			return fmt.Errorf("apply: rollback %s %q: %w", r.Kind, r.Name, err)
+		}
+	}
+	return nil
+}
+
+func deleteResource(ctx context.Context, client *v1.Client, r Resource) error {
+	switch r.Kind {
+	case KindSubscription:
+		return apigw.NewSubscriptionOp(client).Delete(ctx, r.ID)
+	case KindOidc:
+		return apigw.NewOidcOp(client).Delete(ctx, r.ID)
+	case KindCertificate:
+		return apigw.NewCertificateOp(client).Delete(ctx, r.ID)
+	case KindDomain:
+		return apigw.NewDomainOp(client).Delete(ctx, r.ID)
+	case KindGroup:
+		return apigw.NewGroupOp(client).Delete(ctx, r.ID)
+	case KindUser:
+		return apigw.NewUserOp(client).Delete(ctx, r.ID)
+	case KindService:
+		return apigw.NewServiceOp(client).Delete(ctx, r.ID)
+	case KindRoute:
+		return apigw.NewRouteOp(client, r.Parent).Delete(ctx, r.ID)
+	}
+	return fmt.Errorf("unable to delete %s", r.Kind)
+}
+
+// executor 変更の適用中の状態。作成したリソースのIDを記録する
+type executor struct {
+	client    *v1.Client
+	ids       map[ref]uuid.UUID
+	onCreated func(Resource)
+}
+
+func (e *executor) id(kind Kind, name string) (uuid.UUID, error) {
+	id, ok := e.ids[ref{kind: kind, name: name}]
+	if !ok {
+		return uuid.Nil, fmt.Errorf("%s %q not found", kind, name)
+	}
+	return id, nil
+}
+
+func (e *executor) created(r Resource) {
+	e.ids[ref{kind: r.Kind, name: r.Name}] = r.ID
+	if e.onCreated != nil {
+		e.onCreated(r)
+	}
+}
+
+// state アカウントの現在の状態
+type state struct {
+	certificates  []v1.Certificate
+	domains       []v1.Domain
+	groups        []v1.Group
+	users         []v1.User
+	services      []v1.ServiceDetailResponse
+	subscriptions []v1.Subscription
+	oidcs         []v1.Oidc
+}
+
+func (p *Planner) fetch(ctx context.Context) (*state, error) {
+	var s state
+	var err error
+	if s.certificates, err = apigw.NewCertificateOp(p.client).List(ctx); err != nil {
+		return nil, err
+	}
+	if s.domains, err = apigw.NewDomainOp(p.client).List(ctx); err != nil {
+		return nil, err
+	}
+	if s.groups, err = apigw.NewGroupOp(p.client).List(ctx); err != nil {
+		return nil, err
+	}
+	if s.users, err = apigw.NewUserOp(p.client).List(ctx); err != nil {
+		return nil, err
+	}
+	if s.services, err = apigw.NewServiceOp(p.client).List(ctx); err != nil {
+		return nil, err
+	}
+	if s.subscriptions, err = apigw.NewSubscriptionOp(p.client).List(ctx); err != nil {
+		return nil, err
+	}
+	if s.oidcs, err = apigw.NewOidcOp(p.client).List(ctx); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (s *state) ids() map[ref]uuid.UUID {
+	ids := make(map[ref]uuid.UUID)
+	for _, c := range s.certificates {
+		ids[ref{KindCertificate, string(c.Name.Value)}] = c.ID.Value
+	}
+	for _, d := range s.domains {
+		ids[ref{KindDomain, d.DomainName}] = d.ID.Value
+	}
+	for _, g := range s.groups {
+		ids[ref{KindGroup, string(g.Name.Value)}] = g.ID.Value
+	}
+	for _, u := range s.users {
+		ids[ref{KindUser, string(u.Name)}] = u.ID.Value
+	}
+	for _, svc := range s.services {
+		ids[ref{KindService, string(svc.Name)}] = svc.ID.Value
+	}
+	for _, sub := range s.subscriptions {
+		ids[ref{KindSubscription, string(sub.Name.Value)}] = sub.ID.Value
+	}
+	for _, o := range s.oidcs {
+		ids[ref{KindOidc, string(o.Name)}] = o.ID.Value
+	}
+	return ids
+}
+
+func (s *state) subscription(name string) (*v1.Subscription, bool) {
+	for i := range s.subscriptions {
+		if string(s.subscriptions[i].Name.Value) == name {
+			return &s.subscriptions[i], true
+		}
+	}
+	return nil, false
+}
+
+func (s *state) oidc(name string) (*v1.Oidc, bool) {
+	for i := range s.oidcs {
+		if string(s.oidcs[i].Name) == name {
+			return &s.oidcs[i], true
+		}
+	}
+	return nil, false
+}
+
+func (s *state) certificate(name string) (*v1.Certificate, bool) {
+	for i := range s.certificates {
+		if string(s.certificates[i].Name.Value) == name {
+			return &s.certificates[i], true
+		}
+	}
+	return nil, false
+}
+
+func (s *state) certificateName(id uuid.UUID) string {
+	for _, c := range s.certificates {
+		if c.ID.Value == id {
+			return string(c.Name.Value)
+		}
+	}
+	return ""
+}
+
+func (s *state) domain(name string) (*v1.Domain, bool) {
+	for i := range s.domains {
+		if s.domains[i].DomainName == name {
+			return &s.domains[i], true
+		}
+	}
+	return nil, false
+}
+
+func (s *state) group(name string) (*v1.Group, bool) {
+	for i := range s.groups {
+		if string(s.groups[i].Name.Value) == name {
+			return &s.groups[i], true
+		}
+	}
+	return nil, false
+}
+
+func (s *state) user(name string) (*v1.User, bool) {
+	for i := range s.users {
+		if string(s.users[i].Name) == name {
+			return &s.users[i], true
+		}
+	}
+	return nil, false
+}
+
+func (s *state) service(name string) (*v1.ServiceDetailResponse, bool) {
+	for i := range s.services {
+		if string(s.services[i].Name) == name {
+			return &s.services[i], true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,831 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apply
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	apigw "github.com/sacloud/apigw-api-go"
+	v1 "github.com/sacloud/apigw-api-go/apis/v1"
+	"github.com/sacloud/apigw-api-go/internal/jsondiff"
+	"github.com/sacloud/apigw-api-go/spec"
+)
+
+// builder ドキュメントの各リソースについて変更を計画する
+type builder struct {
+	client *v1.Client
+	doc    *spec.Document
+	cur    *state
+	prune  bool
+	plan   *Plan
+
+	// routeDeletes ドキュメントから取り除かれたルートの削除。他の変更の後に行う
+	routeDeletes []*Change
+}
+
+func (b *builder) add(c *Change) {
+	b.plan.Changes = append(b.plan.Changes, c)
+}
+
+// known 参照先のリソースがドキュメントまたはアカウントに存在するかを返す。
+// Pruneする場合はドキュメントに含まれないリソースは削除されるため、ドキュメントのみを対象とする
+func (b *builder) known(kind Kind, name string) bool {
+	switch kind {
+	case KindSubscription:
+		if slices.ContainsFunc(b.doc.Subscriptions, func(s spec.Subscription) bool { return s.Name == name }) {
+			return true
+		}
+		// サブスクリプションとOIDC認証はPruneの対象外
+		_, ok := b.plan.ids[ref{kind: kind, name: name}]
+		return ok
+	case KindOidc:
+		if slices.ContainsFunc(b.doc.Oidc, func(o spec.Oidc) bool { return o.Name == name }) {
+			return true
+		}
+		_, ok := b.plan.ids[ref{kind: kind, name: name}]
+		return ok
+	case KindCertificate:
+		if slices.ContainsFunc(b.doc.Certificates, func(c spec.Certificate) bool { return c.Name == name }) {
+			return true
+		}
+	case KindGroup:
+		if slices.ContainsFunc(b.doc.Groups, func(g spec.Group) bool { return g.Name == name }) {
+			return true
+		}
+	}
+	if b.prune {
+		return false
+	}
+	_, ok := b.plan.ids[ref{kind: kind, name: name}]
+	return ok
+}
+
+func (b *builder) subscriptions(ctx context.Context) error {
+	if len(b.doc.Subscriptions) == 0 {
+		return nil
+	}
+	plans, err := apigw.NewSubscriptionOp(b.client).ListPlans(ctx)
+	if err != nil {
+		return err
+	}
+	planName := func(id uuid.UUID) string {
+		for _, p := range plans {
+			if p.ID.Value == id {
+				return p.Name.Value
+			}
+		}
+		return ""
+	}
+
+	for _, sub := range b.doc.Subscriptions {
+		name := sub.Name
+		i := slices.IndexFunc(plans, func(p v1.Plan) bool { return p.Name.Value == sub.Plan })
+		if i < 0 {
+			return fmt.Errorf("apply: subscription %q: plan %q not found", name, sub.Plan)
+		}
+		planID := plans[i].ID.Value
+
+		if current, ok := b.cur.subscription(name); ok {
+			if current.PlanId.Value != planID {
+				return fmt.Errorf("apply: subscription %q: plan cannot be changed from %q to %q", name, planName(current.PlanId.Value), sub.Plan)
+			}
+			continue
+		}
+		b.add(&Change{Action: ActionCreate, Kind: KindSubscription, Name: name, run: func(ctx context.Context, e *executor) error {
+			op := apigw.NewSubscriptionOp(e.client)
+			if err := op.Create(ctx, planID, name); err != nil {
+				return err
+			}
+			// 作成APIはIDを返さないため、一覧から取得する
+			subscriptions, err := op.List(ctx)
+			if err != nil {
+				return err
+			}
+			for _, s := range subscriptions {
+				if string(s.Name.Value) == name {
+					e.created(Resource{Kind: KindSubscription, Name: name, ID: s.ID.Value})
+					return nil
+				}
+			}
+			return fmt.Errorf("created subscription %q not found", name)
+		}})
+	}
+	return nil
+}
+
+func (b *builder) oidc(ctx context.Context) error {
+	for i := range b.doc.Oidc {
+		o := &b.doc.Oidc[i]
+		name := o.Name
+		desired := oidcDetail(o)
+
+		current, ok := b.cur.oidc(name)
+		if !ok {
+			b.add(&Change{Action: ActionCreate, Kind: KindOidc, Name: name, run: func(ctx context.Context, e *executor) error {
+				created, err := apigw.NewOidcOp(e.client).Create(ctx, &desired)
+				if err != nil {
+					return err
+				}
+				e.created(Resource{Kind: KindOidc, Name: name, ID: created.ID.Value})
+				return nil
+			}})
+			continue
+		}
+
+		diffs, err := compare(current, &desired)
+		if err != nil {
+			return err
+		}
+		if len(diffs) == 0 {
+			continue
+		}
+		var req v1.Oidc
+		if err := jsondiff.Overlay(&req, current, &desired); err != nil {
+			return fmt.Errorf("apply: oidc %q: %w", name, err)
+		}
+		id := current.ID.Value
+		b.add(&Change{Action: ActionUpdate, Kind: KindOidc, Name: name, Diffs: diffs, run: func(ctx context.Context, e *executor) error {
+			return apigw.NewOidcOp(e.client).Update(ctx, &req, id)
+		}})
+	}
+	return nil
+}
+
+func (b *builder) certificates(ctx context.Context) error {
+	for _, c := range b.doc.Certificates {
+		name := c.Name
+		desired := v1.Certificate{
+			Name:  v1.NewOptName(v1.Name(name)),
+			Rsa:   certificateDetails(c.RSA),
+			Ecdsa: certificateDetails(c.ECDSA),
+		}
+
+		current, ok := b.cur.certificate(name)
+		if !ok {
+			b.add(&Change{Action: ActionCreate, Kind: KindCertificate, Name: name, run: func(ctx context.Context, e *executor) error {
+				created, err := apigw.NewCertificateOp(e.client).Create(ctx, &desired)
+				if err != nil {
+					return err
+				}
+				e.created(Resource{Kind: KindCertificate, Name: name, ID: created.ID.Value})
+				return nil
+			}})
+			continue
+		}
+
+		// 秘密鍵は参照できないため、証明書の有効期限で変更の有無を判定する
+		var diffs []Difference
+		for _, kp := range []struct {
+			path    string
+			pair    *spec.KeyPair
+			current v1.OptCertificateDetails
+		}{{"rsa", c.RSA, current.Rsa}, {"ecdsa", c.ECDSA, current.Ecdsa}} {
+			if kp.pair == nil {
+				continue
+			}
+			notAfter, err := certificateExpiry(kp.pair.Cert)
+			if err != nil {
+				return fmt.Errorf("apply: certificate %q: %w", name, err)
+			}
+			var from any
+			if expiredAt := kp.current.Value.ExpiredAt; kp.current.Set && expiredAt.Set {
+				if expiredAt.Value.Unix() == notAfter.Unix() {
+					continue
+				}
+				from = expiredAt.Value.UTC().Format(time.RFC3339)
+			}
+			diffs = append(diffs, Difference{Path: kp.path + ".expiredAt", From: from, To: notAfter.UTC().Format(time.RFC3339)})
+		}
+		if len(diffs) == 0 {
+			continue
+		}
+
+		id := current.ID.Value
+		b.add(&Change{Action: ActionUpdate, Kind: KindCertificate, Name: name, Diffs: diffs, run: func(ctx context.Context, e *executor) error {
+			return apigw.NewCertificateOp(e.client).Update(ctx, &desired, id)
+		}})
+	}
+	return nil
+}
+
+func (b *builder) domains(ctx context.Context) error {
+	for _, d := range b.doc.Domains {
+		name, certName := d.Name, d.Certificate
+		if certName != "" && !b.known(KindCertificate, certName) {
+			return fmt.Errorf("apply: domain %q: certificate %q not found", name, certName)
+		}
+		certificateID := func(e *executor) (v1.OptUUID, error) {
+			if certName == "" {
+				return v1.OptUUID{}, nil
+			}
+			id, err := e.id(KindCertificate, certName)
+			if err != nil {
+				return v1.OptUUID{}, err
+			}
+			return v1.NewOptUUID(id), nil
+		}
+
+		current, ok := b.cur.domain(name)
+		if !ok {
+			b.add(&Change{Action: ActionCreate, Kind: KindDomain, Name: name, run: func(ctx context.Context, e *executor) error {
+				certID, err := certificateID(e)
+				if err != nil {
+					return err
+				}
+				created, err := apigw.NewDomainOp(e.client).Create(ctx, &v1.Domain{DomainName: name, CertificateId: certID})
+				if err != nil {
+					return err
+				}
+				e.created(Resource{Kind: KindDomain, Name: name, ID: created.ID.Value})
+				return nil
+			}})
+			continue
+		}
+
+		if certName == "" {
+			continue
+		}
+		var from any
+		if current.CertificateId.Set {
+			from = b.cur.certificateName(current.CertificateId.Value)
+		}
+		if from == any(certName) {
+			continue
+		}
+
+		id := current.ID.Value
+		b.add(&Change{
+			Action: ActionUpdate, Kind: KindDomain, Name: name,
+			Diffs: []Difference{{Path: "certificate", From: from, To: certName}},
+			run: func(ctx context.Context, e *executor) error {
+				certID, err := certificateID(e)
+				if err != nil {
+					return err
+				}
+				return apigw.NewDomainOp(e.client).Update(ctx, &v1.DomainPUT{CertificateId: certID}, id)
+			},
+		})
+	}
+	return nil
+}
+
+func (b *builder) groups(ctx context.Context) error {
+	for _, g := range b.doc.Groups {
+		name := g.Name
+		desired := v1.Group{Name: v1.NewOptName(v1.Name(name)), Tags: g.Tags}
+
+		current, ok := b.cur.group(name)
+		if !ok {
+			b.add(&Change{Action: ActionCreate, Kind: KindGroup, Name: name, run: func(ctx context.Context, e *executor) error {
+				created, err := apigw.NewGroupOp(e.client).Create(ctx, &desired)
+				if err != nil {
+					return err
+				}
+				e.created(Resource{Kind: KindGroup, Name: name, ID: created.ID.Value})
+				return nil
+			}})
+			continue
+		}
+
+		diffs, err := compare(current, &desired)
+		if err != nil {
+			return err
+		}
+		if len(diffs) == 0 {
+			continue
+		}
+		var req v1.Group
+		if err := jsondiff.Overlay(&req, current, &desired); err != nil {
+			return err
+		}
+		id := current.ID.Value
+		b.add(&Change{Action: ActionUpdate, Kind: KindGroup, Name: name, Diffs: diffs, run: func(ctx context.Context, e *executor) error {
+			return apigw.NewGroupOp(e.client).Update(ctx, &req, id)
+		}})
+	}
+	return nil
+}
+
+func (b *builder) users(ctx context.Context) error {
+	for i := range b.doc.Users {
+		u := &b.doc.Users[i]
+		name := u.Name
+		desired := userDetail(u)
+
+		current, exists := b.cur.user(name)
+		if !exists {
+			b.add(&Change{Action: ActionCreate, Kind: KindUser, Name: name, run: func(ctx context.Context, e *executor) error {
+				created, err := apigw.NewUserOp(e.client).Create(ctx, &desired)
+				if err != nil {
+					return err
+				}
+				e.created(Resource{Kind: KindUser, Name: name, ID: created.ID.Value})
+				return nil
+			}})
+		} else {
+			detail, err := apigw.NewUserOp(b.client).Read(ctx, current.ID.Value)
+			if err != nil {
+				return err
+			}
+			diffs, err := compare(detail, &desired)
+			if err != nil {
+				return err
+			}
+			if len(diffs) > 0 {
+				var req v1.UserDetail
+				if err := jsondiff.Overlay(&req, detail, &desired, "groups"); err != nil {
+					return err
+				}
+				id := current.ID.Value
+				b.add(&Change{Action: ActionUpdate, Kind: KindUser, Name: name, Diffs: diffs, run: func(ctx context.Context, e *executor) error {
+					return apigw.NewUserOp(e.client).Update(ctx, &req, id)
+				}})
+			}
+		}
+
+		if err := b.userGroups(ctx, u, current); err != nil {
+			return err
+		}
+		if err := b.userAuthentication(ctx, u, current); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *builder) userGroups(ctx context.Context, u *spec.User, current *v1.User) error {
+	if u.Groups == nil {
+		return nil
+	}
+	name := u.Name
+	for _, g := range u.Groups {
+		if !b.known(KindGroup, g) {
+			return fmt.Errorf("apply: user %q: group %q not found", name, g)
+		}
+	}
+
+	assigned := []string{}
+	if current != nil {
+		groups, err := apigw.NewUserExtraOp(b.client, current.ID.Value).ListGroup(ctx)
+		if err != nil {
+			return err
+		}
+		for _, g := range groups {
+			if g.IsAssigned {
+				assigned = append(assigned, string(g.Name))
+			}
+		}
+	}
+	want := slices.Sorted(slices.Values(u.Groups))
+	slices.Sort(assigned)
+	if slices.Equal(assigned, want) {
+		return nil
+	}
+
+	c := &Change{Action: ActionCreate, Kind: KindUserGroups, Name: name, run: func(ctx context.Context, e *executor) error {
+		id, err := e.id(KindUser, name)
+		if err != nil {
+			return err
+		}
+		op := apigw.NewUserExtraOp(e.client, id)
+		for _, g := range want {
+			if !slices.Contains(assigned, g) {
+				if err := op.UpdateGroup(ctx, g, true); err != nil {
+					return err
+				}
+			}
+		}
+		for _, g := range assigned {
+			if !slices.Contains(want, g) {
+				if err := op.UpdateGroup(ctx, g, false); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}}
+	if current != nil {
+		c.Action = ActionUpdate
+		c.Diffs = []Difference{{Path: "groups", From: assigned, To: want}}
+	}
+	b.add(c)
+	return nil
+}
+
+func (b *builder) userAuthentication(ctx context.Context, u *spec.User, current *v1.User) error {
+	if u.Authentication == nil {
+		return nil
+	}
+	name := u.Name
+	desired := *u.Authentication
+	c := &Change{Action: ActionCreate, Kind: KindUserAuthentication, Name: name, run: func(ctx context.Context, e *executor) error {
+		id, err := e.id(KindUser, name)
+		if err != nil {
+			return err
+		}
+		return apigw.NewUserExtraOp(e.client, id).UpdateAuth(ctx, desired)
+	}}
+
+	if current != nil {
+		auth, err := apigw.NewUserExtraOp(b.client, current.ID.Value).ReadAuth(ctx)
+		if apigw.IsNotFound(err) {
+			auth, err = &v1.UserAuthentication{}, nil
+		}
+		if err != nil {
+			return err
+		}
+		if c.Diffs, err = compare(auth, &desired); err != nil {
+			return err
+		}
+		if len(c.Diffs) == 0 {
+			return nil
+		}
+		c.Action = ActionUpdate
+	}
+	b.add(c)
+	return nil
+}
+
+func (b *builder) services(ctx context.Context) error {
+	for i := range b.doc.Services {
+		s := &b.doc.Services[i]
+		name, subscriptionName, oidcName := s.Name, s.Subscription, s.Oidc
+
+		if !b.known(KindSubscription, subscriptionName) {
+			return fmt.Errorf("apply: service %q: subscription %q not found", name, subscriptionName)
+		}
+		desired := serviceDetail(s)
+		if oidcName != "" {
+			if !b.known(KindOidc, oidcName) {
+				return fmt.Errorf("apply: service %q: oidc %q not found", name, oidcName)
+			}
+			desired.Oidc = v1.NewOptOidcSummary(v1.OidcSummary{Name: v1.NewOptString(oidcName)})
+		}
+		// OIDC認証は計画時点で存在しない場合があるため、適用時にIDを解決する
+		setOidc := func(e *executor, dst *v1.OptOidcSummary) error {
+			if oidcName == "" {
+				return nil
+			}
+			id, err := e.id(KindOidc, oidcName)
+			if err != nil {
+				return err
+			}
+			*dst = v1.NewOptOidcSummary(v1.OidcSummary{ID: v1.NewOptUUID(id)})
+			return nil
+		}
+
+		current, exists := b.cur.service(name)
+		if !exists {
+			var req v1.ServiceDetailRequest
+			subscription, err := json.Marshal(map[string]any{"subscription": v1.ServiceSubscriptionRequest{}})
+			if err != nil {
+				return err
+			}
+			if err := convert(&req, &desired, json.RawMessage(subscription)); err != nil {
+				return fmt.Errorf("apply: service %q: %w", name, err)
+			}
+			b.add(&Change{Action: ActionCreate, Kind: KindService, Name: name, run: func(ctx context.Context, e *executor) error {
+				subscriptionID, err := e.id(KindSubscription, subscriptionName)
+				if err != nil {
+					return err
+				}
+				req.Subscription.ID = subscriptionID
+				if err := setOidc(e, &req.Oidc); err != nil {
+					return err
+				}
+				created, err := apigw.NewServiceOp(e.client).Create(ctx, &req)
+				if err != nil {
+					return err
+				}
+				e.created(Resource{Kind: KindService, Name: name, ID: created.ID.Value})
+				return nil
+			}})
+		} else {
+			if current.Subscription.Name != subscriptionName {
+				return fmt.Errorf("apply: service %q: subscription cannot be changed from %q to %q",
+					name, current.Subscription.Name, subscriptionName)
+			}
+			diffs, err := compare(current, &desired)
+			if err != nil {
+				return err
+			}
+			if len(diffs) > 0 {
+				var req v1.ServiceDetail
+				if err := jsondiff.Overlay(&req, current, &desired, "routeHost", "subscription"); err != nil {
+					return fmt.Errorf("apply: service %q: %w", name, err)
+				}
+				id := current.ID.Value
+				b.add(&Change{Action: ActionUpdate, Kind: KindService, Name: name, Diffs: diffs, run: func(ctx context.Context, e *executor) error {
+					if err := setOidc(e, &req.Oidc); err != nil {
+						return err
+					}
+					return apigw.NewServiceOp(e.client).Update(ctx, &req, id)
+				}})
+			}
+		}
+
+		if err := b.routes(ctx, s, current); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *builder) routes(ctx context.Context, s *spec.Service, service *v1.ServiceDetailResponse) error {
+	if s.Routes == nil {
+		return nil
+	}
+
+	var current []v1.Route
+	if service != nil {
+		var err error
+		if current, err = apigw.NewRouteOp(b.client, service.ID.Value).List(ctx); err != nil {
+			return err
+		}
+	}
+
+	for i := range s.Routes {
+		r := &s.Routes[i]
+		var live *v1.Route
+		if j := slices.IndexFunc(current, func(rt v1.Route) bool { return string(rt.Name.Value) == r.Name }); j >= 0 {
+			live = &current[j]
+		}
+		if err := b.route(ctx, s.Name, r, service, live); err != nil {
+			return err
+		}
+	}
+
+	for _, rt := range current {
+		if !slices.ContainsFunc(s.Routes, func(r spec.Route) bool { return r.Name == string(rt.Name.Value) }) {
+			b.routeDeletes = append(b.routeDeletes, deleteRoute(s.Name, rt))
+		}
+	}
+	return nil
+}
+
+// RouteName 計画で使うルートの名前("サービス名/ルート名")を返す。ルートに名前がない場合はIDを使う
+func RouteName(service string, route v1.Route) string {
+	if route.Name.Set {
+		return service + "/" + string(route.Name.Value)
+	}
+	return service + "/" + route.ID.Value.String()
+}
+
+func deleteRoute(service string, route v1.Route) *Change {
+	return deleteChange(Resource{Kind: KindRoute, Name: RouteName(service, route), ID: route.ID.Value, Parent: route.ServiceId.Value})
+}
+
+func (b *builder) route(ctx context.Context, serviceName string, r *spec.Route, service *v1.ServiceDetailResponse, current *v1.Route) error {
+	key := serviceName + "/" + r.Name
+	desired := routeDetail(r)
+
+	// ルートの設定を変更するためのRouteExtraAPIを返す。ルートは計画時点で存在しない場合がある
+	extraOp := func(e *executor) (apigw.RouteExtraAPI, error) {
+		serviceID, err := e.id(KindService, serviceName)
+		if err != nil {
+			return nil, err
+		}
+		id, err := e.id(KindRoute, key)
+		if err != nil {
+			return nil, err
+		}
+		return apigw.NewRouteExtraOp(e.client, serviceID, id), nil
+	}
+
+	var liveExtra apigw.RouteExtraAPI
+	if current == nil {
+		b.add(&Change{Action: ActionCreate, Kind: KindRoute, Name: key, run: func(ctx context.Context, e *executor) error {
+			serviceID, err := e.id(KindService, serviceName)
+			if err != nil {
+				return err
+			}
+			created, err := apigw.NewRouteOp(e.client, serviceID).Create(ctx, &desired)
+			if err != nil {
+				return err
+			}
+			e.created(Resource{Kind: KindRoute, Name: key, ID: created.ID.Value, Parent: serviceID})
+			return nil
+		}})
+	} else {
+		serviceID, id := service.ID.Value, current.ID.Value
+		b.plan.ids[ref{KindRoute, key}] = id
+		liveExtra = apigw.NewRouteExtraOp(b.client, serviceID, id)
+
+		detail, err := apigw.NewRouteOp(b.client, serviceID).Read(ctx, id)
+		if err != nil {
+			return err
+		}
+		diffs, err := compare(detail, &desired)
+		if err != nil {
+			return err
+		}
+		if len(diffs) > 0 {
+			var req v1.RouteDetail
+			if err := jsondiff.Overlay(&req, detail, &desired, "serviceId", "host"); err != nil {
+				return fmt.Errorf("apply: route %q: %w", key, err)
+			}
+			b.add(&Change{Action: ActionUpdate, Kind: KindRoute, Name: key, Diffs: diffs, run: func(ctx context.Context, e *executor) error {
+				return apigw.NewRouteOp(e.client, serviceID).Update(ctx, &req, id)
+			}})
+		}
+	}
+
+	if r.Authorization != nil {
+		if err := b.routeAuthorization(ctx, key, r.Authorization, liveExtra, extraOp); err != nil {
+			return err
+		}
+	}
+	if r.RequestTransformation != nil {
+		desired := *r.RequestTransformation
+		var current json.Marshaler = &v1.RequestTransformation{}
+		if liveExtra != nil {
+			cur, err := liveExtra.ReadRequestTransformation(ctx)
+			if err != nil && !apigw.IsNotFound(err) {
+				return err
+			}
+			if cur != nil {
+				current = cur
+			}
+		}
+		err := routeSetting(b, KindRequestTransformation, key, liveExtra != nil, current, &desired, func(ctx context.Context, e *executor) error {
+			op, err := extraOp(e)
+			if err != nil {
+				return err
+			}
+			return op.UpdateRequestTransformation(ctx, &desired)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if r.ResponseTransformation != nil {
+		desired := *r.ResponseTransformation
+		var current json.Marshaler = &v1.ResponseTransformation{}
+		if liveExtra != nil {
+			cur, err := liveExtra.ReadResponseTransformation(ctx)
+			if err != nil && !apigw.IsNotFound(err) {
+				return err
+			}
+			if cur != nil {
+				current = cur
+			}
+		}
+		err := routeSetting(b, KindResponseTransformation, key, liveExtra != nil, current, &desired, func(ctx context.Context, e *executor) error {
+			op, err := extraOp(e)
+			if err != nil {
+				return err
+			}
+			return op.UpdateResponseTransformation(ctx, &desired)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// routeSetting ルートの変換設定の変更を計画する
+func routeSetting[T any, PT interface {
+	*T
+	json.Marshaler
+	json.Unmarshaler
+}](b *builder, kind Kind, key string, exists bool, current json.Marshaler, desired PT, run func(ctx context.Context, e *executor) error) error {
+	c := &Change{Action: ActionCreate, Kind: kind, Name: key, run: run}
+	if exists {
+		var err error
+		if c.Diffs, err = compare(current, desired); err != nil {
+			return err
+		}
+		if len(c.Diffs) == 0 {
+			return nil
+		}
+		c.Action = ActionUpdate
+	}
+	b.add(c)
+	return nil
+}
+
+// authorizedGroup ルートの認可設定の比較に用いる表現
+type authorizedGroup struct {
+	Name    string `json:"name"`
+	Enabled bool   `json:"enabled"`
+}
+
+func (b *builder) routeAuthorization(ctx context.Context, key string, authz *spec.RouteAuthorization, live apigw.RouteExtraAPI, extraOp func(*executor) (apigw.RouteExtraAPI, error)) error {
+	want := []authorizedGroup{}
+	for _, g := range authz.Groups {
+		if !b.known(KindGroup, g.Name) {
+			return fmt.Errorf("apply: route %q: group %q not found", key, g.Name)
+		}
+		want = append(want, authorizedGroup{Name: g.Name, Enabled: g.IsEnabled()})
+	}
+	byName := func(a, b authorizedGroup) int { return strings.Compare(a.Name, b.Name) }
+	slices.SortFunc(want, byName)
+
+	c := &Change{Action: ActionCreate, Kind: KindRouteAuthorization, Name: key, run: func(ctx context.Context, e *executor) error {
+		op, err := extraOp(e)
+		if err != nil {
+			return err
+		}
+		if len(want) == 0 {
+			return op.DisableAuthorization(ctx)
+		}
+		groups := make([]v1.RouteAuthorization, 0, len(want))
+		for _, g := range want {
+			id, err := e.id(KindGroup, g.Name)
+			if err != nil {
+				return err
+			}
+			groups = append(groups, v1.RouteAuthorization{ID: v1.NewOptUUID(id), Enabled: v1.NewOptBool(g.Enabled)})
+		}
+		return op.EnableAuthorization(ctx, groups)
+	}}
+
+	if live != nil {
+		// 認可設定のないルートは404を返す
+		current, err := live.ReadAuthorization(ctx)
+		if err != nil && !apigw.IsNotFound(err) {
+			return err
+		}
+		have := []authorizedGroup{}
+		if current != nil && current.IsACLEnabled {
+			for _, g := range current.Groups {
+				have = append(have, authorizedGroup{Name: string(g.Name.Value), Enabled: !g.Enabled.Set || g.Enabled.Value})
+			}
+		}
+		slices.SortFunc(have, byName)
+		if reflect.DeepEqual(have, want) {
+			return nil
+		}
+		c.Action = ActionUpdate
+		c.Diffs = []Difference{{Path: "groups", From: have, To: want}}
+	} else if len(want) == 0 {
+		// 作成したルートの認可設定は無効になっている
+		return nil
+	}
+	b.add(c)
+	return nil
+}
+
+// pruneResources ドキュメントに含まれないリソースを削除する
+func (b *builder) pruneResources(ctx context.Context) error {
+	for _, svc := range b.cur.services {
+		if slices.ContainsFunc(b.doc.Services, func(s spec.Service) bool { return s.Name == string(svc.Name) }) {
+			continue
+		}
+		routes, err := apigw.NewRouteOp(b.client, svc.ID.Value).List(ctx)
+		if err != nil {
+			return err
+		}
+		for _, rt := range routes {
+			b.add(deleteRoute(string(svc.Name), rt))
+		}
+		b.add(deleteChange(Resource{Kind: KindService, Name: string(svc.Name), ID: svc.ID.Value}))
+	}
+	for _, u := range b.cur.users {
+		if !slices.ContainsFunc(b.doc.Users, func(s spec.User) bool { return s.Name == string(u.Name) }) {
+			b.add(deleteChange(Resource{Kind: KindUser, Name: string(u.Name), ID: u.ID.Value}))
+		}
+	}
+	for _, g := range b.cur.groups {
+		if !slices.ContainsFunc(b.doc.Groups, func(s spec.Group) bool { return s.Name == string(g.Name.Value) }) {
+			b.add(deleteChange(Resource{Kind: KindGroup, Name: string(g.Name.Value), ID: g.ID.Value}))
+		}
+	}
+	for _, d := range b.cur.domains {
+		if !slices.ContainsFunc(b.doc.Domains, func(s spec.Domain) bool { return s.Name == d.DomainName }) {
+			b.add(deleteChange(Resource{Kind: KindDomain, Name: d.DomainName, ID: d.ID.Value}))
+		}
+	}
+	for _, c := range b.cur.certificates {
+		if !slices.ContainsFunc(b.doc.Certificates, func(s spec.Certificate) bool { return s.Name == string(c.Name.Value) }) {
+			b.add(deleteChange(Resource{Kind: KindCertificate, Name: string(c.Name.Value), ID: c.ID.Value}))
+		}
+	}
+	return nil
+}
+
+func deleteChange(r Resource) *Change {
+	return &Change{Action: ActionDelete, Kind: r.Kind, Name: r.Name, run: func(ctx context.Context, e *executor) error {
+		return deleteResource(ctx, e.client, r)
+	}}
+}
@@ -0,0 +1,522 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apigw
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	v1 "github.com/sacloud/apigw-api-go/apis/v1"
+	"golang.org/x/sync/singleflight"
+)
+
+// DefaultCacheTTL CacheTTLで指定しなかったリソースのキャッシュの有効期間
+const DefaultCacheTTL = 30 * time.Second
+
+// CacheTTL リソースの種類ごとのキャッシュの有効期間。
+// 0の場合はDefaultCacheTTL、負の場合はキャッシュしない
+type CacheTTL struct {
+	Service     time.Duration
+	Route       time.Duration
+	Group       time.Duration
+	User        time.Duration
+	Domain      time.Duration
+	Certificate time.Duration
+}
+
+type cacheKind int
+
+const (
+	cacheService cacheKind = iota
+	cacheRoute
+	cacheGroup
+	cacheUser
+	cacheDomain
+	cacheCertificate
+	cacheKinds
+)
+
+// cacheDependents 変更時に合わせて無効化するリソース。
+// サービスを削除するとルートも削除され、ユーザーや証明書の詳細にはグループや証明書の情報が含まれる
+var cacheDependents = map[cacheKind][]cacheKind{
+	cacheService:     {cacheRoute},
+	cacheGroup:       {cacheUser},
+	cacheCertificate: {cacheDomain},
+}
+
+type cacheKey struct {
+	kind   cacheKind
+	parent uuid.UUID
+	id     uuid.UUID
+	list   bool
+}
+
+type cacheEntry struct {
+	value   any
+	expires time.Time
+}
+
+// Cache 一覧や詳細の取得結果をリソースの種類ごとの有効期間だけ保持するキャッシュ。
+// ServiceOpなどが返す操作は各APIのインターフェースを実装し、読み取りはキャッシュを経由する。
+// 同じ内容の読み取りが同時に行われた場合はAPIを1度だけ呼び出す。
+// 作成・更新・削除が成功すると、そのリソースの種類のキャッシュを無効化する。
+// 返す値は呼び出しごとの複製だが、スライスなどの内部の値は共有するため変更しないこと
+type Cache struct {
+	client *v1.Client
+	ttl    [cacheKinds]time.Duration
+	now    func() time.Time
+
+	mu      sync.Mutex
+	entries map[cacheKey]cacheEntry
+	gen     [cacheKinds]uint64
+	flight  singleflight.Group
+}
+
+// NewCache Cacheを生成する
+func NewCache(client *v1.Client, ttl CacheTTL) *Cache {
+	c := &Cache{client: client, now: time.Now, entries: map[cacheKey]cacheEntry{}}
+	for kind, d := range map[cacheKind]time.Duration{
+		cacheService:     ttl.Service,
+		cacheRoute:       ttl.Route,
+		cacheGroup:       ttl.Group,
+		cacheUser:        ttl.User,
+		cacheDomain:      ttl.Domain,
+		cacheCertificate: ttl.Certificate,
+	} {
+		if d == 0 {
+			d = DefaultCacheTTL
+		}
+		c.ttl[kind] = d
+	}
+	return c
+}
+
+// Purge 全てのキャッシュを無効化する
+func (c *Cache) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	clear(c.entries)
+	for kind := range c.gen {
+		c.gen[kind]++
+	}
+}
+
+// invalidate kindとそれに依存する種類のキャッシュを無効化する
+func (c *Cache) invalidate(kind cacheKind) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, k := range append([]cacheKind{kind}, cacheDependents[kind]...) {
+		c.gen[k]++
+		for key := range c.entries {
+			if key.kind == k {
+				delete(c.entries, key)
+			}
+		}
+	}
+}
+
+// load keyのキャッシュを返す。ない場合はfetchで取得して保持する。
+// 取得中に無効化された場合、その結果は保持しない
+func load[T any](ctx context.Context, c *Cache, key cacheKey, fetch func(context.Context) (T, error)) (T, error) {
+	ttl := c.ttl[key.kind]
+	if ttl < 0 {
+		return fetch(ctx)
+	}
+
+	c.mu.Lock()
+	if e, ok := c.entries[key]; ok && c.now().Before(e.expires) {
+		c.mu.Unlock()
+		return e.value.(T), nil
+	}
+	gen := c.gen[key.kind]
+	c.mu.Unlock()
+
+	// 無効化の前後の読み取りを同じ呼び出しにまとめないよう、世代をキーに含める。
+	// 待機している他の呼び出しがあるため、取得は呼び出し元のキャンセルで中断しない
+	ch := c.flight.DoChan(fmt.Sprintf("%v/%d", key, gen), func() (any, error) {
+		v, err := fetch(context.WithoutCancel(ctx))
+		if err != nil {
+			return nil, err
+		}
+		c.mu.Lock()
+		if c.gen[key.kind] == gen {
+			c.entries[key] = cacheEntry{value: v, expires: c.now().Add(ttl)}
+		}
+		c.mu.Unlock()
+		return v, nil
+	})
+	select {
+	case r := <-ch:
+		if r.Err != nil {
+			var zero T
+			return zero, r.Err
+		}
+		return r.Val.(T), nil
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
+
+func loadList[T any](ctx context.Context, c *Cache, key cacheKey, fetch func(context.Context) ([]T, error)) ([]T, error) {
+	key.list = true
+	items, err := load(ctx, c, key, fetch)
+	return slices.Clone(items), err
+}
+
+func loadOne[T any](ctx context.Context, c *Cache, key cacheKey, fetch func(context.Context, uuid.UUID) (*T, error)) (*T, error) {
+	v, err := load(ctx, c, key, func(ctx context.Context) (*T, error) { return fetch(ctx, key.id) })
+	if err != nil {
+		return nil, err
+	}
+	ret := *v
+	return &ret, nil
+}
+
+// mutated errがnilの場合にkindのキャッシュを無効化する
+func (c *Cache) mutated(kind cacheKind, err error) error {
+	if err == nil {
+		c.invalidate(kind)
+	}
+	return err
+}
+
+// ServiceOp キャッシュを経由するServiceAPIを返す
+func (c *Cache) ServiceOp() ServiceAPI {
+	return &cachedServiceOp{c: c, op: NewServiceOp(c.client)}
+}
+
+type cachedServiceOp struct {
+	c  *Cache
+	op ServiceAPI
+}
+
+var _ ServiceAPI = (*cachedServiceOp)(nil)
+
+func (o *cachedServiceOp) List(ctx context.Context) ([]v1.ServiceDetailResponse, error) {
+	return loadList(ctx, o.c, cacheKey{kind: cacheService}, o.op.List)
+}
+
+func (o *cachedServiceOp) All(ctx context.Context) iter.Seq2[v1.ServiceDetailResponse, error] {
+	return all(ctx, o.List)
+}
+
+func (o *cachedServiceOp) Create(ctx context.Context, request *v1.ServiceDetailRequest) (*v1.ServiceDetailRequest, error) {
+	ret, err := o.op.Create(ctx, request)
+	return ret, o.c.mutated(cacheService, err)
+}
+
+func (o *cachedServiceOp) Read(ctx context.Context, id uuid.UUID) (*v1.ServiceDetailResponse, error) {
+	return loadOne(ctx, o.c, cacheKey{kind: cacheService, id: id}, o.op.Read)
+}
+
+func (o *cachedServiceOp) Update(ctx context.Context, request *v1.ServiceDetail, id uuid.UUID) error {
+	return o.c.mutated(cacheService, o.op.Update(ctx, request, id))
+}
+
+func (o *cachedServiceOp) Delete(ctx context.Context, id uuid.UUID) error {
+	return o.c.mutated(cacheService, o.op.Delete(ctx, id))
+}
+
+func (o *cachedServiceOp) FindByName(ctx context.Context, name string) ([]v1.ServiceDetailResponse, error) {
+	return serviceLookup(o.List).find(ctx, name)
+}
+
+func (o *cachedServiceOp) GetByName(ctx context.Context, name string) (*v1.ServiceDetailResponse, error) {
+	return serviceLookup(o.List).get(ctx, name)
+}
+
+func (o *cachedServiceOp) Resolve(ctx context.Context, idOrName string) (*v1.ServiceDetailResponse, error) {
+	return serviceLookup(o.List).resolve(ctx, idOrName)
+}
+
+// Ensure 名前による検索はキャッシュを経由しない
+func (o *cachedServiceOp) Ensure(ctx context.Context, desired *v1.ServiceDetailRequest) (*v1.ServiceDetailResponse, EnsureAction, error) {
+	ret, action, err := o.op.Ensure(ctx, desired)
+	if action != EnsureUnchanged {
+		o.c.invalidate(cacheService)
+	}
+	return ret, action, err
+}
+
+// RouteOp キャッシュを経由するRouteAPIを返す
+func (c *Cache) RouteOp(serviceId uuid.UUID) RouteAPI {
+	return &cachedRouteOp{c: c, op: NewRouteOp(c.client, serviceId), serviceId: serviceId}
+}
+
+type cachedRouteOp struct {
+	c         *Cache
+	op        RouteAPI
+	serviceId uuid.UUID
+}
+
+var _ RouteAPI = (*cachedRouteOp)(nil)
+
+func (o *cachedRouteOp) List(ctx context.Context) ([]v1.Route, error) {
+	return loadList(ctx, o.c, cacheKey{kind: cacheRoute, parent: o.serviceId}, o.op.List)
+}
+
+func (o *cachedRouteOp) All(ctx context.Context) iter.Seq2[v1.Route, error] {
+	return all(ctx, o.List)
+}
+
+func (o *cachedRouteOp) Create(ctx context.Context, request *v1.RouteDetail) (*v1.RouteDetail, error) {
+	ret, err := o.op.Create(ctx, request)
+	return ret, o.c.mutated(cacheRoute, err)
+}
+
+func (o *cachedRouteOp) Read(ctx context.Context, id uuid.UUID) (*v1.RouteDetail, error) {
+	return loadOne(ctx, o.c, cacheKey{kind: cacheRoute, parent: o.serviceId, id: id}, o.op.Read)
+}
+
+func (o *cachedRouteOp) Update(ctx context.Context, request *v1.RouteDetail, id uuid.UUID) error {
+	return o.c.mutated(cacheRoute, o.op.Update(ctx, request, id))
+}
+
+func (o *cachedRouteOp) Delete(ctx context.Context, id uuid.UUID) error {
+	return o.c.mutated(cacheRoute, o.op.Delete(ctx, id))
+}
+
+func (o *cachedRouteOp) FindByName(ctx context.Context, name string) ([]v1.Route, error) {
+	return routeLookup(o.List).find(ctx, name)
+}
+
+func (o *cachedRouteOp) GetByName(ctx context.Context, name string) (*v1.Route, error) {
+	return routeLookup(o.List).get(ctx, name)
+}
+
+func (o *cachedRouteOp) Resolve(ctx context.Context, idOrName string) (*v1.Route, error) {
+	return routeLookup(o.List).resolve(ctx, idOrName)
+}
+
+// Ensure 名前による検索はキャッシュを経由しない
+func (o *cachedRouteOp) Ensure(ctx context.Context, desired *v1.RouteDetail) (*v1.RouteDetail, EnsureAction, error) {
+	ret, action, err := o.op.Ensure(ctx, desired)
+	if action != EnsureUnchanged {
+		o.c.invalidate(cacheRoute)
+	}
+	return ret, action, err
+}
+
+// GroupOp キャッシュを経由するGroupAPIを返す
+func (c *Cache) GroupOp() GroupAPI {
+	return &cachedGroupOp{c: c, op: NewGroupOp(c.client)}
+}
+
+type cachedGroupOp struct {
+	c  *Cache
+	op GroupAPI
+}
+
+var _ GroupAPI = (*cachedGroupOp)(nil)
+
+func (o *cachedGroupOp) List(ctx context.Context) ([]v1.Group, error) {
+	return loadList(ctx, o.c, cacheKey{kind: cacheGroup}, o.op.List)
+}
+
+func (o *cachedGroupOp) All(ctx context.Context) iter.Seq2[v1.Group, error] {
+	return all(ctx, o.List)
+}
+
+func (o *cachedGroupOp) Create(ctx context.Context, request *v1.Group) (*v1.Group, error) {
+	ret, err := o.op.Create(ctx, request)
+	return ret, o.c.mutated(cacheGroup, err)
+}
+
+func (o *cachedGroupOp) Read(ctx context.Context, id uuid.UUID) (*v1.Group, error) {
+	return loadOne(ctx, o.c, cacheKey{kind: cacheGroup, id: id}, o.op.Read)
+}
+
+func (o *cachedGroupOp) Update(ctx context.Context, request *v1.Group, id uuid.UUID) error {
+	return o.c.mutated(cacheGroup, o.op.Update(ctx, request, id))
+}
+
+func (o *cachedGroupOp) Delete(ctx context.Context, id uuid.UUID) error {
+	return o.c.mutated(cacheGroup, o.op.Delete(ctx, id))
+}
+
+func (o *cachedGroupOp) FindByName(ctx context.Context, name string) ([]v1.Group, error) {
+	return groupLookup(o.List).find(ctx, name)
+}
+
+func (o *cachedGroupOp) GetByName(ctx context.Context, name string) (*v1.Group, error) {
+	return groupLookup(o.List).get(ctx, name)
+}
+
+func (o *cachedGroupOp) Resolve(ctx context.Context, idOrName string) (*v1.Group, error) {
+	return groupLookup(o.List).resolve(ctx, idOrName)
+}
+
+// Ensure 名前による検索はキャッシュを経由しない
+func (o *cachedGroupOp) Ensure(ctx context.Context, desired *v1.Group) (*v1.Group, EnsureAction, error) {
+	ret, action, err := o.op.Ensure(ctx, desired)
+	if action != EnsureUnchanged {
+		o.c.invalidate(cacheGroup)
+	}
+	return ret, action, err
+}
+
+// UserOp キャッシュを経由するUserAPIを返す
+func (c *Cache) UserOp() UserAPI {
+	return &cachedUserOp{c: c, op: NewUserOp(c.client)}
+}
+
+type cachedUserOp struct {
+	c  *Cache
+	op UserAPI
+}
+
+var _ UserAPI = (*cachedUserOp)(nil)
+
+func (o *cachedUserOp) List(ctx context.Context) ([]v1.User, error) {
+	return loadList(ctx, o.c, cacheKey{kind: cacheUser}, o.op.List)
+}
+
+func (o *cachedUserOp) All(ctx context.Context) iter.Seq2[v1.User, error] {
+	return all(ctx, o.List)
+}
+
+func (o *cachedUserOp) Create(ctx context.Context, request *v1.UserDetail) (*v1.UserDetail, error) {
+	ret, err := o.op.Create(ctx, request)
+	return ret, o.c.mutated(cacheUser, err)
+}
+
+func (o *cachedUserOp) Read(ctx context.Context, id uuid.UUID) (*v1.UserDetail, error) {
+	return loadOne(ctx, o.c, cacheKey{kind: cacheUser, id: id}, o.op.Read)
+}
+
+func (o *cachedUserOp) Update(ctx context.Context, request *v1.UserDetail, id uuid.UUID) error {
+	return o.c.mutated(cacheUser, o.op.Update(ctx, request, id))
+}
+
+func (o *cachedUserOp) Delete(ctx context.Context, id uuid.UUID) error {
+	return o.c.mutated(cacheUser, o.op.Delete(ctx, id))
+}
+
+func (o *cachedUserOp) FindByName(ctx context.Context, name string) ([]v1.User, error) {
+	return userLookup(o.List).find(ctx, name)
+}
+
+func (o *cachedUserOp) GetByName(ctx context.Context, name string) (*v1.User, error) {
+	return userLookup(o.List).get(ctx, name)
+}
+
+func (o *cachedUserOp) Resolve(ctx context.Context, idOrName string) (*v1.User, error) {
+	return userLookup(o.List).resolve(ctx, idOrName)
+}
+
+// Ensure 名前による検索はキャッシュを経由しない
+func (o *cachedUserOp) Ensure(ctx context.Context, desired *v1.UserDetail) (*v1.UserDetail, EnsureAction, error) {
+	ret, action, err := o.op.Ensure(ctx, desired)
+	if action != EnsureUnchanged {
+		o.c.invalidate(cacheUser)
+	}
+	return ret, action, err
+}
+
+// DomainOp キャッシュを経由するDomainAPIを返す
+func (c *Cache) DomainOp() DomainAPI {
+	return &cachedDomainOp{c: c, op: NewDomainOp(c.client)}
+}
+
+type cachedDomainOp struct {
+	c  *Cache
+	op DomainAPI
+}
+
+var _ DomainAPI = (*cachedDomainOp)(nil)
+
+func (o *cachedDomainOp) List(ctx context.Context) ([]v1.Domain, error) {
+	return loadList(ctx, o.c, cacheKey{kind: cacheDomain}, o.op.List)
+}
+
+func (o *cachedDomainOp) All(ctx context.Context) iter.Seq2[v1.Domain, error] {
+	return all(ctx, o.List)
+}
+
+func (o *cachedDomainOp) Create(ctx context.Context, request *v1.Domain) (*v1.Domain, error) {
+	ret, err := o.op.Create(ctx, request)
+	return ret, o.c.mutated(cacheDomain, err)
+}
+
+func (o *cachedDomainOp) Delete(ctx context.Context, id uuid.UUID) error {
+	return o.c.mutated(cacheDomain, o.op.Delete(ctx, id))
+}
+
+func (o *cachedDomainOp) Update(ctx context.Context, request *v1.DomainPUT, id uuid.UUID) error {
+	return o.c.mutated(cacheDomain, o.op.Update(ctx, request, id))
+}
+
+func (o *cachedDomainOp) FindByName(ctx context.Context, domainName string) ([]v1.Domain, error) {
+	return domainLookup(o.List).find(ctx, domainName)
+}
+
+func (o *cachedDomainOp) GetByName(ctx context.Context, domainName string) (*v1.Domain, error) {
+	return domainLookup(o.List).get(ctx, domainName)
+}
+
+func (o *cachedDomainOp) Resolve(ctx context.Context, idOrName string) (*v1.Domain, error) {
+	return domainLookup(o.List).resolve(ctx, idOrName)
+}
+
+// CertificateOp キャッシュを経由するCertificateAPIを返す
+func (c *Cache) CertificateOp() CertificateAPI {
+	return &cachedCertificateOp{c: c, op: NewCertificateOp(c.client)}
+}
+
+type cachedCertificateOp struct {
+	c  *Cache
+	op CertificateAPI
+}
+
+var _ CertificateAPI = (*cachedCertificateOp)(nil)
+
+func (o *cachedCertificateOp) List(ctx context.Context) ([]v1.Certificate, error) {
+	return loadList(ctx, o.c, cacheKey{kind: cacheCertificate}, o.op.List)
+}
+
+func (o *cachedCertificateOp) All(ctx context.Context) iter.Seq2[v1.Certificate, error] {
+	return all(ctx, o.List)
+}
+
+func (o *cachedCertificateOp) Create(ctx context.Context, request *v1.Certificate) (*v1.Certificate, error) {
+	ret, err := o.op.Create(ctx, request)
+	return ret, o.c.mutated(cacheCertificate, err)
+}
+
+func (o *cachedCertificateOp) Update(ctx context.Context, request *v1.Certificate, id uuid.UUID) error {
+	return o.c.mutated(cacheCertificate, o.op.Update(ctx, request, id))
+}
+
+func (o *cachedCertificateOp) Delete(ctx context.Context, id uuid.UUID) error {
+	return o.c.mutated(cacheCertificate, o.op.Delete(ctx, id))
+}
+
+func (o *cachedCertificateOp) FindByName(ctx context.Context, name string) ([]v1.Certificate, error) {
+	return certificateLookup(o.List).find(ctx, name)
+}
+
+func (o *cachedCertificateOp) GetByName(ctx context.Context, name string) (*v1.Certificate, error) {
+	return certificateLookup(o.List).get(ctx, name)
+}
+
+func (o *cachedCertificateOp) Resolve(ctx context.Context, idOrName string) (*v1.Certificate, error) {
+	return certificateLookup(o.List).resolve(ctx, idOrName)
+}
@@ -0,0 +1,118 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apigw_test
+
+import (
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/sacloud/apigw-api-go"
+	"github.com/sacloud/apigw-api-go/apigwtest"
+	v1 "github.com/sacloud/apigw-api-go/apis/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCache(t *testing.T) {
+	// GETリクエストの数を数える
+	var gets atomic.Int32
+	count := func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			if req.Method == http.MethodGet {
+				gets.Add(1)
+			}
+			return next.Do(req)
+		})
+	}
+
+	_, client := apigwtest.NewClient(t, count)
+	ctx := t.Context()
+
+	cache := NewCache(client, CacheTTL{User: -1})
+	groupOp := cache.GroupOp()
+	created, err := groupOp.Create(ctx, &v1.Group{Name: v1.NewOptName("admins")})
+	require.NoError(t, err)
+
+	// 同時の読み取りは1度の取得にまとめる
+	gets.Store(0)
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Go(func() {
+			groups, err := groupOp.List(ctx)
+			assert.NoError(t, err)
+			assert.Len(t, groups, 1)
+		})
+	}
+	wg.Wait()
+	_, err = groupOp.GetByName(ctx, "admins")
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, gets.Load())
+
+	// 変更が成功すると無効化する
+	require.NoError(t, groupOp.Update(ctx, &v1.Group{Name: v1.NewOptName("operators")}, created.ID.Value))
+	group, err := groupOp.Read(ctx, created.ID.Value)
+	require.NoError(t, err)
+	assert.Equal(t, v1.Name("operators"), group.Name.Value)
+	assert.EqualValues(t, 2, gets.Load())
+
+	// 返した値を変更してもキャッシュには影響しない
+	group.Name.Value = "changed"
+	group, err = groupOp.Read(ctx, created.ID.Value)
+	require.NoError(t, err)
+	assert.Equal(t, v1.Name("operators"), group.Name.Value)
+	assert.EqualValues(t, 2, gets.Load())
+
+	// 有効期間が負の種類はキャッシュしない
+	userOp := cache.UserOp()
+	for range 2 {
+		_, err := userOp.List(ctx)
+		require.NoError(t, err)
+	}
+	assert.EqualValues(t, 4, gets.Load())
+
+	// エラーはキャッシュしない
+	_, err = groupOp.GetByName(ctx, "unknown")
+	assert.True(t, IsNotFound(err))
+	cache.Purge()
+	_, err = groupOp.Read(ctx, created.ID.Value)
+	require.NoError(t, err)
+	assert.EqualValues(t, 6, gets.Load())
+}
+
+func TestCache_TTL(t *testing.T) {
+	_, client := apigwtest.NewClient(t)
+	ctx := t.Context()
+
+	cache := NewCache(client, CacheTTL{Group: 50 * time.Millisecond})
+	groupOp := cache.GroupOp()
+	groups, err := groupOp.List(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, groups)
+
+	// キャッシュを経由しない変更は有効期間が過ぎるまで反映しない
+	_, err = NewGroupOp(client).Create(ctx, &v1.Group{Name: v1.NewOptName("admins")})
+	require.NoError(t, err)
+	groups, err = groupOp.List(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, groups)
+
+	assert.Eventually(t, func() bool {
+		groups, err := groupOp.List(ctx)
+		return err == nil && len(groups) == 1
+	}, time.Second, 10*time.Millisecond)
+}
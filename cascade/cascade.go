@@ -0,0 +1,269 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cascade リソースを、それに依存するリソースや参照とともに削除する。
+//
+//	deleter := cascade.NewDeleter(client)
+//	plan, err := deleter.Service(ctx, "backend")
+//	plan.Print(os.Stdout) // 実行する前に手順を確認できる
+//	err = deleter.Delete(ctx, plan)
+//
+// 依存するリソースは既存のList/Readで探す。
+// 親がなければ存在できないリソース(サービスのルート)は削除し、それ以外(ドメインの証明書、ルートの認可設定、
+// ユーザーの所属グループ、サービスのOIDC認証)は参照を解除する。
+package cascade
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	apigw "github.com/sacloud/apigw-api-go"
+	v1 "github.com/sacloud/apigw-api-go/apis/v1"
+	"github.com/sacloud/apigw-api-go/apply"
+	"github.com/sacloud/apigw-api-go/internal/jsondiff"
+)
+
+// ErrInUse 削除するリソースを使用しているリソースがあり、明示的な指定がなければ参照を解除できない
+var ErrInUse = errors.New("in use; detaching it requires disabling authentication")
+
+// Deleter 依存関係を考慮した削除の手順の計画と実行を行う
+type Deleter struct {
+	client *v1.Client
+
+	// DisableAuthentication OIDC認証を使用しているサービスがある場合に、OIDC認証を外して認証方式をnoneにする。
+	// falseの場合、Oidcはそのようなサービスがあればエラーを返す
+	DisableAuthentication bool
+}
+
+// NewDeleter Deleterを生成する
+func NewDeleter(client *v1.Client) *Deleter {
+	return &Deleter{client: client}
+}
+
+// Delete planの手順を順に実行する。エラーが発生した場合はその時点で中断する
+func (d *Deleter) Delete(ctx context.Context, plan *Plan) error {
+	for _, s := range plan.Steps {
+		if err := s.run(ctx); err != nil {
+			return fmt.Errorf("cascade: %s: %w", s, err)
+		}
+	}
+	return nil
+}
+
+// Service サービスを、そのルートとともに削除する手順を計画する
+func (d *Deleter) Service(ctx context.Context, idOrName string) (*Plan, error) {
+	serviceOp := apigw.NewServiceOp(d.client)
+	service, err := serviceOp.Resolve(ctx, idOrName)
+	if err != nil {
+		return nil, err
+	}
+	serviceID, serviceName := service.ID.Value, string(service.Name)
+
+	routeOp := apigw.NewRouteOp(d.client, serviceID)
+	routes, err := routeOp.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	plan := &Plan{}
+	for _, r := range routes {
+		id := r.ID.Value
+		plan.Steps = append(plan.Steps, &Step{Action: ActionDelete, Kind: apply.KindRoute, Name: apply.RouteName(serviceName, r), run: func(ctx context.Context) error {
+			return routeOp.Delete(ctx, id)
+		}})
+	}
+	plan.Steps = append(plan.Steps, &Step{Action: ActionDelete, Kind: apply.KindService, Name: serviceName, run: func(ctx context.Context) error {
+		return serviceOp.Delete(ctx, serviceID)
+	}})
+	return plan, nil
+}
+
+// Certificate 証明書を削除する手順を計画する。証明書を使用しているドメインからは証明書を外す
+func (d *Deleter) Certificate(ctx context.Context, idOrName string) (*Plan, error) {
+	certOp := apigw.NewCertificateOp(d.client)
+	cert, err := certOp.Resolve(ctx, idOrName)
+	if err != nil {
+		return nil, err
+	}
+	certID, certName := cert.ID.Value, string(cert.Name.Value)
+
+	domainOp := apigw.NewDomainOp(d.client)
+	domains, err := domainOp.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	plan := &Plan{}
+	for _, domain := range domains {
+		if !domain.CertificateId.Set || domain.CertificateId.Value != certID {
+			continue
+		}
+		id := domain.ID.Value
+		plan.Steps = append(plan.Steps, &Step{
+			Action: ActionDetach,
+			Kind:   apply.KindDomain,
+			Name:   domain.DomainName,
+			Detail: fmt.Sprintf("remove certificate %q", certName),
+			run: func(ctx context.Context) error {
+				return domainOp.Update(ctx, &v1.DomainPUT{}, id)
+			},
+		})
+	}
+	plan.Steps = append(plan.Steps, &Step{Action: ActionDelete, Kind: apply.KindCertificate, Name: certName, run: func(ctx context.Context) error {
+		return certOp.Delete(ctx, certID)
+	}})
+	return plan, nil
+}
+
+// Group グループを削除する手順を計画する。ルートの認可設定とユーザーの所属からグループを外す。
+// 認可するグループがなくなるルートは認可設定を無効にする
+func (d *Deleter) Group(ctx context.Context, idOrName string) (*Plan, error) {
+	groupOp := apigw.NewGroupOp(d.client)
+	group, err := groupOp.Resolve(ctx, idOrName)
+	if err != nil {
+		return nil, err
+	}
+	groupID, groupName := group.ID.Value, string(group.Name.Value)
+
+	plan := &Plan{}
+	for sr, err := range apigw.NewWalker(d.client).AllRoutes(ctx) {
+		if err != nil {
+			return nil, err
+		}
+		extraOp := apigw.NewRouteExtraOp(d.client, sr.Service.ID.Value, sr.Route.ID.Value)
+		authz, err := extraOp.ReadAuthorization(ctx)
+		if apigw.IsNotFound(err) {
+			// 認可設定のないルート
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !authz.IsACLEnabled {
+			continue
+		}
+		var rest []v1.RouteAuthorization
+		for _, g := range authz.Groups {
+			if g.ID.Value != groupID {
+				rest = append(rest, v1.RouteAuthorization{ID: g.ID, Enabled: g.Enabled})
+			}
+		}
+		if len(rest) == len(authz.Groups) {
+			continue
+		}
+		step := &Step{
+			Action: ActionDetach,
+			Kind:   apply.KindRouteAuthorization,
+			Name:   apply.RouteName(string(sr.Service.Name), sr.Route),
+			Detail: fmt.Sprintf("remove group %q", groupName),
+			run: func(ctx context.Context) error {
+				return extraOp.EnableAuthorization(ctx, rest)
+			},
+		}
+		if len(rest) == 0 {
+			step.Detail = fmt.Sprintf("remove group %q and disable authorization", groupName)
+			step.run = extraOp.DisableAuthorization
+		}
+		plan.Steps = append(plan.Steps, step)
+	}
+
+	users, err := apigw.NewUserOp(d.client).List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, u := range users {
+		userOp := apigw.NewUserExtraOp(d.client, u.ID.Value)
+		groups, err := userOp.ListGroup(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, g := range groups {
+			if g.ID != groupID || !g.IsAssigned {
+				continue
+			}
+			plan.Steps = append(plan.Steps, &Step{
+				Action: ActionDetach,
+				Kind:   apply.KindUserGroups,
+				Name:   string(u.Name),
+				Detail: fmt.Sprintf("remove from group %q", groupName),
+				run: func(ctx context.Context) error {
+					return userOp.UpdateGroup(ctx, groupID.String(), false)
+				},
+			})
+		}
+	}
+
+	plan.Steps = append(plan.Steps, &Step{Action: ActionDelete, Kind: apply.KindGroup, Name: groupName, run: func(ctx context.Context) error {
+		return groupOp.Delete(ctx, groupID)
+	}})
+	return plan, nil
+}
+
+// Oidc OIDC認証を削除する手順を計画する。
+// OIDC認証を使用しているサービスがある場合はエラーを返す。
+// DisableAuthenticationがtrueの場合は、それらのサービスからOIDC認証を外し、認証方式をnoneにする
+func (d *Deleter) Oidc(ctx context.Context, idOrName string) (*Plan, error) {
+	oidcOp := apigw.NewOidcOp(d.client)
+	oidc, err := oidcOp.Resolve(ctx, idOrName)
+	if err != nil {
+		return nil, err
+	}
+	oidcID, oidcName := oidc.ID.Value, string(oidc.Name)
+
+	detail, err := oidcOp.Read(ctx, oidcID)
+	if err != nil {
+		return nil, err
+	}
+	if len(detail.Services) > 0 && !d.DisableAuthentication {
+		names := make([]string, len(detail.Services))
+		for i, s := range detail.Services {
+			names[i] = strconv.Quote(string(s.Name.Value))
+		}
+		return nil, fmt.Errorf("cascade: oidc %q is used by services %s: %w", oidcName, strings.Join(names, ", "), ErrInUse)
+	}
+	serviceOp := apigw.NewServiceOp(d.client)
+	plan := &Plan{}
+	for _, s := range detail.Services {
+		id := s.ID.Value
+		plan.Steps = append(plan.Steps, &Step{
+			Action: ActionDetach,
+			Kind:   apply.KindService,
+			Name:   string(s.Name.Value),
+			Detail: fmt.Sprintf("remove oidc %q and set authentication to none", oidcName),
+			run: func(ctx context.Context) error {
+				return detachOidc(ctx, serviceOp, id)
+			},
+		})
+	}
+	plan.Steps = append(plan.Steps, &Step{Action: ActionDelete, Kind: apply.KindOidc, Name: oidcName, run: func(ctx context.Context) error {
+		return oidcOp.Delete(ctx, oidcID)
+	}})
+	return plan, nil
+}
+
+// detachOidc サービスのOIDC認証を外す。実行時点のサービスの設定を読み込み、それ以外の項目は維持する
+func detachOidc(ctx context.Context, op apigw.ServiceAPI, id uuid.UUID) error {
+	service, err := op.Read(ctx, id)
+	if err != nil {
+		return err
+	}
+	var req v1.ServiceDetail
+	if err := jsondiff.Overlay(&req, service, nil, "routeHost", "subscription", "oidc"); err != nil {
+		return err
+	}
+	req.Authentication = v1.NewOptServiceDetailAuthentication(v1.ServiceDetailAuthenticationNone)
+	return op.Update(ctx, &req, id)
+}
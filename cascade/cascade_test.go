@@ -0,0 +1,221 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cascade_test
+
+import (
+	"os"
+	"testing"
+
+	apigw "github.com/sacloud/apigw-api-go"
+	"github.com/sacloud/apigw-api-go/apigwtest"
+	v1 "github.com/sacloud/apigw-api-go/apis/v1"
+	"github.com/sacloud/apigw-api-go/apply"
+	"github.com/sacloud/apigw-api-go/cascade"
+	"github.com/sacloud/apigw-api-go/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setup(t *testing.T) *v1.Client {
+	t.Helper()
+	fake, client := apigwtest.NewClient(t)
+	ctx := t.Context()
+	require.NoError(t, apigw.NewSubscriptionOp(client).Create(ctx, fake.Plans()[0].ID.Value, "test-sub"))
+	_, err := apigw.NewOidcOp(client).Create(ctx, &v1.Oidc{
+		Name:                  "test_oidc",
+		AuthenticationMethods: v1.AuthenticationMethods{v1.AuthenticationMethodsItemAccessToken},
+		Issuer:                "https://idp.example.com",
+		ClientId:              "client",
+		ClientSecret:          "client-secret",
+		Scopes:                []string{"openid"},
+	})
+	require.NoError(t, err)
+
+	crt, err := os.ReadFile("../testdata/rsa.crt")
+	require.NoError(t, err)
+	key, err := os.ReadFile("../testdata/rsa.key")
+	require.NoError(t, err)
+
+	doc := &spec.Document{
+		Version: spec.Version,
+		Certificates: []spec.Certificate{{
+			Name: "test-cert",
+			RSA:  &spec.KeyPair{Cert: string(crt), Key: string(key)},
+		}},
+		Domains: []spec.Domain{{Name: "api.example.com", Certificate: "test-cert"}},
+		Groups:  []spec.Group{{Name: "admins"}, {Name: "developers"}},
+		Users:   []spec.User{{Name: "alice", Groups: []string{"developers", "admins"}}, {Name: "bob", Groups: []string{"developers"}}},
+		Services: []spec.Service{{
+			Name:         "backend",
+			Subscription: "test-sub",
+			Protocol:     "https",
+			Host:         "backend.example.com",
+			Oidc:         "test_oidc",
+			Routes: []spec.Route{{
+				Name:          "api",
+				Path:          "/api",
+				Authorization: authorization("admins", "developers"),
+			}, {
+				Name:          "admin",
+				Path:          "/admin",
+				Authorization: authorization("admins"),
+			}, {
+				Name: "health",
+				Path: "/health",
+			}},
+		}},
+	}
+	planner := apply.NewPlanner(client)
+	plan, err := planner.Plan(ctx, doc)
+	require.NoError(t, err)
+	require.NoError(t, planner.Apply(ctx, plan))
+	return client
+}
+
+func authorization(groups ...string) *spec.RouteAuthorization {
+	a := &spec.RouteAuthorization{}
+	for _, g := range groups {
+		a.Groups = append(a.Groups, spec.RouteAuthorizationGroup{Name: g})
+	}
+	return a
+}
+
+func TestDeleter_Service(t *testing.T) {
+	client := setup(t)
+	ctx := t.Context()
+	deleter := cascade.NewDeleter(client)
+
+	plan, err := deleter.Service(ctx, "backend")
+	require.NoError(t, err)
+	assert.Equal(t, `- route "backend/api"
+- route "backend/admin"
+- route "backend/health"
+- service "backend"
+
+Teardown: 4 to delete, 0 to detach.
+`, plan.String())
+
+	require.NoError(t, deleter.Delete(ctx, plan))
+	services, err := apigw.NewServiceOp(client).List(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, services)
+}
+
+func TestDeleter_Certificate(t *testing.T) {
+	client := setup(t)
+	ctx := t.Context()
+	deleter := cascade.NewDeleter(client)
+
+	// 依存関係を無視した削除は失敗する
+	cert, err := apigw.NewCertificateOp(client).Resolve(ctx, "test-cert")
+	require.NoError(t, err)
+	require.Error(t, apigw.NewCertificateOp(client).Delete(ctx, cert.ID.Value))
+
+	plan, err := deleter.Certificate(ctx, "test-cert")
+	require.NoError(t, err)
+	require.Len(t, plan.Steps, 2)
+	assert.Equal(t, cascade.ActionDetach, plan.Steps[0].Action)
+	assert.Equal(t, `detach domain "api.example.com": remove certificate "test-cert"`, plan.Steps[0].String())
+
+	require.NoError(t, deleter.Delete(ctx, plan))
+	domains, err := apigw.NewDomainOp(client).List(ctx)
+	require.NoError(t, err)
+	require.Len(t, domains, 1)
+	assert.False(t, domains[0].CertificateId.Set)
+	certs, err := apigw.NewCertificateOp(client).List(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, certs)
+}
+
+func TestDeleter_Group(t *testing.T) {
+	client := setup(t)
+	ctx := t.Context()
+	deleter := cascade.NewDeleter(client)
+
+	plan, err := deleter.Group(ctx, "admins")
+	require.NoError(t, err)
+	assert.Equal(t, `~ route.authorization "backend/api"
+    remove group "admins"
+~ route.authorization "backend/admin"
+    remove group "admins" and disable authorization
+~ user.groups "alice"
+    remove from group "admins"
+- group "admins"
+
+Teardown: 1 to delete, 3 to detach.
+`, plan.String())
+
+	require.NoError(t, deleter.Delete(ctx, plan))
+	service, err := apigw.NewServiceOp(client).Resolve(ctx, "backend")
+	require.NoError(t, err)
+	routeOp := apigw.NewRouteOp(client, service.ID.Value)
+	api, err := routeOp.Resolve(ctx, "api")
+	require.NoError(t, err)
+	authz, err := apigw.NewRouteExtraOp(client, service.ID.Value, api.ID.Value).ReadAuthorization(ctx)
+	require.NoError(t, err)
+	require.Len(t, authz.Groups, 1)
+	assert.Equal(t, v1.Name("developers"), authz.Groups[0].Name.Value)
+	_, err = apigw.NewGroupOp(client).Resolve(ctx, "admins")
+	assert.True(t, apigw.IsNotFound(err))
+}
+
+func TestDeleter_Oidc(t *testing.T) {
+	client := setup(t)
+	ctx := t.Context()
+	deleter := cascade.NewDeleter(client)
+
+	// 使用しているサービスがある場合は、明示的な指定がなければ認証方式を変更しない
+	_, err := deleter.Oidc(ctx, "test_oidc")
+	require.ErrorIs(t, err, cascade.ErrInUse)
+	assert.ErrorContains(t, err, `oidc "test_oidc" is used by services "backend"`)
+
+	deleter.DisableAuthentication = true
+	plan, err := deleter.Oidc(ctx, "test_oidc")
+	require.NoError(t, err)
+	require.Len(t, plan.Steps, 2)
+	assert.Equal(t, `detach service "backend": remove oidc "test_oidc" and set authentication to none`, plan.Steps[0].String())
+
+	require.NoError(t, deleter.Delete(ctx, plan))
+	service, err := apigw.NewServiceOp(client).Resolve(ctx, "backend")
+	require.NoError(t, err)
+	detail, err := apigw.NewServiceOp(client).Read(ctx, service.ID.Value)
+	require.NoError(t, err)
+	assert.False(t, detail.Oidc.Set)
+	assert.Equal(t, "backend.example.com", detail.Host)
+	oidcs, err := apigw.NewOidcOp(client).List(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, oidcs)
+}
+
+func TestDeleter_Delete(t *testing.T) {
+	client := setup(t)
+	ctx := t.Context()
+	deleter := cascade.NewDeleter(client)
+
+	// 計画の後に依存するリソースが削除された場合は、その手順で中断する
+	plan, err := deleter.Service(ctx, "backend")
+	require.NoError(t, err)
+	service, err := apigw.NewServiceOp(client).Resolve(ctx, "backend")
+	require.NoError(t, err)
+	routeOp := apigw.NewRouteOp(client, service.ID.Value)
+	api, err := routeOp.Resolve(ctx, "api")
+	require.NoError(t, err)
+	require.NoError(t, routeOp.Delete(ctx, api.ID.Value))
+
+	err = deleter.Delete(ctx, plan)
+	assert.ErrorContains(t, err, `cascade: delete route "backend/api"`)
+	_, err = apigw.NewServiceOp(client).Resolve(ctx, "backend")
+	assert.NoError(t, err)
+}
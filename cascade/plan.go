@@ -0,0 +1,82 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cascade
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/sacloud/apigw-api-go/apply"
+	"github.com/sacloud/apigw-api-go/internal/planfmt"
+)
+
+// Action 手順の種別。applyパッケージのActionと同じ型
+type Action = planfmt.Action
+
+const (
+	// ActionDelete リソースを削除する
+	ActionDelete = planfmt.Delete
+	// ActionDetach 削除するリソースへの参照を解除する
+	ActionDetach = planfmt.Detach
+)
+
+// Step 削除の1つの手順。
+// Kindはapplyパッケージと同じリソースの種別で、Nameはリソースの名前(ルートはapply.RouteNameの形式)となる
+type Step struct {
+	Action Action
+	Kind   apply.Kind
+	Name   string
+	// Detail 参照の解除の内容。ActionDetachの場合のみ設定される
+	Detail string
+
+	run func(ctx context.Context) error
+}
+
+func (s *Step) String() string {
+	if s.Detail != "" {
+		return fmt.Sprintf("%s %s %q: %s", s.Action, s.Kind, s.Name, s.Detail)
+	}
+	return fmt.Sprintf("%s %s %q", s.Action, s.Kind, s.Name)
+}
+
+// Plan 削除の手順の一覧。Stepsは実行する順に並び、最後の手順が対象のリソースの削除となる
+type Plan struct {
+	Steps []*Step
+}
+
+// Summary 削除と参照の解除の件数を返す
+func (p *Plan) Summary() (del, detach int) {
+	counts := planfmt.Count(p.Steps, func(s *Step) Action { return s.Action })
+	return counts[ActionDelete], counts[ActionDetach]
+}
+
+// Print 手順の一覧を人が読める形式でwに出力する
+func (p *Plan) Print(w io.Writer) error {
+	_, err := io.WriteString(w, p.String())
+	return err
+}
+
+func (p *Plan) String() string {
+	lines := make([]planfmt.Line, len(p.Steps))
+	for i, s := range p.Steps {
+		lines[i] = planfmt.Line{Action: s.Action, Kind: string(s.Kind), Name: s.Name}
+		if s.Detail != "" {
+			lines[i].Details = []string{s.Detail}
+		}
+	}
+	del, detach := p.Summary()
+	return planfmt.Format(lines, fmt.Sprintf("Teardown: %d to delete, %d to detach.", del, detach))
+}
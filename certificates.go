@@ -17,6 +17,7 @@ package apigw
 import (
 	"context"
 	"errors"
+	"iter"
 
 	"github.com/google/uuid"
 	v1 "github.com/sacloud/apigw-api-go/apis/v1"
@@ -24,9 +25,17 @@ import (
 
 type CertificateAPI interface {
 	List(ctx context.Context) ([]v1.Certificate, error)
+	// All Listの結果を1件ずつ返すイテレータ。取得に失敗した場合はエラーを1度だけ返す
+	All(ctx context.Context) iter.Seq2[v1.Certificate, error]
 	Create(ctx context.Context, request *v1.Certificate) (*v1.Certificate, error)
 	Update(ctx context.Context, request *v1.Certificate, id uuid.UUID) error
 	Delete(ctx context.Context, id uuid.UUID) error
+	// FindByName nameが一致するものをすべて返す
+	FindByName(ctx context.Context, name string) ([]v1.Certificate, error)
+	// GetByName nameが一致するものを1件返す。見つからない場合は*NotFoundError、複数ある場合は*AmbiguousErrorを返す
+	GetByName(ctx context.Context, name string) (*v1.Certificate, error)
+	// Resolve IDまたはnameがidOrNameと一致するものを1件返す
+	Resolve(ctx context.Context, idOrName string) (*v1.Certificate, error)
 }
 
 var _ CertificateAPI = (*certificateOp)(nil)
@@ -59,7 +68,35 @@ func (op *certificateOp) List(ctx context.Context) ([]v1.Certificate, error) {
 	return nil, NewAPIError("Certificate.List", 0, nil)
 }
 
+func (op *certificateOp) All(ctx context.Context) iter.Seq2[v1.Certificate, error] {
+	return all(ctx, op.List)
+}
+
+func certificateLookup(list func(context.Context) ([]v1.Certificate, error)) lookup[v1.Certificate] {
+	return lookup[v1.Certificate]{
+		resource: "certificate",
+		list:     list,
+		name:     func(c *v1.Certificate) string { return string(c.Name.Value) },
+		id:       func(c *v1.Certificate) uuid.UUID { return c.ID.Value },
+	}
+}
+
+func (op *certificateOp) FindByName(ctx context.Context, name string) ([]v1.Certificate, error) {
+	return certificateLookup(op.List).find(ctx, name)
+}
+
+func (op *certificateOp) GetByName(ctx context.Context, name string) (*v1.Certificate, error) {
+	return certificateLookup(op.List).get(ctx, name)
+}
+
+func (op *certificateOp) Resolve(ctx context.Context, idOrName string) (*v1.Certificate, error) {
+	return certificateLookup(op.List).resolve(ctx, idOrName)
+}
+
 func (op *certificateOp) Create(ctx context.Context, request *v1.Certificate) (*v1.Certificate, error) {
+	if err := validateRequest("Certificate.Create", request); err != nil {
+		return nil, err
+	}
 	res, err := op.client.AddCertificate(ctx, request)
 	if err != nil {
 		return nil, NewAPIError("Certificate.Create", 0, err)
@@ -82,6 +119,9 @@ func (op *certificateOp) Create(ctx context.Context, request *v1.Certificate) (*
 }
 
 func (op *certificateOp) Update(ctx context.Context, request *v1.Certificate, id uuid.UUID) error {
+	if err := validateRequest("Certificate.Update", request); err != nil {
+		return err
+	}
 	res, err := op.client.UpdateCertificate(ctx, request, v1.UpdateCertificateParams{CertificateId: id})
 	if err != nil {
 		return NewAPIError("Certificate.Update", 0, err)
@@ -16,6 +16,7 @@ package apigw
 
 import (
 	"fmt"
+	"net/http"
 	"runtime"
 
 	v1 "github.com/sacloud/apigw-api-go/apis/v1"
@@ -38,7 +39,22 @@ var UserAgent = fmt.Sprintf(
 	runtime.GOARCH,
 )
 
-func NewClient(client saclient.ClientAPI) (*v1.Client, error) {
+// Doer HTTPリクエストを送信するクライアント
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// DoerFunc 関数をDoerとして使うためのアダプタ。http.HandlerFuncと同様に、fはDoの処理となる
+type DoerFunc func(req *http.Request) (*http.Response, error)
+
+// Do f(req)を呼び出す
+func (f DoerFunc) Do(req *http.Request) (*http.Response, error) { return f(req) }
+
+// Layer saclientが送信する前のリクエストを包み、再試行やログ出力などの処理を追加する。
+// NewClientに複数指定した場合は、先に指定したものが外側(APIの呼び出し元に近い側)になる
+type Layer func(next Doer) Doer
+
+func NewClient(client saclient.ClientAPI, layers ...Layer) (*v1.Client, error) {
 	endpointConfig, err := client.EndpointConfig()
 	if err != nil {
 		return nil, NewError("unable to load endpoint configuration", err)
@@ -47,10 +63,10 @@ func NewClient(client saclient.ClientAPI) (*v1.Client, error) {
 	if ep, ok := endpointConfig.Endpoints[ServiceKey]; ok && ep != "" {
 		endpoint = ep
 	}
-	return NewClientWithAPIRootURL(client, endpoint)
+	return NewClientWithAPIRootURL(client, endpoint, layers...)
 }
 
-func NewClientWithAPIRootURL(client saclient.ClientAPI, apiRootURL string) (*v1.Client, error) {
+func NewClientWithAPIRootURL(client saclient.ClientAPI, apiRootURL string, layers ...Layer) (*v1.Client, error) {
 	dupable, ok := client.(saclient.ClientOptionAPI)
 	if !ok {
 		return nil, NewError("client does not implement saclient.ClientOptionAPI", nil)
@@ -62,5 +78,9 @@ func NewClientWithAPIRootURL(client saclient.ClientAPI, apiRootURL string) (*v1.
 	if err != nil {
 		return nil, err
 	}
-	return v1.NewClient(apiRootURL, v1.WithClient(augmented))
+	var doer Doer = augmented
+	for i := len(layers) - 1; i >= 0; i-- {
+		doer = layers[i](doer)
+	}
+	return v1.NewClient(apiRootURL, v1.WithClient(doer))
 }
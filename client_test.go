@@ -55,6 +55,31 @@ func TestNewClient_WithCustomEndpoint(t *testing.T) {
 	assert.Len(requests, 1)
 }
 
+func TestNewClient_WithLayers(t *testing.T) {
+	assert := require.New(t)
+
+	tracker := newMockRequestTracker()
+	defer tracker.Close()
+
+	var order []string
+	layer := func(name string) Layer {
+		return func(next Doer) Doer {
+			return DoerFunc(func(req *http.Request) (*http.Response, error) {
+				order = append(order, name)
+				return next.Do(req)
+			})
+		}
+	}
+
+	var theClient saclient.Client
+	client, err := NewClientWithAPIRootURL(&theClient, tracker.URL(), layer("outer"), layer("inner"))
+	assert.NoError(err)
+
+	_, _ = NewSubscriptionOp(client).List(t.Context())
+	assert.Equal([]string{"outer", "inner"}, order)
+	assert.Len(tracker.Requests(), 1)
+}
+
 type mockRequestTracker struct {
 	mu       sync.Mutex
 	requests []*http.Request
@@ -0,0 +1,71 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+
+	v1 "github.com/sacloud/apigw-api-go/apis/v1"
+	"github.com/sacloud/apigw-api-go/cascade"
+)
+
+// cascadeFlags 削除コマンドで共通の、依存リソースを含めて削除するためのフラグ
+type cascadeFlags struct {
+	cascade *bool
+	dryRun  *bool
+	yes     *bool
+}
+
+func newCascadeFlags(c *call) *cascadeFlags {
+	return &cascadeFlags{
+		cascade: c.Bool("cascade", false, "also delete dependent resources and detach references to the resource"),
+		dryRun:  c.Bool("dry-run", false, "show the teardown steps of --cascade without executing them"),
+		yes:     c.Bool("yes", false, "delete with --cascade without confirmation"),
+	}
+}
+
+// run --cascadeが指定された場合に、削除の手順を表示して実行する。指定されなかった場合はfalseを返す
+func (f *cascadeFlags) run(ctx context.Context, a *app, c *call, client *v1.Client, ref string,
+	plan func(d *cascade.Deleter, ctx context.Context, idOrName string) (*cascade.Plan, error)) (bool, error) {
+	if !*f.cascade {
+		if *f.dryRun {
+			return true, &usageError{msg: c.path + ": --dry-run requires --cascade", print: c.usage}
+		}
+		return false, nil
+	}
+	deleter := cascade.NewDeleter(client)
+	p, err := plan(deleter, ctx, ref)
+	if err != nil {
+		return true, err
+	}
+	if err := p.Print(a.stdout); err != nil {
+		return true, err
+	}
+	if *f.dryRun {
+		return true, nil
+	}
+	if !*f.yes {
+		ok, err := a.confirm("Delete these resources?")
+		if err != nil || !ok {
+			return true, err
+		}
+	}
+	if err := deleter.Delete(ctx, p); err != nil {
+		return true, err
+	}
+	_, err = fmt.Fprintln(a.stdout, "Delete complete.")
+	return true, err
+}
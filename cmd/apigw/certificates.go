@@ -0,0 +1,154 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	apigw "github.com/sacloud/apigw-api-go"
+	v1 "github.com/sacloud/apigw-api-go/apis/v1"
+	"github.com/sacloud/apigw-api-go/cascade"
+)
+
+var certificateColumns = []column[v1.Certificate]{
+	{"ID", func(c *v1.Certificate) string { return formatUUID(c.ID) }},
+	{"NAME", func(c *v1.Certificate) string { return string(c.Name.Value) }},
+	{"RSA EXPIRES", func(c *v1.Certificate) string { return formatTime(c.Rsa.Value.ExpiredAt) }},
+	{"ECDSA EXPIRES", func(c *v1.Certificate) string { return formatTime(c.Ecdsa.Value.ExpiredAt) }},
+}
+
+var certificateCommand = &command{
+	name:    "cert",
+	summary: "Manage certificates",
+	sub: []*command{
+		{name: "list", summary: "List certificates", run: certificateList},
+		{name: "upload", summary: "Upload a certificate and its private key", run: certificateUpload},
+		{name: "update", args: "CERT", summary: "Replace a certificate and its private key", run: certificateUpdate},
+		{name: "delete", args: "CERT", summary: "Delete a certificate", run: certificateDelete},
+	},
+}
+
+func findCertificate(ctx context.Context, client *v1.Client, ref string) (*v1.Certificate, error) {
+	return apigw.NewCertificateOp(client).Resolve(ctx, ref)
+}
+
+func certificateList(ctx context.Context, a *app, c *call) error {
+	_, client, err := c.prepare(0)
+	if err != nil {
+		return err
+	}
+	certs, err := apigw.NewCertificateOp(client).List(ctx)
+	if err != nil {
+		return err
+	}
+	return list(a, certs, certificateColumns...)
+}
+
+// certificateFiles 証明書と秘密鍵のファイルを指定するフラグ
+type certificateFiles struct {
+	cert, key *string
+	ecdsa     *bool
+}
+
+func newCertificateFiles(c *call) *certificateFiles {
+	return &certificateFiles{
+		cert:  c.String("cert", "", "the PEM encoded certificate `file` (required)"),
+		key:   c.String("key", "", "the PEM encoded private key `file` (required)"),
+		ecdsa: c.Bool("ecdsa", false, "the key is an ECDSA key instead of RSA"),
+	}
+}
+
+// set ファイルを読み込み、reqに設定する
+func (f *certificateFiles) set(req *v1.Certificate) error {
+	cert, err := os.ReadFile(filepath.Clean(*f.cert))
+	if err != nil {
+		return err
+	}
+	key, err := os.ReadFile(filepath.Clean(*f.key))
+	if err != nil {
+		return err
+	}
+	details := v1.NewOptCertificateDetails(v1.CertificateDetails{
+		Cert: v1.NewOptString(string(cert)),
+		Key:  v1.NewOptString(string(key)),
+	})
+	if *f.ecdsa {
+		req.Ecdsa = details
+	} else {
+		req.Rsa = details
+	}
+	return nil
+}
+
+func certificateUpload(ctx context.Context, a *app, c *call) error {
+	name := c.String("name", "", "the certificate name (required)")
+	files := newCertificateFiles(c)
+	_, client, err := c.prepare(0, "name", "cert", "key")
+	if err != nil {
+		return err
+	}
+	req := v1.Certificate{Name: v1.NewOptName(v1.Name(*name))}
+	if err := files.set(&req); err != nil {
+		return err
+	}
+	created, err := apigw.NewCertificateOp(client).Create(ctx, &req)
+	if err != nil {
+		return err
+	}
+	return show(a, created, certificateColumns...)
+}
+
+func certificateUpdate(ctx context.Context, a *app, c *call) error {
+	files := newCertificateFiles(c)
+	args, client, err := c.prepare(1, "cert", "key")
+	if err != nil {
+		return err
+	}
+	current, err := findCertificate(ctx, client, args[0])
+	if err != nil {
+		return err
+	}
+	req := v1.Certificate{Name: current.Name}
+	if err := files.set(&req); err != nil {
+		return err
+	}
+	op := apigw.NewCertificateOp(client)
+	if err := op.Update(ctx, &req, current.ID.Value); err != nil {
+		return err
+	}
+	updated, err := findCertificate(ctx, client, current.ID.Value.String())
+	if err != nil {
+		return err
+	}
+	return show(a, updated, certificateColumns...)
+}
+
+func certificateDelete(ctx context.Context, a *app, c *call) error {
+	cf := newCascadeFlags(c)
+	args, client, err := c.prepare(1)
+	if err != nil {
+		return err
+	}
+	if ok, err := cf.run(ctx, a, c, client, args[0], (*cascade.Deleter).Certificate); ok {
+		return err
+	}
+	cert, err := findCertificate(ctx, client, args[0])
+	if err != nil {
+		return err
+	}
+	return apigw.NewCertificateOp(client).Delete(ctx, cert.ID.Value)
+}
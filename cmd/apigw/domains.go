@@ -0,0 +1,123 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+
+	apigw "github.com/sacloud/apigw-api-go"
+	v1 "github.com/sacloud/apigw-api-go/apis/v1"
+)
+
+var domainColumns = []column[v1.Domain]{
+	{"ID", func(d *v1.Domain) string { return formatUUID(d.ID) }},
+	{"NAME", func(d *v1.Domain) string { return d.DomainName }},
+	{"CERTIFICATE", func(d *v1.Domain) string { return d.CertificateName.Value }},
+}
+
+var domainCommand = &command{
+	name:    "domain",
+	summary: "Manage custom domains",
+	sub: []*command{
+		{name: "list", summary: "List domains", run: domainList},
+		{name: "create", args: "DOMAIN", summary: "Register a domain", run: domainCreate},
+		{name: "update", args: "DOMAIN", summary: "Change the certificate of a domain", run: domainUpdate},
+		{name: "delete", args: "DOMAIN", summary: "Delete a domain", run: domainDelete},
+	},
+}
+
+func findDomain(ctx context.Context, client *v1.Client, ref string) (*v1.Domain, error) {
+	return apigw.NewDomainOp(client).Resolve(ctx, ref)
+}
+
+func domainList(ctx context.Context, a *app, c *call) error {
+	_, client, err := c.prepare(0)
+	if err != nil {
+		return err
+	}
+	domains, err := apigw.NewDomainOp(client).List(ctx)
+	if err != nil {
+		return err
+	}
+	return list(a, domains, domainColumns...)
+}
+
+// certificateID certの証明書のIDを返す。certが空の場合は証明書を指定しない
+func certificateID(ctx context.Context, client *v1.Client, cert string) (v1.OptUUID, error) {
+	if cert == "" {
+		return v1.OptUUID{}, nil
+	}
+	c, err := findCertificate(ctx, client, cert)
+	if err != nil {
+		return v1.OptUUID{}, err
+	}
+	return c.ID, nil
+}
+
+func domainCreate(ctx context.Context, a *app, c *call) error {
+	cert := c.String("cert", "", "the certificate name or ID")
+	args, client, err := c.prepare(1)
+	if err != nil {
+		return err
+	}
+	certID, err := certificateID(ctx, client, *cert)
+	if err != nil {
+		return err
+	}
+	created, err := apigw.NewDomainOp(client).Create(ctx, &v1.Domain{DomainName: args[0], CertificateId: certID})
+	if err != nil {
+		return err
+	}
+	domain, err := findDomain(ctx, client, created.ID.Value.String())
+	if err != nil {
+		return err
+	}
+	return show(a, domain, domainColumns...)
+}
+
+func domainUpdate(ctx context.Context, a *app, c *call) error {
+	cert := c.String("cert", "", "the certificate name or ID; omit to detach the certificate")
+	args, client, err := c.prepare(1)
+	if err != nil {
+		return err
+	}
+	domain, err := findDomain(ctx, client, args[0])
+	if err != nil {
+		return err
+	}
+	certID, err := certificateID(ctx, client, *cert)
+	if err != nil {
+		return err
+	}
+	if err := apigw.NewDomainOp(client).Update(ctx, &v1.DomainPUT{CertificateId: certID}, domain.ID.Value); err != nil {
+		return err
+	}
+	if domain, err = findDomain(ctx, client, domain.ID.Value.String()); err != nil {
+		return err
+	}
+	return show(a, domain, domainColumns...)
+}
+
+func domainDelete(ctx context.Context, a *app, c *call) error {
+	args, client, err := c.prepare(1)
+	if err != nil {
+		return err
+	}
+	domain, err := findDomain(ctx, client, args[0])
+	if err != nil {
+		return err
+	}
+	return apigw.NewDomainOp(client).Delete(ctx, domain.ID.Value)
+}
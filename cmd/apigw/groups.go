@@ -0,0 +1,139 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	apigw "github.com/sacloud/apigw-api-go"
+	v1 "github.com/sacloud/apigw-api-go/apis/v1"
+	"github.com/sacloud/apigw-api-go/cascade"
+)
+
+var groupColumns = []column[v1.Group]{
+	{"ID", func(g *v1.Group) string { return formatUUID(g.ID) }},
+	{"NAME", func(g *v1.Group) string { return string(g.Name.Value) }},
+	{"TAGS", func(g *v1.Group) string { return formatList(g.Tags) }},
+	{"CREATED", func(g *v1.Group) string { return formatTime(g.CreatedAt) }},
+}
+
+var groupCommand = &command{
+	name:    "group",
+	summary: "Manage groups",
+	sub: []*command{
+		{name: "list", summary: "List groups", run: groupList},
+		{name: "get", args: "GROUP", summary: "Show a group", run: groupGet},
+		{name: "create", summary: "Create a group", run: groupCreate},
+		{name: "update", args: "GROUP", summary: "Update a group", run: groupUpdate},
+		{name: "delete", args: "GROUP", summary: "Delete a group", run: groupDelete},
+	},
+}
+
+func findGroup(ctx context.Context, client *v1.Client, ref string) (*v1.Group, error) {
+	return apigw.NewGroupOp(client).Resolve(ctx, ref)
+}
+
+func showGroup(ctx context.Context, a *app, client *v1.Client, id uuid.UUID) error {
+	group, err := apigw.NewGroupOp(client).Read(ctx, id)
+	if err != nil {
+		return err
+	}
+	return show(a, group, groupColumns...)
+}
+
+func groupList(ctx context.Context, a *app, c *call) error {
+	_, client, err := c.prepare(0)
+	if err != nil {
+		return err
+	}
+	groups, err := apigw.NewGroupOp(client).List(ctx)
+	if err != nil {
+		return err
+	}
+	return list(a, groups, groupColumns...)
+}
+
+func groupGet(ctx context.Context, a *app, c *call) error {
+	args, client, err := c.prepare(1)
+	if err != nil {
+		return err
+	}
+	group, err := findGroup(ctx, client, args[0])
+	if err != nil {
+		return err
+	}
+	return showGroup(ctx, a, client, group.ID.Value)
+}
+
+func groupFlags(c *call) *body {
+	b := newBody(c)
+	b.String("name", "name", "the group name")
+	b.Strings("tag", "tags", "a tag")
+	return b
+}
+
+func groupCreate(ctx context.Context, a *app, c *call) error {
+	b := groupFlags(c)
+	_, client, err := c.prepare(0)
+	if err != nil {
+		return err
+	}
+	var req v1.Group
+	if err := b.build(&req, nil); err != nil {
+		return err
+	}
+	created, err := apigw.NewGroupOp(client).Create(ctx, &req)
+	if err != nil {
+		return err
+	}
+	return showGroup(ctx, a, client, created.ID.Value)
+}
+
+func groupUpdate(ctx context.Context, a *app, c *call) error {
+	b := groupFlags(c)
+	args, client, err := c.prepare(1)
+	if err != nil {
+		return err
+	}
+	current, err := findGroup(ctx, client, args[0])
+	if err != nil {
+		return err
+	}
+	var req v1.Group
+	if err := b.build(&req, current); err != nil {
+		return err
+	}
+	if err := apigw.NewGroupOp(client).Update(ctx, &req, current.ID.Value); err != nil {
+		return err
+	}
+	return showGroup(ctx, a, client, current.ID.Value)
+}
+
+func groupDelete(ctx context.Context, a *app, c *call) error {
+	cf := newCascadeFlags(c)
+	args, client, err := c.prepare(1)
+	if err != nil {
+		return err
+	}
+	if ok, err := cf.run(ctx, a, c, client, args[0], (*cascade.Deleter).Group); ok {
+		return err
+	}
+	group, err := findGroup(ctx, client, args[0])
+	if err != nil {
+		return err
+	}
+	return apigw.NewGroupOp(client).Delete(ctx, group.ID.Value)
+}
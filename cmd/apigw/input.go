@@ -0,0 +1,143 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/ghodss/yaml"
+	"github.com/sacloud/apigw-api-go/internal/jsondiff"
+)
+
+// body フラグと--fileで指定したリクエストのボディ。
+// フラグは指定された場合のみ値を設定するため、更新時に省略した項目は現在の値が維持される
+type body struct {
+	c      *call
+	file   string
+	fields map[string]any
+}
+
+func newBody(c *call) *body {
+	b := &body{c: c, fields: make(map[string]any)}
+	c.StringVar(&b.file, "file", "", "read the request body from a YAML or JSON `file`; options override its values")
+	return b
+}
+
+func (b *body) String(name, key, usage string) {
+	b.c.Func(name, usage, func(s string) error {
+		b.fields[key] = s
+		return nil
+	})
+}
+
+func (b *body) Int(name, key, usage string) {
+	b.c.Func(name, usage, func(s string) error {
+		v, err := strconv.Atoi(s)
+		if err != nil {
+			return err
+		}
+		b.fields[key] = v
+		return nil
+	})
+}
+
+func (b *body) Bool(name, key, usage string) {
+	b.c.Func(name, usage+" (true or false)", func(s string) error {
+		v, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		b.fields[key] = v
+		return nil
+	})
+}
+
+// Strings 繰り返し指定できるフラグ
+func (b *body) Strings(name, key, usage string) {
+	b.c.Func(name, usage+" (repeatable)", func(s string) error {
+		v, _ := b.fields[key].([]string)
+		b.fields[key] = append(v, s)
+		return nil
+	})
+}
+
+// Set フラグ以外から値を設定する
+func (b *body) Set(key string, v any) {
+	b.fields[key] = v
+}
+
+// build current、--fileの内容、フラグの順に上書きしたボディをdstに設定する。
+// currentからはomitで指定した項目とサーバ側で設定される項目を除く
+func (b *body) build(dst json.Unmarshaler, current json.Marshaler, omit ...string) error {
+	data := []byte("{}")
+	if current != nil {
+		cur, err := current.MarshalJSON()
+		if err != nil {
+			return err
+		}
+		if data, err = jsondiff.OmitServerManaged(cur, omit...); err != nil {
+			return err
+		}
+	}
+	if b.file != "" {
+		file, err := b.c.a.readDocument(b.file)
+		if err != nil {
+			return err
+		}
+		if data, err = jsondiff.Merge(data, file); err != nil {
+			return err
+		}
+	}
+	fields, err := json.Marshal(b.fields)
+	if err != nil {
+		return err
+	}
+	if data, err = jsondiff.Merge(data, fields); err != nil {
+		return err
+	}
+	if err := dst.UnmarshalJSON(data); err != nil {
+		return fmt.Errorf("invalid request: %w", err)
+	}
+	return nil
+}
+
+// convert srcをJSONを介してdstに変換する
+func convert(dst json.Unmarshaler, src json.Marshaler) error {
+	data, err := src.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	return dst.UnmarshalJSON(data)
+}
+
+// readDocument YAMLまたはJSONのファイルを読み込み、JSONとして返す。pathが"-"の場合は標準入力から読み込む
+func (a *app) readDocument(path string) ([]byte, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(a.stdin)
+	} else {
+		data, err = os.ReadFile(filepath.Clean(path))
+	}
+	if err != nil {
+		return nil, err
+	}
+	return yaml.YAMLToJSON(data)
+}
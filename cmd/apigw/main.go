@@ -0,0 +1,290 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// apigw APIゲートウェイを操作するコマンドラインツール。
+//
+//	apigw [global options] <resource> <command> [options] [args]
+//
+// 認証情報はsaclient-goと同様にプロファイル、環境変数、コマンドラインオプションから読み込む。
+// リソースは名前またはUUIDで指定できる。
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+
+	apigw "github.com/sacloud/apigw-api-go"
+	v1 "github.com/sacloud/apigw-api-go/apis/v1"
+	"github.com/sacloud/saclient-go"
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	a := &app{stdin: os.Stdin, stdout: os.Stdout, stderr: os.Stderr, env: os.Environ()}
+	err := a.run(ctx, os.Args[1:])
+	stop()
+	os.Exit(exitCode(a, err))
+}
+
+func exitCode(a *app, err error) int {
+	var usage *usageError
+	switch {
+	case err == nil:
+		return 0
+	case errors.Is(err, flag.ErrHelp):
+		return 0
+	case errors.As(err, &usage):
+		// フラグの解析エラーはflagパッケージが出力済み
+		if usage.print != nil {
+			fmt.Fprintf(a.stderr, "apigw: %s\n", usage.msg)
+			usage.print()
+		}
+		return 2
+	}
+	fmt.Fprintf(a.stderr, "apigw: %s\n", err)
+	return 1
+}
+
+// app コマンドの実行環境
+type app struct {
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+	env    []string
+
+	sa         saclient.Client
+	apiRootURL string
+	output     string
+	client     *v1.Client
+}
+
+func (a *app) run(ctx context.Context, args []string) error {
+	fs := a.sa.FlagSet(flag.ContinueOnError)
+	fs.Init("apigw", flag.ContinueOnError)
+	fs.SetOutput(a.stderr)
+	fs.StringVar(&a.apiRootURL, "api-root-url", "", "the root URL of the API gateway API (default: the profile's endpoint or "+apigw.DefaultAPIRootURL+")")
+	a.outputFlags(fs)
+	fs.Usage = func() { a.commandUsage(fs, "apigw", root) }
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return &usageError{msg: err.Error()}
+	}
+	if err := a.sa.SetEnviron(a.env); err != nil {
+		return err
+	}
+	return root.exec(ctx, a, "apigw", fs.Args(), fs)
+}
+
+// api APIクライアントを返す。最初に呼び出された時点で認証情報を読み込む
+func (a *app) api() (*v1.Client, error) {
+	if a.client != nil {
+		return a.client, nil
+	}
+	var err error
+	if a.apiRootURL != "" {
+		a.client, err = apigw.NewClientWithAPIRootURL(&a.sa, a.apiRootURL)
+	} else {
+		a.client, err = apigw.NewClient(&a.sa)
+	}
+	return a.client, err
+}
+
+func (a *app) outputFlags(fs *flag.FlagSet) {
+	if a.output == "" {
+		a.output = outputTable
+	}
+	usage := "output format: " + strings.Join(outputFormats, ", ")
+	for _, name := range []string{"output", "o"} {
+		fs.Func(name, usage+" (default: "+outputTable+")", func(s string) error {
+			if !slices.Contains(outputFormats, s) {
+				return fmt.Errorf("unknown output format %q", s)
+			}
+			a.output = s
+			return nil
+		})
+	}
+}
+
+// command サブコマンド。subを持つコマンドはグループとして振る舞い、runを持つコマンドが実際の処理を行う
+type command struct {
+	name    string
+	args    string
+	summary string
+	sub     []*command
+	run     func(ctx context.Context, a *app, c *call) error
+}
+
+// call コマンドの呼び出し。フラグを定義してからparseを呼び出す
+type call struct {
+	*flag.FlagSet
+	a    *app
+	cmd  *command
+	path string
+	args []string
+	pos  []string
+}
+
+// usageError コマンドの使い方の誤り。printは使い方を出力する
+type usageError struct {
+	msg   string
+	print func()
+}
+
+func (e *usageError) Error() string { return e.msg }
+
+// exec argsに従ってコマンドを実行する。globalはルートのコマンドの場合のみ指定する
+func (c *command) exec(ctx context.Context, a *app, path string, args []string, global *flag.FlagSet) error {
+	if c.run != nil {
+		fs := flag.NewFlagSet(path, flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		a.outputFlags(fs)
+		cl := &call{FlagSet: fs, a: a, cmd: c, path: path, args: args}
+		fs.Usage = cl.usage
+		return c.run(ctx, a, cl)
+	}
+
+	printUsage := func() { a.commandUsage(global, path, c) }
+	if len(args) == 0 {
+		return &usageError{msg: "missing command", print: printUsage}
+	}
+	switch args[0] {
+	case "help", "-h", "-help", "--help":
+		printUsage()
+		return flag.ErrHelp
+	}
+	for _, sub := range c.sub {
+		if sub.name == args[0] {
+			return sub.exec(ctx, a, path+" "+sub.name, args[1:], nil)
+		}
+	}
+	return &usageError{msg: fmt.Sprintf("unknown command %q", args[0]), print: printUsage}
+}
+
+func (a *app) commandUsage(fs *flag.FlagSet, path string, c *command) {
+	w := a.stderr
+	fmt.Fprintf(w, "Usage: %s <command>\n\nCommands:\n", path)
+	for _, sub := range c.sub {
+		fmt.Fprintf(w, "  %-14s %s\n", sub.name, sub.summary)
+	}
+	if fs != nil {
+		fmt.Fprintf(w, "\nGlobal options:\n")
+		fs.PrintDefaults()
+	}
+}
+
+func (cl *call) usage() {
+	w := cl.a.stderr
+	fmt.Fprintf(w, "Usage: %s [options] %s\n\n%s\n", cl.path, cl.cmd.args, cl.cmd.summary)
+	fmt.Fprintf(w, "\nOptions:\n")
+	cl.PrintDefaults()
+}
+
+// parse フラグを解析し、n個の位置引数を返す。nが負の場合は個数を検証しない。
+// フラグは位置引数の後にも指定できる
+func (cl *call) parse(n int) ([]string, error) {
+	args := cl.args
+	for {
+		if err := cl.Parse(args); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return nil, err
+			}
+			return nil, &usageError{msg: err.Error()}
+		}
+		rest := cl.Args()
+		if len(rest) == 0 {
+			break
+		}
+		if consumed := len(args) - len(rest); consumed > 0 && args[consumed-1] == "--" {
+			cl.pos = append(cl.pos, rest...)
+			break
+		}
+		cl.pos = append(cl.pos, rest[0])
+		args = rest[1:]
+	}
+	if n >= 0 && len(cl.pos) != n {
+		return nil, &usageError{msg: fmt.Sprintf("%s: expected %d argument(s), got %d", cl.path, n, len(cl.pos)), print: cl.usage}
+	}
+	return cl.pos, nil
+}
+
+// prepare フラグを解析し、n個の位置引数とAPIクライアントを返す。requiredは必須のフラグ
+func (cl *call) prepare(n int, required ...string) ([]string, *v1.Client, error) {
+	args, err := cl.parse(n)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := cl.required(required...); err != nil {
+		return nil, nil, err
+	}
+	client, err := cl.a.api()
+	if err != nil {
+		return nil, nil, err
+	}
+	return args, client, nil
+}
+
+// required 必須のフラグが指定されているかを検証する
+func (cl *call) required(names ...string) error {
+	set := make(map[string]bool)
+	cl.Visit(func(f *flag.Flag) { set[f.Name] = true })
+	for _, name := range names {
+		if !set[name] {
+			return &usageError{msg: fmt.Sprintf("%s: --%s is required", cl.path, name), print: cl.usage}
+		}
+	}
+	return nil
+}
+
+var root = &command{
+	name: "apigw",
+	sub: []*command{
+		serviceCommand,
+		routeCommand,
+		userCommand,
+		groupCommand,
+		certificateCommand,
+		domainCommand,
+		subscriptionCommand,
+		oidcCommand,
+		planCommand,
+		applyCommand,
+		driftCommand,
+		exportCommand,
+		restoreCommand,
+		versionCommand,
+	},
+}
+
+var versionCommand = &command{
+	name:    "version",
+	summary: "Print the version",
+	run: func(ctx context.Context, a *app, c *call) error {
+		if _, err := c.parse(0); err != nil {
+			return err
+		}
+		_, err := fmt.Fprintln(a.stdout, apigw.Version)
+		return err
+	},
+}
@@ -0,0 +1,191 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sacloud/apigw-api-go/apigwtest"
+	"github.com/sacloud/saclient-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runner フェイクサーバに対してコマンドを実行する
+type runner struct {
+	t     *testing.T
+	fake  *apigwtest.Server
+	stdin string
+}
+
+func newRunner(t *testing.T) *runner {
+	fake := apigwtest.NewServer()
+	t.Cleanup(fake.Close)
+	return &runner{t: t, fake: fake}
+}
+
+func (r *runner) run(args ...string) (string, error) {
+	r.t.Helper()
+	var stdout, stderr bytes.Buffer
+	a := &app{
+		stdin:  strings.NewReader(r.stdin),
+		stdout: &stdout,
+		stderr: &stderr,
+		env:    []string{"SAKURA_RATE_LIMIT=1000"},
+	}
+	require.NoError(r.t, a.sa.SetWith(saclient.WithoutRetry()))
+	err := a.run(r.t.Context(), append([]string{"--api-root-url", r.fake.URL}, args...))
+	return stdout.String(), err
+}
+
+func (r *runner) must(args ...string) string {
+	r.t.Helper()
+	out, err := r.run(args...)
+	require.NoError(r.t, err, strings.Join(args, " "))
+	return out
+}
+
+func TestCommands(t *testing.T) {
+	r := newRunner(t)
+
+	var plans []map[string]any
+	require.NoError(t, json.Unmarshal([]byte(r.must("subscription", "plans", "-o", "json")), &plans))
+	require.NotEmpty(t, plans)
+	r.must("subscription", "create", "--name", "sub", "--plan", plans[0]["name"].(string))
+	r.must("group", "create", "--name", "admins")
+
+	out := r.must("service", "create", "--name", "backend", "--subscription", "sub", "--protocol", "https", "--host", "backend.example.com", "-o", "json")
+	var service map[string]any
+	require.NoError(t, json.Unmarshal([]byte(out), &service))
+	assert.Equal(t, "backend", service["name"])
+	assert.Equal(t, "sub", service["subscription"].(map[string]any)["name"])
+
+	// 名前とUUIDのどちらでも指定できる。フラグは引数の後にも指定できる
+	out = r.must("service", "update", service["id"].(string), "--port", "8443", "--tag", "a", "--tag", "b", "-o", "yaml")
+	assert.Contains(t, out, "port: 8443")
+	assert.Contains(t, out, "host: backend.example.com")
+	out = r.must("service", "list")
+	assert.Regexp(t, `(?m)^ID\s+NAME\s+PROTOCOL`, out)
+	assert.Contains(t, out, "backend.example.com")
+
+	out = r.must("route", "create", "--service", "backend", "--name", "api", "--path", "/api", "--method", "GET", "--method", "POST")
+	assert.Contains(t, out, "GET,POST")
+	assert.Contains(t, r.must("route", "auth", "get", "--service", "backend", "api", "-o", "json"), `"isACLEnabled": false`)
+	out = r.must("route", "auth", "enable", "--service", "backend", "api", "--group", "admins")
+	assert.Regexp(t, `admins\s+true`, out)
+	r.must("route", "transform", "set", "--service", "backend", "api", "--file", writeFile(t, "transform.yaml", "httpMethod: PUT\n"))
+	assert.Contains(t, r.must("route", "transform", "get", "--service", "backend", "api"), "httpMethod: PUT")
+	r.stdin = "httpMethod: POST\n"
+	r.must("route", "transform", "set", "--service", "backend", "api", "--file", "-")
+	assert.Contains(t, r.must("route", "transform", "get", "--service", "backend", "api"), "httpMethod: POST")
+
+	r.must("user", "create", "--name", "alice")
+	r.must("user", "auth", "set", "alice", "--basic", "--username", "alice", "--password", "secret")
+	assert.Contains(t, r.must("user", "auth", "get", "alice", "-o", "json"), `"userName": "alice"`)
+	assert.Regexp(t, `admins\s+true`, r.must("user", "group", "add", "alice", "admins"))
+
+	r.must("cert", "upload", "--name", "example", "--cert", "../../testdata/rsa.crt", "--key", "../../testdata/rsa.key")
+	assert.Regexp(t, `api\.example\.com\s+example`, r.must("domain", "create", "api.example.com", "--cert", "example"))
+
+	// 書き出したドキュメントは現在の状態と一致する
+	doc := filepath.Join(t.TempDir(), "apigw.yaml")
+	r.must("export", "--include-secrets", "--file", doc)
+	assert.Equal(t, "No changes.\n", r.must("plan", "--file", doc))
+	assert.Equal(t, "No drift detected.\n", r.must("drift", "--file", doc))
+
+	r.must("route", "delete", "--service", "backend", "api")
+	r.must("service", "delete", "backend")
+	assert.NotContains(t, r.must("service", "list"), "backend")
+}
+
+func TestApply(t *testing.T) {
+	r := newRunner(t)
+	r.must("subscription", "create", "--name", "sub", "--plan", r.fake.Plans()[0].Name.Value)
+	doc := writeFile(t, "apigw.yaml", `
+version: 1
+groups:
+  - name: admins
+services:
+  - name: backend
+    subscription: sub
+    protocol: https
+    host: backend.example.com
+`)
+
+	// 確認で拒否した場合は適用しない
+	r.stdin = "n\n"
+	out := r.must("apply", "--file", doc)
+	assert.Contains(t, out, "Plan: 2 to create, 0 to update, 0 to delete.")
+	assert.NotContains(t, r.must("group", "list"), "admins")
+
+	r.stdin = "y\n"
+	assert.Contains(t, r.must("apply", "--file", doc), "Apply complete.")
+	assert.Equal(t, "No changes.\n", r.must("apply", "--file", doc, "--yes"))
+}
+
+func TestCascade(t *testing.T) {
+	r := newRunner(t)
+	r.must("subscription", "create", "--name", "sub", "--plan", r.fake.Plans()[0].Name.Value)
+	r.must("service", "create", "--name", "backend", "--subscription", "sub", "--protocol", "https", "--host", "backend.example.com")
+	r.must("route", "create", "--service", "backend", "--name", "api", "--path", "/api")
+
+	// --dry-runでは手順を表示するだけで削除しない
+	out := r.must("service", "delete", "backend", "--cascade", "--dry-run")
+	assert.Equal(t, "- route \"backend/api\"\n- service \"backend\"\n\nTeardown: 2 to delete, 0 to detach.\n", out)
+	assert.Contains(t, r.must("service", "list"), "backend")
+
+	assert.Contains(t, r.must("service", "delete", "backend", "--cascade", "--yes"), "Delete complete.")
+	assert.NotContains(t, r.must("service", "list"), "backend")
+
+	var usage *usageError
+	_, err := r.run("group", "delete", "admins", "--dry-run")
+	require.ErrorAs(t, err, &usage)
+	_, err = r.run("oidc", "delete", "test_oidc", "--disable-authentication")
+	require.ErrorAs(t, err, &usage)
+}
+
+func TestErrors(t *testing.T) {
+	r := newRunner(t)
+
+	_, err := r.run("service", "get", "unknown")
+	assert.ErrorContains(t, err, `service "unknown" not found`)
+
+	var usage *usageError
+	_, err = r.run("service", "create", "--name", "backend")
+	require.ErrorAs(t, err, &usage)
+	assert.Contains(t, usage.msg, "--subscription is required")
+	_, err = r.run("service", "get")
+	require.ErrorAs(t, err, &usage)
+	_, err = r.run("unknown")
+	require.ErrorAs(t, err, &usage)
+	_, err = r.run("service", "list", "-o", "xml")
+	require.ErrorAs(t, err, &usage)
+	// 必須のフラグはサービスやルートを検索する前に検証する
+	_, err = r.run("route", "auth", "enable", "--service", "unknown", "api")
+	require.ErrorAs(t, err, &usage)
+	assert.Contains(t, usage.msg, "--group is required")
+}
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	return path
+}
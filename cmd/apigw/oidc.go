@@ -0,0 +1,169 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	apigw "github.com/sacloud/apigw-api-go"
+	v1 "github.com/sacloud/apigw-api-go/apis/v1"
+	"github.com/sacloud/apigw-api-go/cascade"
+)
+
+var oidcColumns = []column[v1.OidcDetail]{
+	{"ID", func(o *v1.OidcDetail) string { return formatUUID(o.ID) }},
+	{"NAME", func(o *v1.OidcDetail) string { return string(o.Name) }},
+	{"ISSUER", func(o *v1.OidcDetail) string { return o.Issuer }},
+	{"METHODS", func(o *v1.OidcDetail) string { return formatList(o.AuthenticationMethods) }},
+	{"SCOPES", func(o *v1.OidcDetail) string { return formatList(o.Scopes) }},
+}
+
+var oidcCommand = &command{
+	name:    "oidc",
+	summary: "Manage OIDC authentication",
+	sub: []*command{
+		{name: "list", summary: "List OIDC authentication", run: oidcList},
+		{name: "get", args: "OIDC", summary: "Show an OIDC authentication", run: oidcGet},
+		{name: "create", summary: "Create an OIDC authentication", run: oidcCreate},
+		{name: "update", args: "OIDC", summary: "Update an OIDC authentication", run: oidcUpdate},
+		{name: "delete", args: "OIDC", summary: "Delete an OIDC authentication", run: oidcDelete},
+	},
+}
+
+func findOidc(ctx context.Context, client *v1.Client, ref string) (*v1.Oidc, error) {
+	return apigw.NewOidcOp(client).Resolve(ctx, ref)
+}
+
+func showOidc(ctx context.Context, a *app, client *v1.Client, id uuid.UUID) error {
+	oidc, err := apigw.NewOidcOp(client).Read(ctx, id)
+	if err != nil {
+		return err
+	}
+	return show(a, oidc, oidcColumns...)
+}
+
+func oidcList(ctx context.Context, a *app, c *call) error {
+	_, client, err := c.prepare(0)
+	if err != nil {
+		return err
+	}
+	oidcs, err := apigw.NewOidcOp(client).List(ctx)
+	if err != nil {
+		return err
+	}
+	details := make([]v1.OidcDetail, 0, len(oidcs))
+	for _, o := range oidcs {
+		var d v1.OidcDetail
+		if err := convert(&d, &o); err != nil {
+			return err
+		}
+		details = append(details, d)
+	}
+	return list(a, details, oidcColumns...)
+}
+
+func oidcGet(ctx context.Context, a *app, c *call) error {
+	args, client, err := c.prepare(1)
+	if err != nil {
+		return err
+	}
+	oidc, err := findOidc(ctx, client, args[0])
+	if err != nil {
+		return err
+	}
+	return showOidc(ctx, a, client, oidc.ID.Value)
+}
+
+func oidcFlags(c *call) *body {
+	b := newBody(c)
+	b.String("name", "name", "the OIDC authentication name")
+	b.Strings("method", "authenticationMethods", "an authentication method: authorizationCodeFlow, clientCredentials, accessToken or refreshToken")
+	b.String("issuer", "issuer", "the issuer URL of the IdP")
+	b.String("client-id", "clientId", "the client ID registered to the IdP")
+	b.String("client-secret", "clientSecret", "the client secret registered to the IdP")
+	b.Strings("scope", "scopes", "a scope requested to the IdP")
+	b.Bool("hide-credentials", "hideCredentials", "do not forward credentials to the upstream")
+	b.Strings("token-audience", "tokenAudiences", "an accepted aud claim of tokens")
+	b.Bool("use-session", "useSession", "store credentials as a session in the API gateway")
+	return b
+}
+
+func oidcCreate(ctx context.Context, a *app, c *call) error {
+	b := oidcFlags(c)
+	_, client, err := c.prepare(0)
+	if err != nil {
+		return err
+	}
+	var req v1.Oidc
+	if err := b.build(&req, nil); err != nil {
+		return err
+	}
+	created, err := apigw.NewOidcOp(client).Create(ctx, &req)
+	if err != nil {
+		return err
+	}
+	return showOidc(ctx, a, client, created.ID.Value)
+}
+
+func oidcUpdate(ctx context.Context, a *app, c *call) error {
+	b := oidcFlags(c)
+	args, client, err := c.prepare(1)
+	if err != nil {
+		return err
+	}
+	current, err := findOidc(ctx, client, args[0])
+	if err != nil {
+		return err
+	}
+	var req v1.Oidc
+	if err := b.build(&req, current); err != nil {
+		return err
+	}
+	if err := apigw.NewOidcOp(client).Update(ctx, &req, current.ID.Value); err != nil {
+		return err
+	}
+	return showOidc(ctx, a, client, current.ID.Value)
+}
+
+func oidcDelete(ctx context.Context, a *app, c *call) error {
+	cf := newCascadeFlags(c)
+	disableAuth := c.Bool("disable-authentication", false, "with --cascade, detach the oidc from services using it and set their authentication to none")
+	args, client, err := c.prepare(1)
+	if err != nil {
+		return err
+	}
+	if *disableAuth && !*cf.cascade {
+		return &usageError{msg: c.path + ": --disable-authentication requires --cascade", print: c.usage}
+	}
+	plan := func(d *cascade.Deleter, ctx context.Context, idOrName string) (*cascade.Plan, error) {
+		d.DisableAuthentication = *disableAuth
+		p, err := d.Oidc(ctx, idOrName)
+		if errors.Is(err, cascade.ErrInUse) {
+			return nil, fmt.Errorf("%w (use --disable-authentication)", err)
+		}
+		return p, err
+	}
+	if ok, err := cf.run(ctx, a, c, client, args[0], plan); ok {
+		return err
+	}
+	oidc, err := findOidc(ctx, client, args[0])
+	if err != nil {
+		return err
+	}
+	return apigw.NewOidcOp(client).Delete(ctx, oidc.ID.Value)
+}
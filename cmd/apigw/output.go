@@ -0,0 +1,132 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/ghodss/yaml"
+	v1 "github.com/sacloud/apigw-api-go/apis/v1"
+)
+
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+	outputYAML  = "yaml"
+)
+
+var outputFormats = []string{outputTable, outputJSON, outputYAML}
+
+// column 表形式で出力する列
+type column[T any] struct {
+	header string
+	value  func(*T) string
+}
+
+// list 一覧を出力する
+func list[T any](a *app, rows []T, cols ...column[T]) error {
+	if a.output == outputTable {
+		return table(a, rows, cols)
+	}
+	if rows == nil {
+		rows = []T{}
+	}
+	return a.encode(rows)
+}
+
+// show 単一のリソースを出力する
+func show[T any](a *app, v *T, cols ...column[T]) error {
+	if a.output == outputTable {
+		return table(a, []T{*v}, cols)
+	}
+	return a.encode(v)
+}
+
+// encodeDocument 表形式で表せない値を出力する。表形式が指定された場合はYAMLで出力する
+func (a *app) encodeDocument(v any) error {
+	if a.output == outputTable {
+		return a.encodeAs(v, outputYAML)
+	}
+	return a.encode(v)
+}
+
+func (a *app) encode(v any) error {
+	return a.encodeAs(v, a.output)
+}
+
+func (a *app) encodeAs(v any, format string) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	if format == outputYAML {
+		if data, err = yaml.JSONToYAML(data); err != nil {
+			return err
+		}
+	} else {
+		data = append(data, '\n')
+	}
+	_, err = a.stdout.Write(data)
+	return err
+}
+
+func table[T any](a *app, rows []T, cols []column[T]) error {
+	w := tabwriter.NewWriter(a.stdout, 0, 4, 2, ' ', 0)
+	line := make([]string, len(cols))
+	for i, c := range cols {
+		line[i] = c.header
+	}
+	fmt.Fprintln(w, strings.Join(line, "\t"))
+	for i := range rows {
+		for j, c := range cols {
+			line[j] = c.value(&rows[i])
+		}
+		fmt.Fprintln(w, strings.Join(line, "\t"))
+	}
+	return w.Flush()
+}
+
+func formatUUID(v v1.OptUUID) string {
+	if !v.Set {
+		return ""
+	}
+	return v.Value.String()
+}
+
+func formatInt(v v1.OptInt) string {
+	if !v.Set {
+		return ""
+	}
+	return strconv.Itoa(v.Value)
+}
+
+func formatTime(v v1.OptDateTime) string {
+	if !v.Set {
+		return ""
+	}
+	return v.Value.Format("2006-01-02 15:04:05")
+}
+
+func formatList[T ~string](v []T) string {
+	s := make([]string, len(v))
+	for i := range v {
+		s[i] = string(v[i])
+	}
+	return strings.Join(s, ",")
+}
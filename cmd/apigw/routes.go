@@ -0,0 +1,325 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/google/uuid"
+	apigw "github.com/sacloud/apigw-api-go"
+	v1 "github.com/sacloud/apigw-api-go/apis/v1"
+)
+
+var routeColumns = []column[v1.RouteDetail]{
+	{"ID", func(r *v1.RouteDetail) string { return formatUUID(r.ID) }},
+	{"NAME", func(r *v1.RouteDetail) string { return string(r.Name.Value) }},
+	{"PROTOCOLS", func(r *v1.RouteDetail) string { return string(r.Protocols.Value) }},
+	{"PATH", func(r *v1.RouteDetail) string { return r.Path.Value }},
+	{"METHODS", func(r *v1.RouteDetail) string { return formatList(r.Methods) }},
+	{"HOST", func(r *v1.RouteDetail) string { return r.Host.Value }},
+}
+
+var routeAuthorizationColumns = []column[v1.RouteAuthorization]{
+	{"ID", func(g *v1.RouteAuthorization) string { return formatUUID(g.ID) }},
+	{"GROUP", func(g *v1.RouteAuthorization) string { return string(g.Name.Value) }},
+	{"ENABLED", func(g *v1.RouteAuthorization) string { return strconv.FormatBool(!g.Enabled.Set || g.Enabled.Value) }},
+}
+
+var routeCommand = &command{
+	name:    "route",
+	summary: "Manage routes of a service",
+	sub: []*command{
+		{name: "list", summary: "List routes of a service", run: routeList},
+		{name: "get", args: "ROUTE", summary: "Show a route", run: routeGet},
+		{name: "create", summary: "Create a route", run: routeCreate},
+		{name: "update", args: "ROUTE", summary: "Update a route", run: routeUpdate},
+		{name: "delete", args: "ROUTE", summary: "Delete a route", run: routeDelete},
+		{
+			name:    "auth",
+			summary: "Manage authorization of a route",
+			sub: []*command{
+				{name: "get", args: "ROUTE", summary: "Show the groups authorized to access a route", run: routeAuthGet},
+				{name: "enable", args: "ROUTE", summary: "Authorize only the given groups to access a route", run: routeAuthEnable},
+				{name: "disable", args: "ROUTE", summary: "Disable authorization of a route", run: routeAuthDisable},
+			},
+		},
+		{
+			name:    "transform",
+			summary: "Manage request and response transformation of a route",
+			sub: []*command{
+				{name: "get", args: "ROUTE", summary: "Show the transformation of a route", run: routeTransformGet},
+				{name: "set", args: "ROUTE", summary: "Replace the transformation of a route with a YAML or JSON file", run: routeTransformSet},
+			},
+		},
+	},
+}
+
+// routeTarget ルートを操作するコマンドの共通の引数
+type routeTarget struct {
+	c       *call
+	service *string
+}
+
+func newRouteTarget(c *call) *routeTarget {
+	return &routeTarget{c: c, service: c.String("service", "", "the service name or ID (required)")}
+}
+
+// prepare フラグを解析し、サービスのIDと位置引数、APIクライアントを返す。requiredは--service以外の必須のフラグ
+func (t *routeTarget) prepare(ctx context.Context, n int, required ...string) ([]string, *v1.Client, uuid.UUID, error) {
+	args, client, err := t.c.prepare(n, append([]string{"service"}, required...)...)
+	if err != nil {
+		return nil, nil, uuid.Nil, err
+	}
+	service, err := findService(ctx, client, *t.service)
+	if err != nil {
+		return nil, nil, uuid.Nil, err
+	}
+	return args, client, service.ID.Value, nil
+}
+
+// route フラグを解析し、対象のルートを返す
+func (t *routeTarget) route(ctx context.Context, required ...string) (*v1.Client, uuid.UUID, *v1.Route, error) {
+	args, client, serviceID, err := t.prepare(ctx, 1, required...)
+	if err != nil {
+		return nil, uuid.Nil, nil, err
+	}
+	route, err := findRoute(ctx, client, serviceID, args[0])
+	if err != nil {
+		return nil, uuid.Nil, nil, err
+	}
+	return client, serviceID, route, nil
+}
+
+func findRoute(ctx context.Context, client *v1.Client, serviceID uuid.UUID, ref string) (*v1.Route, error) {
+	return apigw.NewRouteOp(client, serviceID).Resolve(ctx, ref)
+}
+
+func showRoute(ctx context.Context, a *app, client *v1.Client, serviceID, id uuid.UUID) error {
+	route, err := apigw.NewRouteOp(client, serviceID).Read(ctx, id)
+	if err != nil {
+		return err
+	}
+	return show(a, route, routeColumns...)
+}
+
+func routeList(ctx context.Context, a *app, c *call) error {
+	_, client, serviceID, err := newRouteTarget(c).prepare(ctx, 0)
+	if err != nil {
+		return err
+	}
+	routes, err := apigw.NewRouteOp(client, serviceID).List(ctx)
+	if err != nil {
+		return err
+	}
+	details := make([]v1.RouteDetail, 0, len(routes))
+	for _, r := range routes {
+		var d v1.RouteDetail
+		if err := convert(&d, &r); err != nil {
+			return err
+		}
+		details = append(details, d)
+	}
+	return list(a, details, routeColumns...)
+}
+
+func routeGet(ctx context.Context, a *app, c *call) error {
+	client, serviceID, route, err := newRouteTarget(c).route(ctx)
+	if err != nil {
+		return err
+	}
+	return showRoute(ctx, a, client, serviceID, route.ID.Value)
+}
+
+func routeFlags(c *call) *body {
+	b := newBody(c)
+	b.String("name", "name", "the route name")
+	b.Strings("tag", "tags", "a tag")
+	b.String("protocols", "protocols", "the accepted protocols: http, https or http,https")
+	b.String("path", "path", "the request path")
+	b.Strings("host", "hosts", "a host name accepted by the route")
+	b.Strings("method", "methods", "an HTTP method accepted by the route")
+	b.Int("https-redirect-status-code", "httpsRedirectStatusCode", "the status code used to redirect HTTP requests to HTTPS")
+	b.Int("regex-priority", "regexPriority", "the priority of the path when it is a regular expression")
+	b.Bool("strip-path", "stripPath", "strip the matched path before forwarding the request")
+	b.Bool("preserve-host", "preserveHost", "forward the Host header of the request")
+	b.Bool("request-buffering", "requestBuffering", "buffer requests")
+	b.Bool("response-buffering", "responseBuffering", "buffer responses")
+	return b
+}
+
+func routeCreate(ctx context.Context, a *app, c *call) error {
+	t := newRouteTarget(c)
+	b := routeFlags(c)
+	_, client, serviceID, err := t.prepare(ctx, 0)
+	if err != nil {
+		return err
+	}
+	var req v1.RouteDetail
+	if err := b.build(&req, nil); err != nil {
+		return err
+	}
+	created, err := apigw.NewRouteOp(client, serviceID).Create(ctx, &req)
+	if err != nil {
+		return err
+	}
+	return showRoute(ctx, a, client, serviceID, created.ID.Value)
+}
+
+func routeUpdate(ctx context.Context, a *app, c *call) error {
+	t := newRouteTarget(c)
+	b := routeFlags(c)
+	client, serviceID, route, err := t.route(ctx)
+	if err != nil {
+		return err
+	}
+	op := apigw.NewRouteOp(client, serviceID)
+	current, err := op.Read(ctx, route.ID.Value)
+	if err != nil {
+		return err
+	}
+	var req v1.RouteDetail
+	if err := b.build(&req, current, "serviceId", "host"); err != nil {
+		return err
+	}
+	if err := op.Update(ctx, &req, route.ID.Value); err != nil {
+		return err
+	}
+	return showRoute(ctx, a, client, serviceID, route.ID.Value)
+}
+
+func routeDelete(ctx context.Context, a *app, c *call) error {
+	client, serviceID, route, err := newRouteTarget(c).route(ctx)
+	if err != nil {
+		return err
+	}
+	return apigw.NewRouteOp(client, serviceID).Delete(ctx, route.ID.Value)
+}
+
+func routeAuthGet(ctx context.Context, a *app, c *call) error {
+	client, serviceID, route, err := newRouteTarget(c).route(ctx)
+	if err != nil {
+		return err
+	}
+	return showRouteAuth(ctx, a, apigw.NewRouteExtraOp(client, serviceID, route.ID.Value))
+}
+
+func showRouteAuth(ctx context.Context, a *app, op apigw.RouteExtraAPI) error {
+	// 認可設定のないルートは404を返す
+	authz, err := op.ReadAuthorization(ctx)
+	if apigw.IsNotFound(err) {
+		authz, err = &v1.RouteAuthorizationDetailResponse{Groups: []v1.RouteAuthorization{}}, nil
+	}
+	if err != nil {
+		return err
+	}
+	if a.output != outputTable {
+		return a.encode(authz)
+	}
+	var groups []v1.RouteAuthorization
+	if authz.IsACLEnabled {
+		groups = authz.Groups
+	}
+	return list(a, groups, routeAuthorizationColumns...)
+}
+
+func routeAuthEnable(ctx context.Context, a *app, c *call) error {
+	t := newRouteTarget(c)
+	var groups, disabled []string
+	c.Func("group", "a group name or ID authorized to access the route (repeatable, required)", func(s string) error {
+		groups = append(groups, s)
+		return nil
+	})
+	c.Func("disabled-group", "a group name or ID registered but not authorized (repeatable)", func(s string) error {
+		disabled = append(disabled, s)
+		return nil
+	})
+	client, serviceID, route, err := t.route(ctx, "group")
+	if err != nil {
+		return err
+	}
+
+	var req []v1.RouteAuthorization
+	for _, refs := range []struct {
+		names   []string
+		enabled bool
+	}{{groups, true}, {disabled, false}} {
+		for _, ref := range refs.names {
+			group, err := findGroup(ctx, client, ref)
+			if err != nil {
+				return err
+			}
+			req = append(req, v1.RouteAuthorization{ID: group.ID, Enabled: v1.NewOptBool(refs.enabled)})
+		}
+	}
+	op := apigw.NewRouteExtraOp(client, serviceID, route.ID.Value)
+	if err := op.EnableAuthorization(ctx, req); err != nil {
+		return err
+	}
+	return showRouteAuth(ctx, a, op)
+}
+
+func routeAuthDisable(ctx context.Context, a *app, c *call) error {
+	client, serviceID, route, err := newRouteTarget(c).route(ctx)
+	if err != nil {
+		return err
+	}
+	return apigw.NewRouteExtraOp(client, serviceID, route.ID.Value).DisableAuthorization(ctx)
+}
+
+func routeTransformGet(ctx context.Context, a *app, c *call) error {
+	t := newRouteTarget(c)
+	response := c.Bool("response", false, "show the response transformation instead of the request transformation")
+	client, serviceID, route, err := t.route(ctx)
+	if err != nil {
+		return err
+	}
+	op := apigw.NewRouteExtraOp(client, serviceID, route.ID.Value)
+	if *response {
+		v, err := op.ReadResponseTransformation(ctx)
+		if err != nil {
+			return err
+		}
+		return a.encodeDocument(v)
+	}
+	v, err := op.ReadRequestTransformation(ctx)
+	if err != nil {
+		return err
+	}
+	return a.encodeDocument(v)
+}
+
+func routeTransformSet(ctx context.Context, a *app, c *call) error {
+	t := newRouteTarget(c)
+	response := c.Bool("response", false, "set the response transformation instead of the request transformation")
+	b := newBody(c)
+	client, serviceID, route, err := t.route(ctx, "file")
+	if err != nil {
+		return err
+	}
+	op := apigw.NewRouteExtraOp(client, serviceID, route.ID.Value)
+	if *response {
+		var req v1.ResponseTransformation
+		if err := b.build(&req, nil); err != nil {
+			return err
+		}
+		return op.UpdateResponseTransformation(ctx, &req)
+	}
+	var req v1.RequestTransformation
+	if err := b.build(&req, nil); err != nil {
+		return err
+	}
+	return op.UpdateRequestTransformation(ctx, &req)
+}
@@ -0,0 +1,180 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	apigw "github.com/sacloud/apigw-api-go"
+	v1 "github.com/sacloud/apigw-api-go/apis/v1"
+	"github.com/sacloud/apigw-api-go/cascade"
+)
+
+var serviceColumns = []column[v1.ServiceDetailResponse]{
+	{"ID", func(s *v1.ServiceDetailResponse) string { return formatUUID(s.ID) }},
+	{"NAME", func(s *v1.ServiceDetailResponse) string { return string(s.Name) }},
+	{"PROTOCOL", func(s *v1.ServiceDetailResponse) string { return string(s.Protocol) }},
+	{"HOST", func(s *v1.ServiceDetailResponse) string { return s.Host }},
+	{"PORT", func(s *v1.ServiceDetailResponse) string { return formatInt(s.Port) }},
+	{"PATH", func(s *v1.ServiceDetailResponse) string { return s.Path.Value }},
+	{"SUBSCRIPTION", func(s *v1.ServiceDetailResponse) string { return s.Subscription.Name }},
+	{"ROUTE HOST", func(s *v1.ServiceDetailResponse) string { return s.RouteHost.Value }},
+}
+
+var serviceCommand = &command{
+	name:    "service",
+	summary: "Manage services",
+	sub: []*command{
+		{name: "list", summary: "List services", run: serviceList},
+		{name: "get", args: "SERVICE", summary: "Show a service", run: serviceGet},
+		{name: "create", summary: "Create a service", run: serviceCreate},
+		{name: "update", args: "SERVICE", summary: "Update a service", run: serviceUpdate},
+		{name: "delete", args: "SERVICE", summary: "Delete a service", run: serviceDelete},
+	},
+}
+
+func findService(ctx context.Context, client *v1.Client, ref string) (*v1.ServiceDetailResponse, error) {
+	return apigw.NewServiceOp(client).Resolve(ctx, ref)
+}
+
+func serviceList(ctx context.Context, a *app, c *call) error {
+	_, client, err := c.prepare(0)
+	if err != nil {
+		return err
+	}
+	services, err := apigw.NewServiceOp(client).List(ctx)
+	if err != nil {
+		return err
+	}
+	return list(a, services, serviceColumns...)
+}
+
+func serviceGet(ctx context.Context, a *app, c *call) error {
+	args, client, err := c.prepare(1)
+	if err != nil {
+		return err
+	}
+	service, err := findService(ctx, client, args[0])
+	if err != nil {
+		return err
+	}
+	return showService(ctx, a, client, service.ID.Value)
+}
+
+func showService(ctx context.Context, a *app, client *v1.Client, id uuid.UUID) error {
+	service, err := apigw.NewServiceOp(client).Read(ctx, id)
+	if err != nil {
+		return err
+	}
+	return show(a, service, serviceColumns...)
+}
+
+// serviceFlags サービスの作成・更新で共通のフラグを定義する。参照先の名前はresolveで解決する
+func serviceFlags(c *call) (b *body, oidc *string) {
+	b = newBody(c)
+	b.String("name", "name", "the service name")
+	b.Strings("tag", "tags", "a tag")
+	b.String("protocol", "protocol", "the upstream protocol: http or https")
+	b.String("host", "host", "the upstream host")
+	b.String("path", "path", "the upstream path")
+	b.Int("port", "port", "the upstream port")
+	b.Int("retries", "retries", "the number of retries")
+	b.Int("connect-timeout", "connectTimeout", "the connect timeout in milliseconds")
+	b.Int("write-timeout", "writeTimeout", "the write timeout in milliseconds")
+	b.Int("read-timeout", "readTimeout", "the read timeout in milliseconds")
+	b.String("authentication", "authentication", "the authentication method: none, basic, hmac, jwt or oidc")
+	oidc = c.String("oidc", "", "the OIDC authentication name or ID")
+	return b, oidc
+}
+
+func resolveOidc(ctx context.Context, client *v1.Client, b *body, ref string) error {
+	if ref == "" {
+		return nil
+	}
+	oidc, err := findOidc(ctx, client, ref)
+	if err != nil {
+		return err
+	}
+	b.Set("oidc", map[string]any{"id": oidc.ID.Value})
+	return nil
+}
+
+func serviceCreate(ctx context.Context, a *app, c *call) error {
+	b, oidc := serviceFlags(c)
+	subscription := c.String("subscription", "", "the subscription name or ID (required)")
+	_, client, err := c.prepare(0, "subscription")
+	if err != nil {
+		return err
+	}
+
+	sub, err := findSubscription(ctx, client, *subscription)
+	if err != nil {
+		return err
+	}
+	b.Set("subscription", map[string]any{"id": sub.ID.Value})
+	if err := resolveOidc(ctx, client, b, *oidc); err != nil {
+		return err
+	}
+	var req v1.ServiceDetailRequest
+	if err := b.build(&req, nil); err != nil {
+		return err
+	}
+	created, err := apigw.NewServiceOp(client).Create(ctx, &req)
+	if err != nil {
+		return err
+	}
+	return showService(ctx, a, client, created.ID.Value)
+}
+
+func serviceUpdate(ctx context.Context, a *app, c *call) error {
+	b, oidc := serviceFlags(c)
+	args, client, err := c.prepare(1)
+	if err != nil {
+		return err
+	}
+
+	current, err := findService(ctx, client, args[0])
+	if err != nil {
+		return err
+	}
+	if err := resolveOidc(ctx, client, b, *oidc); err != nil {
+		return err
+	}
+	var req v1.ServiceDetail
+	if err := b.build(&req, current, "routeHost", "subscription"); err != nil {
+		return err
+	}
+	if err := apigw.NewServiceOp(client).Update(ctx, &req, current.ID.Value); err != nil {
+		return err
+	}
+	return showService(ctx, a, client, current.ID.Value)
+}
+
+func serviceDelete(ctx context.Context, a *app, c *call) error {
+	cf := newCascadeFlags(c)
+	args, client, err := c.prepare(1)
+	if err != nil {
+		return err
+	}
+	if ok, err := cf.run(ctx, a, c, client, args[0], (*cascade.Deleter).Service); ok {
+		return err
+	}
+	service, err := findService(ctx, client, args[0])
+	if err != nil {
+		return err
+	}
+	return apigw.NewServiceOp(client).Delete(ctx, service.ID.Value)
+}
@@ -0,0 +1,224 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/sacloud/apigw-api-go/apply"
+	"github.com/sacloud/apigw-api-go/drift"
+	"github.com/sacloud/apigw-api-go/snapshot"
+	"github.com/sacloud/apigw-api-go/spec"
+)
+
+var planCommand = &command{
+	name:    "plan",
+	summary: "Show changes required to match the account to a spec document",
+	run:     runPlan,
+}
+
+var applyCommand = &command{
+	name:    "apply",
+	summary: "Apply a spec document to the account",
+	run:     runApply,
+}
+
+var driftCommand = &command{
+	name:    "drift",
+	summary: "Report differences between a spec document and the services in the account",
+	run:     runDrift,
+}
+
+var exportCommand = &command{
+	name:    "export",
+	summary: "Write the whole account as a spec document",
+	run:     runExport,
+}
+
+var restoreCommand = &command{
+	name:    "restore",
+	summary: "Recreate an exported spec document in the account",
+	run:     runRestore,
+}
+
+// planFlags plan/applyで共通のフラグ
+type planFlags struct {
+	file  *string
+	prune *bool
+}
+
+func newPlanFlags(c *call) *planFlags {
+	return &planFlags{
+		file:  c.String("file", "", "the spec document `file` (required)"),
+		prune: c.Bool("prune", false, "delete resources not in the document"),
+	}
+}
+
+func (f *planFlags) plan(ctx context.Context, c *call) (*apply.Planner, *apply.Plan, error) {
+	_, client, err := c.prepare(0, "file")
+	if err != nil {
+		return nil, nil, err
+	}
+	doc, err := spec.Load(*f.file)
+	if err != nil {
+		return nil, nil, err
+	}
+	planner := apply.NewPlanner(client)
+	planner.Prune = *f.prune
+	plan, err := planner.Plan(ctx, doc)
+	if err != nil {
+		return nil, nil, err
+	}
+	return planner, plan, nil
+}
+
+func runPlan(ctx context.Context, a *app, c *call) error {
+	_, plan, err := newPlanFlags(c).plan(ctx, c)
+	if err != nil {
+		return err
+	}
+	return plan.Print(a.stdout)
+}
+
+func runApply(ctx context.Context, a *app, c *call) error {
+	f := newPlanFlags(c)
+	yes := c.Bool("yes", false, "apply without confirmation")
+	planner, plan, err := f.plan(ctx, c)
+	if err != nil {
+		return err
+	}
+	if err := plan.Print(a.stdout); err != nil {
+		return err
+	}
+	if plan.Empty() {
+		return nil
+	}
+	if !*yes {
+		ok, err := a.confirm("Apply these changes?")
+		if err != nil || !ok {
+			return err
+		}
+	}
+	if err := planner.Apply(ctx, plan); err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(a.stdout, "Apply complete.")
+	return err
+}
+
+func runDrift(ctx context.Context, a *app, c *call) error {
+	file := c.String("file", "", "the spec document `file` (required)")
+	format := c.String("format", "", "the report format: text, json or junit (default: json with -o json, text otherwise)")
+	_, client, err := c.prepare(0, "file")
+	if err != nil {
+		return err
+	}
+	f := drift.Format(*format)
+	switch {
+	case f == "" && a.output == outputJSON:
+		f = drift.FormatJSON
+	case f == "":
+		f = drift.FormatText
+	case f != drift.FormatText && f != drift.FormatJSON && f != drift.FormatJUnit:
+		return &usageError{msg: fmt.Sprintf("%s: unknown format %q", c.path, *format), print: c.usage}
+	}
+	doc, err := spec.Load(*file)
+	if err != nil {
+		return err
+	}
+	report, err := drift.NewDetector(client).Detect(ctx, doc)
+	if err != nil {
+		return err
+	}
+	if err := report.Write(a.stdout, f); err != nil {
+		return err
+	}
+	// 定期的な検出で気付けるよう、差分がある場合は失敗とする
+	if !report.Empty() {
+		return fmt.Errorf("drift detected in %d resources", report.Drifted())
+	}
+	return nil
+}
+
+// confirm 標準入力から確認の応答を読み込む
+func (a *app) confirm(prompt string) (bool, error) {
+	fmt.Fprintf(a.stderr, "%s [y/N]: ", prompt)
+	line, err := bufio.NewReader(a.stdin).ReadString('\n')
+	if err != nil && line == "" {
+		return false, errors.New("no confirmation; use --yes to apply without confirmation")
+	}
+	switch strings.ToLower(strings.TrimSpace(line)) {
+	case "y", "yes":
+		return true, nil
+	}
+	fmt.Fprintln(a.stderr, "Canceled.")
+	return false, nil
+}
+
+func runExport(ctx context.Context, a *app, c *call) error {
+	file := c.String("file", "", "write the document to `file` instead of the standard output; the format is chosen by its extension")
+	secrets := c.Bool("include-secrets", false, "write passwords, keys and other secrets as they are")
+	_, client, err := c.prepare(0)
+	if err != nil {
+		return err
+	}
+	exporter := snapshot.NewExporter(client)
+	exporter.IncludeSecrets = *secrets
+	doc, err := exporter.Export(ctx)
+	if err != nil {
+		return err
+	}
+	if *file != "" {
+		return spec.Save(*file, doc)
+	}
+	format := spec.FormatYAML
+	if a.output == outputJSON {
+		format = spec.FormatJSON
+	}
+	data, err := spec.Marshal(doc, format)
+	if err != nil {
+		return err
+	}
+	_, err = a.stdout.Write(data)
+	return err
+}
+
+func runRestore(ctx context.Context, a *app, c *call) error {
+	file := c.String("file", "", "the spec document `file` exported with --include-secrets (required)")
+	checkpoint := c.String("checkpoint", "", "record created resources to `file` to resume or roll back after a failure")
+	rollback := c.Bool("rollback", false, "delete the created resources when the restore fails")
+	_, client, err := c.prepare(0, "file")
+	if err != nil {
+		return err
+	}
+	doc, err := spec.Load(*file)
+	if err != nil {
+		return err
+	}
+	restorer := snapshot.NewRestorer(client)
+	restorer.Checkpoint = *checkpoint
+	restorer.Rollback = *rollback
+	plan, err := restorer.Restore(ctx, doc)
+	if plan != nil {
+		if err := plan.Print(a.stdout); err != nil {
+			return err
+		}
+	}
+	return err
+}
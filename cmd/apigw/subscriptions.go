@@ -0,0 +1,184 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	apigw "github.com/sacloud/apigw-api-go"
+	v1 "github.com/sacloud/apigw-api-go/apis/v1"
+)
+
+var planColumns = []column[v1.Plan]{
+	{"ID", func(p *v1.Plan) string { return formatUUID(p.ID) }},
+	{"NAME", func(p *v1.Plan) string { return p.Name.Value }},
+	{"PRICE", func(p *v1.Plan) string { return p.Price.Value }},
+	{"MAX SERVICES", func(p *v1.Plan) string { return formatInt(p.MaxServices) }},
+	{"MAX REQUESTS", func(p *v1.Plan) string { return formatInt(p.MaxRequests) }},
+}
+
+var subscriptionColumns = []column[v1.SubscriptionDetailResponse]{
+	{"ID", func(s *v1.SubscriptionDetailResponse) string { return formatUUID(s.ID) }},
+	{"NAME", func(s *v1.SubscriptionDetailResponse) string { return string(s.Name.Value) }},
+	{"PLAN", func(s *v1.SubscriptionDetailResponse) string { return s.Plan.Value.PlanName.Value }},
+	{"SERVICE", func(s *v1.SubscriptionDetailResponse) string { return s.Service.Value.Name }},
+	{"MONTHLY REQUESTS", func(s *v1.SubscriptionDetailResponse) string { return formatInt(s.MonthlyRequest) }},
+}
+
+var subscriptionCommand = &command{
+	name:    "subscription",
+	summary: "Manage subscriptions",
+	sub: []*command{
+		{name: "plans", summary: "List available plans", run: subscriptionPlans},
+		{name: "list", summary: "List subscriptions", run: subscriptionList},
+		{name: "get", args: "SUBSCRIPTION", summary: "Show a subscription", run: subscriptionGet},
+		{name: "create", summary: "Subscribe to a plan", run: subscriptionCreate},
+		{name: "rename", args: "SUBSCRIPTION NAME", summary: "Rename a subscription", run: subscriptionRename},
+		{name: "delete", args: "SUBSCRIPTION", summary: "Cancel a subscription", run: subscriptionDelete},
+	},
+}
+
+func findSubscription(ctx context.Context, client *v1.Client, ref string) (*v1.Subscription, error) {
+	return apigw.Resolve(ctx, "subscription", ref, apigw.NewSubscriptionOp(client).List,
+		func(s *v1.Subscription) string { return string(s.Name.Value) },
+		func(s *v1.Subscription) uuid.UUID { return s.ID.Value })
+}
+
+func showSubscription(ctx context.Context, a *app, client *v1.Client, id uuid.UUID) error {
+	sub, err := apigw.NewSubscriptionOp(client).Read(ctx, id)
+	if err != nil {
+		return err
+	}
+	return show(a, sub, subscriptionColumns...)
+}
+
+func subscriptionPlans(ctx context.Context, a *app, c *call) error {
+	_, client, err := c.prepare(0)
+	if err != nil {
+		return err
+	}
+	plans, err := apigw.NewSubscriptionOp(client).ListPlans(ctx)
+	if err != nil {
+		return err
+	}
+	return list(a, plans, planColumns...)
+}
+
+func subscriptionList(ctx context.Context, a *app, c *call) error {
+	_, client, err := c.prepare(0)
+	if err != nil {
+		return err
+	}
+	op := apigw.NewSubscriptionOp(client)
+	subs, err := op.List(ctx)
+	if err != nil {
+		return err
+	}
+	plans, err := op.ListPlans(ctx)
+	if err != nil {
+		return err
+	}
+
+	// 一覧にはプラン名が含まれないため、プランの一覧から補う
+	details := make([]v1.SubscriptionDetailResponse, 0, len(subs))
+	for _, s := range subs {
+		d := v1.SubscriptionDetailResponse{
+			ID:             s.ID,
+			CreatedAt:      s.CreatedAt,
+			UpdatedAt:      s.UpdatedAt,
+			Name:           s.Name,
+			ResourceId:     s.ResourceId,
+			MonthlyRequest: s.MonthlyRequest,
+			Service:        s.Service,
+		}
+		for _, p := range plans {
+			if p.ID == s.PlanId {
+				d.Plan = v1.NewOptSubscriptionPlanResponse(v1.SubscriptionPlanResponse{
+					PlanID:      p.ID,
+					PlanName:    p.Name,
+					Price:       p.Price,
+					MaxServices: p.MaxServices,
+				})
+			}
+		}
+		details = append(details, d)
+	}
+	return list(a, details, subscriptionColumns...)
+}
+
+func subscriptionGet(ctx context.Context, a *app, c *call) error {
+	args, client, err := c.prepare(1)
+	if err != nil {
+		return err
+	}
+	sub, err := findSubscription(ctx, client, args[0])
+	if err != nil {
+		return err
+	}
+	return showSubscription(ctx, a, client, sub.ID.Value)
+}
+
+func subscriptionCreate(ctx context.Context, a *app, c *call) error {
+	name := c.String("name", "", "the subscription name (required)")
+	plan := c.String("plan", "", "the plan name or ID (required)")
+	_, client, err := c.prepare(0, "name", "plan")
+	if err != nil {
+		return err
+	}
+	op := apigw.NewSubscriptionOp(client)
+	p, err := apigw.Resolve(ctx, "plan", *plan, op.ListPlans,
+		func(p *v1.Plan) string { return p.Name.Value },
+		func(p *v1.Plan) uuid.UUID { return p.ID.Value })
+	if err != nil {
+		return err
+	}
+	if err := op.Create(ctx, p.ID.Value, *name); err != nil {
+		return err
+	}
+	// 作成APIはIDを返さないため、名前で検索する
+	sub, err := findSubscription(ctx, client, *name)
+	if err != nil {
+		return err
+	}
+	return showSubscription(ctx, a, client, sub.ID.Value)
+}
+
+func subscriptionRename(ctx context.Context, a *app, c *call) error {
+	args, client, err := c.prepare(2)
+	if err != nil {
+		return err
+	}
+	sub, err := findSubscription(ctx, client, args[0])
+	if err != nil {
+		return err
+	}
+	if err := apigw.NewSubscriptionOp(client).Update(ctx, sub.ID.Value, args[1]); err != nil {
+		return err
+	}
+	return showSubscription(ctx, a, client, sub.ID.Value)
+}
+
+func subscriptionDelete(ctx context.Context, a *app, c *call) error {
+	args, client, err := c.prepare(1)
+	if err != nil {
+		return err
+	}
+	sub, err := findSubscription(ctx, client, args[0])
+	if err != nil {
+		return err
+	}
+	return apigw.NewSubscriptionOp(client).Delete(ctx, sub.ID.Value)
+}
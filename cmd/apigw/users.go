@@ -0,0 +1,294 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	apigw "github.com/sacloud/apigw-api-go"
+	v1 "github.com/sacloud/apigw-api-go/apis/v1"
+)
+
+var userColumns = []column[v1.UserDetail]{
+	{"ID", func(u *v1.UserDetail) string { return formatUUID(u.ID) }},
+	{"NAME", func(u *v1.UserDetail) string { return string(u.Name) }},
+	{"CUSTOM ID", func(u *v1.UserDetail) string { return u.CustomID.Value }},
+	{"TAGS", func(u *v1.UserDetail) string { return formatList(u.Tags) }},
+	{"CREATED", func(u *v1.UserDetail) string { return formatTime(u.CreatedAt) }},
+}
+
+var userGroupColumns = []column[v1.UserGroupDetail]{
+	{"ID", func(g *v1.UserGroupDetail) string { return g.ID.String() }},
+	{"GROUP", func(g *v1.UserGroupDetail) string { return string(g.Name) }},
+	{"ASSIGNED", func(g *v1.UserGroupDetail) string { return strconv.FormatBool(g.IsAssigned) }},
+}
+
+var userCommand = &command{
+	name:    "user",
+	summary: "Manage users",
+	sub: []*command{
+		{name: "list", summary: "List users", run: userList},
+		{name: "get", args: "USER", summary: "Show a user", run: userGet},
+		{name: "create", summary: "Create a user", run: userCreate},
+		{name: "update", args: "USER", summary: "Update a user", run: userUpdate},
+		{name: "delete", args: "USER", summary: "Delete a user", run: userDelete},
+		{
+			name:    "group",
+			summary: "Manage groups a user belongs to",
+			sub: []*command{
+				{name: "list", args: "USER", summary: "List groups and whether the user belongs to them", run: userGroupList},
+				{name: "add", args: "USER GROUP...", summary: "Add a user to groups", run: userGroupUpdate(true)},
+				{name: "remove", args: "USER GROUP...", summary: "Remove a user from groups", run: userGroupUpdate(false)},
+			},
+		},
+		{
+			name:    "auth",
+			summary: "Manage credentials of a user",
+			sub: []*command{
+				{name: "get", args: "USER", summary: "Show the credentials of a user", run: userAuthGet},
+				{name: "set", args: "USER", summary: "Set basic, HMAC or JWT credentials of a user", run: userAuthSet},
+			},
+		},
+	},
+}
+
+func findUser(ctx context.Context, client *v1.Client, ref string) (*v1.User, error) {
+	return apigw.NewUserOp(client).Resolve(ctx, ref)
+}
+
+func showUser(ctx context.Context, a *app, client *v1.Client, id uuid.UUID) error {
+	user, err := apigw.NewUserOp(client).Read(ctx, id)
+	if err != nil {
+		return err
+	}
+	return show(a, user, userColumns...)
+}
+
+func userList(ctx context.Context, a *app, c *call) error {
+	_, client, err := c.prepare(0)
+	if err != nil {
+		return err
+	}
+	users, err := apigw.NewUserOp(client).List(ctx)
+	if err != nil {
+		return err
+	}
+	details := make([]v1.UserDetail, 0, len(users))
+	for _, u := range users {
+		var d v1.UserDetail
+		if err := convert(&d, &u); err != nil {
+			return err
+		}
+		details = append(details, d)
+	}
+	return list(a, details, userColumns...)
+}
+
+func userGet(ctx context.Context, a *app, c *call) error {
+	args, client, err := c.prepare(1)
+	if err != nil {
+		return err
+	}
+	user, err := findUser(ctx, client, args[0])
+	if err != nil {
+		return err
+	}
+	return showUser(ctx, a, client, user.ID.Value)
+}
+
+func userFlags(c *call) *body {
+	b := newBody(c)
+	b.String("name", "name", "the user name")
+	b.String("custom-id", "customID", "the custom ID of the user")
+	b.Strings("tag", "tags", "a tag")
+	return b
+}
+
+func userCreate(ctx context.Context, a *app, c *call) error {
+	b := userFlags(c)
+	_, client, err := c.prepare(0)
+	if err != nil {
+		return err
+	}
+	var req v1.UserDetail
+	if err := b.build(&req, nil); err != nil {
+		return err
+	}
+	created, err := apigw.NewUserOp(client).Create(ctx, &req)
+	if err != nil {
+		return err
+	}
+	return showUser(ctx, a, client, created.ID.Value)
+}
+
+func userUpdate(ctx context.Context, a *app, c *call) error {
+	b := userFlags(c)
+	args, client, err := c.prepare(1)
+	if err != nil {
+		return err
+	}
+	user, err := findUser(ctx, client, args[0])
+	if err != nil {
+		return err
+	}
+	op := apigw.NewUserOp(client)
+	current, err := op.Read(ctx, user.ID.Value)
+	if err != nil {
+		return err
+	}
+	var req v1.UserDetail
+	if err := b.build(&req, current, "groups"); err != nil {
+		return err
+	}
+	if err := op.Update(ctx, &req, user.ID.Value); err != nil {
+		return err
+	}
+	return showUser(ctx, a, client, user.ID.Value)
+}
+
+func userDelete(ctx context.Context, a *app, c *call) error {
+	args, client, err := c.prepare(1)
+	if err != nil {
+		return err
+	}
+	user, err := findUser(ctx, client, args[0])
+	if err != nil {
+		return err
+	}
+	return apigw.NewUserOp(client).Delete(ctx, user.ID.Value)
+}
+
+func userGroupList(ctx context.Context, a *app, c *call) error {
+	args, client, err := c.prepare(1)
+	if err != nil {
+		return err
+	}
+	user, err := findUser(ctx, client, args[0])
+	if err != nil {
+		return err
+	}
+	groups, err := apigw.NewUserExtraOp(client, user.ID.Value).ListGroup(ctx)
+	if err != nil {
+		return err
+	}
+	return list(a, groups, userGroupColumns...)
+}
+
+func userGroupUpdate(assign bool) func(ctx context.Context, a *app, c *call) error {
+	return func(ctx context.Context, a *app, c *call) error {
+		args, client, err := c.prepare(-1)
+		if err != nil {
+			return err
+		}
+		if len(args) < 2 {
+			return &usageError{msg: c.path + ": expected a user and at least one group", print: c.usage}
+		}
+		user, err := findUser(ctx, client, args[0])
+		if err != nil {
+			return err
+		}
+		op := apigw.NewUserExtraOp(client, user.ID.Value)
+		for _, g := range args[1:] {
+			if err := op.UpdateGroup(ctx, g, assign); err != nil {
+				return err
+			}
+		}
+		groups, err := op.ListGroup(ctx)
+		if err != nil {
+			return err
+		}
+		return list(a, groups, userGroupColumns...)
+	}
+}
+
+func userAuthGet(ctx context.Context, a *app, c *call) error {
+	args, client, err := c.prepare(1)
+	if err != nil {
+		return err
+	}
+	user, err := findUser(ctx, client, args[0])
+	if err != nil {
+		return err
+	}
+	auth, err := apigw.NewUserExtraOp(client, user.ID.Value).ReadAuth(ctx)
+	if err != nil {
+		return err
+	}
+	return a.encodeDocument(auth)
+}
+
+func userAuthSet(ctx context.Context, a *app, c *call) error {
+	basic := c.Bool("basic", false, "set basic authentication credentials (--username, --password)")
+	hmac := c.Bool("hmac", false, "set HMAC authentication credentials (--username, --secret)")
+	jwt := c.Bool("jwt", false, "set JWT credentials (--key, --secret, --algorithm)")
+	username := c.String("username", "", "the user name for basic or HMAC authentication")
+	password := c.String("password", "", "the password for basic authentication")
+	secret := c.String("secret", "", "the secret for HMAC or JWT authentication")
+	key := c.String("key", "", "the key for JWT authentication")
+	algorithm := c.String("algorithm", string(v1.JwtAlgorithmHS256), "the signing algorithm for JWT authentication: HS256, HS384 or HS512")
+	args, client, err := c.prepare(1)
+	if err != nil {
+		return err
+	}
+	if !*basic && !*hmac && !*jwt {
+		return &usageError{msg: c.path + ": one of --basic, --hmac or --jwt is required", print: c.usage}
+	}
+	user, err := findUser(ctx, client, args[0])
+	if err != nil {
+		return err
+	}
+
+	// 指定しなかった認証方式の設定は維持する
+	op := apigw.NewUserExtraOp(client, user.ID.Value)
+	auth, err := op.ReadAuth(ctx)
+	if apigw.IsNotFound(err) {
+		auth, err = &v1.UserAuthentication{}, nil
+	}
+	if err != nil {
+		return err
+	}
+	var missing []string
+	need := func(name, value string) {
+		if value == "" {
+			missing = append(missing, "--"+name)
+		}
+	}
+	if *basic {
+		need("username", *username)
+		need("password", *password)
+		auth.BasicAuth = v1.NewOptBasicAuth(v1.BasicAuth{UserName: *username, Password: *password})
+	}
+	if *hmac {
+		need("username", *username)
+		need("secret", *secret)
+		auth.HmacAuth = v1.NewOptHmacAuth(v1.HmacAuth{UserName: *username, Secret: *secret})
+	}
+	if *jwt {
+		need("key", *key)
+		need("secret", *secret)
+		auth.Jwt = v1.NewOptJwt(v1.Jwt{Key: *key, Secret: *secret, Algorithm: v1.JwtAlgorithm(*algorithm)})
+	}
+	if len(missing) > 0 {
+		return &usageError{msg: c.path + ": " + strings.Join(missing, ", ") + " required", print: c.usage}
+	}
+	if err := auth.Validate(); err != nil {
+		return fmt.Errorf("invalid credentials: %w", err)
+	}
+	return op.UpdateAuth(ctx, *auth)
+}
@@ -0,0 +1,298 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package diff 生成された型(v1.ServiceDetail、v1.RouteDetailなど)の値を項目単位で比較・マージする。
+//
+// 項目のパスはJSONのキーを"."、配列の要素を"[i]"でつないだ形式となる。比較は次の規則に従う。
+//   - OptXxxは未設定とゼロ値を区別し、どちらも設定されている場合は値を比較する
+//   - 文字列や数値の配列(Tags、Methods、Ipsなど)は順序を無視し、要素の追加・削除として扱う
+//   - それ以外の配列は要素の位置ごとに比較する
+//   - `mask:"true"`タグが付いた項目は値を比較するが、変更内容の値は置き換える
+package diff
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/sacloud/apigw-api-go/internal/jsondiff"
+	"github.com/sacloud/apigw-api-go/internal/wire"
+)
+
+// Op 変更の種別
+type Op int
+
+const (
+	// Add 未設定の項目が設定された、または配列に要素が追加された
+	Add Op = iota + 1
+	// Remove 項目が未設定になった、または配列から要素が削除された
+	Remove
+	// Replace 項目の値が変わった
+	Replace
+)
+
+func (o Op) String() string {
+	switch o {
+	case Add:
+		return "add"
+	case Remove:
+		return "remove"
+	case Replace:
+		return "replace"
+	}
+	return fmt.Sprintf("Op(%d)", int(o))
+}
+
+// Change 1つの項目の変更。
+// From/ToはJSONとしてデコードした値で、秘匿情報は置き換えられている。
+// Addの場合はFrom、Removeの場合はToがnil
+type Change struct {
+	Op   Op
+	Path string
+	From any
+	To   any
+}
+
+func (c Change) String() string {
+	switch c.Op {
+	case Add:
+		return fmt.Sprintf("%s: + %s", c.Path, jsondiff.Format(c.To))
+	case Remove:
+		return fmt.Sprintf("%s: - %s", c.Path, jsondiff.Format(c.From))
+	}
+	return fmt.Sprintf("%s: %s => %s", c.Path, jsondiff.Format(c.From), jsondiff.Format(c.To))
+}
+
+// Compare fromからtoへの変更を返す。変更がない場合は空
+func Compare[T any](from, to T) []Change {
+	var changes []Change
+	compare("", reflect.ValueOf(&from).Elem(), reflect.ValueOf(&to).Elem(), false, &changes)
+	return changes
+}
+
+// Equal aとbに変更がない場合にtrueを返す
+func Equal[T any](a, b T) bool {
+	return equal(reflect.ValueOf(&a).Elem(), reflect.ValueOf(&b).Elem())
+}
+
+func equal(a, b reflect.Value) bool {
+	var changes []Change
+	compare("", a, b, false, &changes)
+	return len(changes) == 0
+}
+
+func compare(path string, a, b reflect.Value, masked bool, changes *[]Change) {
+	add := func(op Op, from, to reflect.Value) {
+		c := Change{Op: op, Path: path}
+		if from.IsValid() {
+			c.From = shown(from, masked)
+		}
+		if to.IsValid() {
+			c.To = shown(to, masked)
+		}
+		*changes = append(*changes, c)
+	}
+
+	t := a.Type()
+	switch {
+	case isOptional(t):
+		aSet, bSet := a.FieldByName("Set").Bool(), b.FieldByName("Set").Bool()
+		switch {
+		case aSet && bSet:
+			compare(path, a.FieldByName("Value"), b.FieldByName("Value"), masked, changes)
+		case bSet:
+			add(Add, reflect.Value{}, b.FieldByName("Value"))
+		case aSet:
+			add(Remove, a.FieldByName("Value"), reflect.Value{})
+		}
+		return
+	case isScalar(t):
+		if !scalarEqual(a, b) {
+			add(Replace, a, b)
+		}
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		switch {
+		case !a.IsNil() && !b.IsNil():
+			compare(path, a.Elem(), b.Elem(), masked, changes)
+		case !b.IsNil():
+			add(Add, reflect.Value{}, b.Elem())
+		case !a.IsNil():
+			add(Remove, a.Elem(), reflect.Value{})
+		}
+	case reflect.Struct:
+		for i := range t.NumField() {
+			f := t.Field(i)
+			if !f.IsExported() {
+				continue
+			}
+			compare(join(path, f), a.Field(i), b.Field(i), masked || f.Tag.Get("mask") == "true", changes)
+		}
+	case reflect.Slice, reflect.Array:
+		if isSet(t) {
+			removed, added := setDiff(a, b)
+			for _, v := range removed {
+				add(Remove, v, reflect.Value{})
+			}
+			for _, v := range added {
+				add(Add, reflect.Value{}, v)
+			}
+			return
+		}
+		n := min(a.Len(), b.Len())
+		for i := range n {
+			compare(fmt.Sprintf("%s[%d]", path, i), a.Index(i), b.Index(i), masked, changes)
+		}
+		for i := n; i < a.Len(); i++ {
+			*changes = append(*changes, Change{Op: Remove, Path: fmt.Sprintf("%s[%d]", path, i), From: shown(a.Index(i), masked)})
+		}
+		for i := n; i < b.Len(); i++ {
+			*changes = append(*changes, Change{Op: Add, Path: fmt.Sprintf("%s[%d]", path, i), To: shown(b.Index(i), masked)})
+		}
+	default:
+		if !reflect.DeepEqual(a.Interface(), b.Interface()) {
+			add(Replace, a, b)
+		}
+	}
+}
+
+// setDiff 順序を無視した配列の比較で、aにのみある要素とbにのみある要素を返す。重複は個数で比較する
+func setDiff(a, b reflect.Value) (removed, added []reflect.Value) {
+	count := make(map[any]int)
+	for i := range b.Len() {
+		count[b.Index(i).Interface()]++
+	}
+	for i := range a.Len() {
+		v := a.Index(i)
+		if count[v.Interface()] > 0 {
+			count[v.Interface()]--
+			continue
+		}
+		removed = append(removed, v)
+	}
+	count = make(map[any]int)
+	for i := range a.Len() {
+		count[a.Index(i).Interface()]++
+	}
+	for i := range b.Len() {
+		v := b.Index(i)
+		if count[v.Interface()] > 0 {
+			count[v.Interface()]--
+			continue
+		}
+		added = append(added, v)
+	}
+	return removed, added
+}
+
+// shown 変更内容として表示する値を返す。秘匿情報はwire.Maskに置き換える
+func shown(v reflect.Value, masked bool) any {
+	switch {
+	case isOptional(v.Type()):
+		if !v.FieldByName("Set").Bool() {
+			return nil
+		}
+		return shown(v.FieldByName("Value"), masked)
+	case v.Kind() == reflect.Pointer:
+		if v.IsNil() {
+			return nil
+		}
+		return shown(v.Elem(), masked)
+	}
+	if masked {
+		if v.Kind() == reflect.String && v.Len() == 0 {
+			return ""
+		}
+		return wire.Mask
+	}
+
+	// ogenの型はポインタに対してMarshalJSONを実装している
+	p := reflect.New(v.Type())
+	p.Elem().Set(v)
+	data, err := json.Marshal(p.Interface())
+	if err != nil {
+		return v.Interface()
+	}
+	var ret any
+	if err := json.Unmarshal(wire.Redact(v.Type(), data), &ret); err != nil {
+		return v.Interface()
+	}
+	return ret
+}
+
+var timeType = reflect.TypeFor[time.Time]()
+
+// isScalar 値全体で比較する型か。UUIDなどの配列もここに含む
+func isScalar(t reflect.Type) bool {
+	if t == timeType || t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8 {
+		return true
+	}
+	switch t.Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	case reflect.Array:
+		return isScalar(t.Elem())
+	}
+	return false
+}
+
+func scalarEqual(a, b reflect.Value) bool {
+	switch {
+	case a.Type() == timeType:
+		return a.Interface().(time.Time).Equal(b.Interface().(time.Time))
+	case a.Kind() == reflect.Slice:
+		return bytes.Equal(a.Bytes(), b.Bytes())
+	}
+	return a.Equal(b)
+}
+
+// isSet 順序を無視して比較する配列か
+func isSet(t reflect.Type) bool {
+	return t.Kind() == reflect.Slice && isScalar(t.Elem()) && t.Elem().Comparable() && t.Elem() != timeType
+}
+
+// isOptional ogenのOptXxx・OptNilXxxか
+func isOptional(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct || t.NumField() < 2 || t.NumField() > 3 {
+		return false
+	}
+	value, ok := t.FieldByName("Value")
+	if !ok || value.Tag != "" {
+		return false
+	}
+	set, ok := t.FieldByName("Set")
+	return ok && set.Type.Kind() == reflect.Bool
+}
+
+// join 項目のパスにフィールドのJSONのキーを加える。
+// JSONのキーを持たないフィールド(oneOf型の候補など)はパスを変えない
+func join(path string, f reflect.StructField) string {
+	name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+	if name == "" || name == "-" {
+		return path
+	}
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
@@ -0,0 +1,108 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff_test
+
+import (
+	"testing"
+
+	v1 "github.com/sacloud/apigw-api-go/apis/v1"
+	"github.com/sacloud/apigw-api-go/diff"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompare(t *testing.T) {
+	from := v1.RouteDetail{
+		Name:      v1.NewOptName("api"),
+		Tags:      []string{"a", "b"},
+		Methods:   []v1.HTTPMethod{v1.HTTPMethodGET, v1.HTTPMethodPOST},
+		StripPath: v1.NewOptBool(true),
+		IpRestrictionConfig: v1.NewOptIpRestrictionConfig(v1.IpRestrictionConfig{
+			Protocols:    v1.IpRestrictionConfigProtocolsHTTPHTTPS,
+			RestrictedBy: v1.IpRestrictionConfigRestrictedByAllowIps,
+			Ips:          []string{"192.0.2.1", "192.0.2.2"},
+		}),
+	}
+
+	// 順序のみが異なる配列は変更としない
+	to := from
+	to.Tags = []string{"b", "a"}
+	to.Methods = []v1.HTTPMethod{v1.HTTPMethodPOST, v1.HTTPMethodGET}
+	assert.Empty(t, diff.Compare(from, to))
+	assert.True(t, diff.Equal(from, to))
+
+	to.Tags = []string{"b", "c"}
+	to.StripPath = v1.NewOptBool(false)
+	to.PreserveHost = v1.NewOptBool(false)
+	to.IpRestrictionConfig.Value.Ips = []string{"192.0.2.2", "192.0.2.3"}
+	var got []string
+	for _, c := range diff.Compare(from, to) {
+		got = append(got, c.String())
+	}
+	assert.Equal(t, []string{
+		`tags: - "a"`,
+		`tags: + "c"`,
+		`stripPath: true => false`,
+		`preserveHost: + false`,
+		`ipRestrictionConfig.ips: - "192.0.2.1"`,
+		`ipRestrictionConfig.ips: + "192.0.2.3"`,
+	}, got)
+
+	// 未設定とゼロ値は区別する
+	changes := diff.Compare(v1.RouteDetail{PreserveHost: v1.NewOptBool(false)}, v1.RouteDetail{})
+	assert.Equal(t, []diff.Change{{Op: diff.Remove, Path: "preserveHost", From: false}}, changes)
+}
+
+func TestCompare_Masked(t *testing.T) {
+	from := v1.UserAuthentication{BasicAuth: v1.NewOptBasicAuth(v1.BasicAuth{UserName: "alice", Password: "old"})}
+	to := v1.UserAuthentication{
+		BasicAuth: v1.NewOptBasicAuth(v1.BasicAuth{UserName: "alice", Password: "new"}),
+		HmacAuth:  v1.NewOptHmacAuth(v1.HmacAuth{UserName: "alice", Secret: "secret"}),
+	}
+	changes := diff.Compare(from, to)
+	assert.Equal(t, []diff.Change{
+		{Op: diff.Replace, Path: "basicAuth.password", From: "********", To: "********"},
+		{Op: diff.Add, Path: "hmacAuth", To: map[string]any{"userName": "alice", "secret": "********"}},
+	}, changes)
+}
+
+func TestMerge(t *testing.T) {
+	base := v1.CorsConfig{
+		AccessControlAllowOrigins: v1.NewOptString("https://example.com"),
+		AccessControlAllowMethods: []v1.HTTPMethod{v1.HTTPMethodGET},
+		AccessControlAllowHeaders: v1.NewOptString("Content-Type"),
+		Credentials:               v1.NewOptBool(false),
+		MaxAge:                    v1.NewOptInt32(60),
+	}
+	local := base
+	local.AccessControlAllowMethods = []v1.HTTPMethod{v1.HTTPMethodGET, v1.HTTPMethodPOST}
+	local.Credentials = v1.NewOptBool(true)
+	local.MaxAge = v1.NewOptInt32(120)
+	remote := base
+	remote.AccessControlAllowMethods = []v1.HTTPMethod{v1.HTTPMethodPUT}
+	remote.AccessControlAllowHeaders = v1.NewOptString("Authorization")
+	remote.MaxAge = v1.NewOptInt32(300)
+
+	merged, conflicts := diff.Merge(base, local, remote)
+	assert.ElementsMatch(t, []v1.HTTPMethod{v1.HTTPMethodPOST, v1.HTTPMethodPUT}, merged.AccessControlAllowMethods)
+	assert.Equal(t, v1.NewOptString("Authorization"), merged.AccessControlAllowHeaders)
+	assert.Equal(t, v1.NewOptBool(true), merged.Credentials)
+	// 衝突した項目はlocalの値をとる
+	assert.Equal(t, v1.NewOptInt32(120), merged.MaxAge)
+	assert.Equal(t, []diff.Conflict{{Path: "maxAge", Base: float64(60), Local: float64(120), Remote: float64(300)}}, conflicts)
+	assert.Equal(t, "maxAge: base 60, local 120, remote 300", conflicts[0].String())
+
+	// 入力は変更しない
+	assert.Equal(t, []v1.HTTPMethod{v1.HTTPMethodGET, v1.HTTPMethodPOST}, local.AccessControlAllowMethods)
+}
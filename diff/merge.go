@@ -0,0 +1,146 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/sacloud/apigw-api-go/internal/jsondiff"
+)
+
+// Conflict 3方向マージでlocalとremoteの双方が異なる値に変更した項目。
+// 値はJSONとしてデコードした値で、秘匿情報は置き換えられている
+type Conflict struct {
+	Path   string
+	Base   any
+	Local  any
+	Remote any
+}
+
+func (c Conflict) String() string {
+	return fmt.Sprintf("%s: base %s, local %s, remote %s",
+		c.Path, jsondiff.Format(c.Base), jsondiff.Format(c.Local), jsondiff.Format(c.Remote))
+}
+
+// Merge baseからのlocalとremoteの変更を合わせた値を返す。
+// 一方のみが変更した項目はその値を、双方が同じ値に変更した項目はその値をとる。
+// 順序を無視する配列は、双方で追加・削除された要素をそれぞれ反映する。
+// 双方が異なる値に変更した項目はConflictとして返し、結果にはlocalの値を用いる
+func Merge[T any](base, local, remote T) (T, []Conflict) {
+	var conflicts []Conflict
+	merged := merge("", reflect.ValueOf(&base).Elem(), reflect.ValueOf(&local).Elem(), reflect.ValueOf(&remote).Elem(), false, &conflicts)
+	return merged.Interface().(T), conflicts
+}
+
+func merge(path string, base, local, remote reflect.Value, masked bool, conflicts *[]Conflict) reflect.Value {
+	switch {
+	case equal(local, remote), equal(base, remote):
+		return local
+	case equal(base, local):
+		return remote
+	}
+
+	conflict := func() reflect.Value {
+		*conflicts = append(*conflicts, Conflict{
+			Path:   path,
+			Base:   shown(base, masked),
+			Local:  shown(local, masked),
+			Remote: shown(remote, masked),
+		})
+		return local
+	}
+
+	// 双方が変更した項目は、より細かい単位でマージできる場合のみマージする
+	t := local.Type()
+	ret := reflect.New(t).Elem()
+	switch {
+	case isOptional(t):
+		if !base.FieldByName("Set").Bool() || !local.FieldByName("Set").Bool() || !remote.FieldByName("Set").Bool() {
+			return conflict()
+		}
+		ret.Set(local)
+		ret.FieldByName("Value").Set(merge(path, base.FieldByName("Value"), local.FieldByName("Value"), remote.FieldByName("Value"), masked, conflicts))
+		return ret
+	case isScalar(t):
+		return conflict()
+	case isSet(t):
+		ret.Set(mergeSet(base, local, remote))
+		return ret
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		if base.IsNil() || local.IsNil() || remote.IsNil() {
+			return conflict()
+		}
+		ret.Set(reflect.New(t.Elem()))
+		ret.Elem().Set(merge(path, base.Elem(), local.Elem(), remote.Elem(), masked, conflicts))
+		return ret
+	case reflect.Struct:
+		ret.Set(local)
+		for i := range t.NumField() {
+			f := t.Field(i)
+			if !f.IsExported() {
+				continue
+			}
+			ret.Field(i).Set(merge(join(path, f), base.Field(i), local.Field(i), remote.Field(i), masked || f.Tag.Get("mask") == "true", conflicts))
+		}
+		return ret
+	case reflect.Slice:
+		// 要素の追加・削除を伴う場合は位置の対応がとれないため、要素数が同じ場合のみ要素ごとにマージする
+		if base.Len() != local.Len() || base.Len() != remote.Len() {
+			return conflict()
+		}
+		ret.Set(reflect.MakeSlice(t, local.Len(), local.Len()))
+		for i := range local.Len() {
+			ret.Index(i).Set(merge(fmt.Sprintf("%s[%d]", path, i), base.Index(i), local.Index(i), remote.Index(i), masked, conflicts))
+		}
+		return ret
+	}
+	return conflict()
+}
+
+// mergeSet localに、remoteでbaseから追加・削除された要素を反映する
+func mergeSet(base, local, remote reflect.Value) reflect.Value {
+	removed, added := setDiff(base, remote)
+	drop := make(map[any]int)
+	for _, v := range removed {
+		drop[v.Interface()]++
+	}
+	ret := reflect.MakeSlice(local.Type(), 0, local.Len()+len(added))
+	for i := range local.Len() {
+		v := local.Index(i)
+		if drop[v.Interface()] > 0 {
+			drop[v.Interface()]--
+			continue
+		}
+		ret = reflect.Append(ret, v)
+	}
+	// remoteが追加した要素のうち、localも追加したものは重複させない
+	_, localAdded := setDiff(base, local)
+	have := make(map[any]int)
+	for _, v := range localAdded {
+		have[v.Interface()]++
+	}
+	for _, v := range added {
+		if have[v.Interface()] > 0 {
+			have[v.Interface()]--
+			continue
+		}
+		ret = reflect.Append(ret, v)
+	}
+	return ret
+}
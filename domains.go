@@ -17,6 +17,7 @@ package apigw
 import (
 	"context"
 	"errors"
+	"iter"
 
 	"github.com/google/uuid"
 	v1 "github.com/sacloud/apigw-api-go/apis/v1"
@@ -24,9 +25,17 @@ import (
 
 type DomainAPI interface {
 	List(ctx context.Context) ([]v1.Domain, error)
+	// All Listの結果を1件ずつ返すイテレータ。取得に失敗した場合はエラーを1度だけ返す
+	All(ctx context.Context) iter.Seq2[v1.Domain, error]
 	Create(ctx context.Context, request *v1.Domain) (*v1.Domain, error)
 	Delete(ctx context.Context, id uuid.UUID) error
 	Update(ctx context.Context, request *v1.DomainPUT, id uuid.UUID) error
+	// FindByName domainNameが一致するものをすべて返す
+	FindByName(ctx context.Context, domainName string) ([]v1.Domain, error)
+	// GetByName domainNameが一致するものを1件返す。見つからない場合は*NotFoundError、複数ある場合は*AmbiguousErrorを返す
+	GetByName(ctx context.Context, domainName string) (*v1.Domain, error)
+	// Resolve IDまたはdomainNameがidOrNameと一致するものを1件返す
+	Resolve(ctx context.Context, idOrName string) (*v1.Domain, error)
 }
 
 var _ DomainAPI = (*domainOp)(nil)
@@ -59,7 +68,35 @@ func (op *domainOp) List(ctx context.Context) ([]v1.Domain, error) {
 	return nil, NewAPIError("Domain.List", 0, nil)
 }
 
+func (op *domainOp) All(ctx context.Context) iter.Seq2[v1.Domain, error] {
+	return all(ctx, op.List)
+}
+
+func domainLookup(list func(context.Context) ([]v1.Domain, error)) lookup[v1.Domain] {
+	return lookup[v1.Domain]{
+		resource: "domain",
+		list:     list,
+		name:     func(d *v1.Domain) string { return d.DomainName },
+		id:       func(d *v1.Domain) uuid.UUID { return d.ID.Value },
+	}
+}
+
+func (op *domainOp) FindByName(ctx context.Context, domainName string) ([]v1.Domain, error) {
+	return domainLookup(op.List).find(ctx, domainName)
+}
+
+func (op *domainOp) GetByName(ctx context.Context, domainName string) (*v1.Domain, error) {
+	return domainLookup(op.List).get(ctx, domainName)
+}
+
+func (op *domainOp) Resolve(ctx context.Context, idOrName string) (*v1.Domain, error) {
+	return domainLookup(op.List).resolve(ctx, idOrName)
+}
+
 func (op *domainOp) Create(ctx context.Context, request *v1.Domain) (*v1.Domain, error) {
+	if err := validateRequest("Domain.Create", request); err != nil {
+		return nil, err
+	}
 	res, err := op.client.AddDomain(ctx, request)
 	if err != nil {
 		return nil, NewAPIError("Domain.Create", 0, err)
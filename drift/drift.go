@@ -0,0 +1,157 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package drift 宣言的に記述した設定(spec.Document)とアカウントの現在の状態を比較し、変更を行わずに差分を報告する。
+//
+//	report, err := drift.NewDetector(client).Detect(ctx, doc)
+//	report.Write(os.Stdout, drift.FormatText)
+//
+// 比較の対象はサービスとそのルート、ルートの認可設定・変換設定で、
+// サーバ側で設定される項目(ID、作成・更新日時、ルートのホスト名)は比較しない。
+package drift
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	v1 "github.com/sacloud/apigw-api-go/apis/v1"
+	"github.com/sacloud/apigw-api-go/apply"
+	"github.com/sacloud/apigw-api-go/internal/jsondiff"
+	"github.com/sacloud/apigw-api-go/spec"
+)
+
+// ignoredFields サーバ側で設定され、差分として扱わない項目
+var ignoredFields = append(slices.Clone(jsondiff.ServerManaged), "routeHost")
+
+// Detector ドキュメントとアカウントの差分を検出する
+type Detector struct {
+	client *v1.Client
+}
+
+// NewDetector Detectorを生成する
+func NewDetector(client *v1.Client) *Detector {
+	return &Detector{client: client}
+}
+
+// Detect docのサービスとアカウントの現在の状態を比較する。
+// サービスが参照するサブスクリプション・グループ・OIDC認証はdocまたはアカウントに存在する必要がある
+func (d *Detector) Detect(ctx context.Context, doc *spec.Document) (*Report, error) {
+	// サービス以外のリソースは比較しないが、サービスからの参照を解決するために含める
+	target := &spec.Document{
+		Version:       doc.Version,
+		Subscriptions: doc.Subscriptions,
+		Groups:        doc.Groups,
+		Oidc:          oidcNames(doc.Oidc),
+		Services:      withAuthorization(doc.Services),
+	}
+	plan, err := apply.NewPlanner(d.client).Plan(ctx, target)
+	if err != nil {
+		return nil, fmt.Errorf("drift: %w", err)
+	}
+
+	report := &Report{Resources: []string{}, Drifts: []Drift{}}
+	for _, s := range doc.Services {
+		report.Resources = append(report.Resources, servicePath(s.Name))
+		for _, r := range s.Routes {
+			report.Resources = append(report.Resources, routePath(s.Name, r.Name))
+		}
+	}
+	for _, c := range plan.Changes {
+		report.add(c)
+	}
+	return report, nil
+}
+
+// oidcNames サービスからの参照の解決に必要なOIDC認証の名前のみを残す。
+// 書き出したドキュメントの秘匿情報はマスクされているため、それ以外の項目は含めない
+func oidcNames(oidc []spec.Oidc) []spec.Oidc {
+	ret := make([]spec.Oidc, len(oidc))
+	for i, o := range oidc {
+		ret[i] = spec.Oidc{Name: o.Name}
+	}
+	return ret
+}
+
+// withAuthorization 認可設定を省略したルートを、認可を無効にしたルートとして扱う。
+// applyでは省略した認可設定は変更しないが、差分の検出ではコントロールパネルで追加された認可設定も報告する
+func withAuthorization(services []spec.Service) []spec.Service {
+	services = slices.Clone(services)
+	for i := range services {
+		routes := slices.Clone(services[i].Routes)
+		for j := range routes {
+			if routes[j].Authorization == nil {
+				routes[j].Authorization = &spec.RouteAuthorization{Groups: []spec.RouteAuthorizationGroup{}}
+			}
+		}
+		services[i].Routes = routes
+	}
+	return services
+}
+
+func (r *Report) add(c *apply.Change) {
+	var resource string
+	switch c.Kind {
+	case apply.KindService:
+		resource = servicePath(c.Name)
+	case apply.KindRoute, apply.KindRouteAuthorization, apply.KindRequestTransformation, apply.KindResponseTransformation:
+		// ルートとその設定の名前は"サービス名/ルート名"
+		service, route, _ := strings.Cut(c.Name, "/")
+		resource = routePath(service, route)
+	default:
+		return
+	}
+
+	switch c.Action {
+	case apply.ActionCreate:
+		// 存在しないルートの設定はルート自体の差分として報告する
+		if c.Kind == apply.KindService || c.Kind == apply.KindRoute {
+			r.Drifts = append(r.Drifts, Drift{Resource: resource, Path: resource, Kind: KindMissing})
+		}
+	case apply.ActionDelete:
+		r.Resources = append(r.Resources, resource)
+		r.Drifts = append(r.Drifts, Drift{Resource: resource, Path: resource, Kind: KindUnexpected})
+	case apply.ActionUpdate:
+		prefix := resource
+		if setting, ok := strings.CutPrefix(string(c.Kind), "route."); ok {
+			prefix += "." + setting
+		}
+		for _, d := range c.Diffs {
+			if ignored(d.Path) {
+				continue
+			}
+			r.Drifts = append(r.Drifts, Drift{
+				Resource: resource,
+				Path:     prefix + "." + d.Path,
+				Kind:     KindChanged,
+				Want:     d.To,
+				Got:      d.From,
+			})
+		}
+	}
+}
+
+func ignored(path string) bool {
+	field, _, _ := strings.Cut(path, ".")
+	return slices.Contains(ignoredFields, field)
+}
+
+func servicePath(name string) string {
+	return "services[" + name + "]"
+}
+
+func routePath(service, route string) string {
+	return servicePath(service) + ".routes[" + route + "]"
+}
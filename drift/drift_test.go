@@ -0,0 +1,174 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drift_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	apigw "github.com/sacloud/apigw-api-go"
+	"github.com/sacloud/apigw-api-go/apigwtest"
+	v1 "github.com/sacloud/apigw-api-go/apis/v1"
+	"github.com/sacloud/apigw-api-go/apply"
+	"github.com/sacloud/apigw-api-go/drift"
+	"github.com/sacloud/apigw-api-go/internal/wire"
+	"github.com/sacloud/apigw-api-go/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const document = `
+version: 1
+groups:
+  - name: admins
+services:
+  - name: backend
+    subscription: test-sub
+    protocol: https
+    host: backend.example.com
+    routes:
+      - name: api
+        path: /api
+        methods: [GET, POST]
+        stripPath: true
+        authorization:
+          groups:
+            - name: admins
+        requestTransformation:
+          httpMethod: POST
+      - name: health
+        path: /health
+`
+
+func TestDetector(t *testing.T) {
+	fake, client := apigwtest.NewClient(t)
+	ctx := t.Context()
+	require.NoError(t, apigw.NewSubscriptionOp(client).Create(ctx, fake.Plans()[0].ID.Value, "test-sub"))
+
+	path := filepath.Join(t.TempDir(), "apigw.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(document), 0o600))
+	doc, err := spec.Load(path)
+	require.NoError(t, err)
+	planner := apply.NewPlanner(client)
+	plan, err := planner.Plan(ctx, doc)
+	require.NoError(t, err)
+	require.NoError(t, planner.Apply(ctx, plan))
+
+	detector := drift.NewDetector(client)
+	report, err := detector.Detect(ctx, doc)
+	require.NoError(t, err)
+	assert.True(t, report.Empty(), report.String())
+	assert.Equal(t, "No drift detected.\n", report.String())
+
+	// 文字列の配列は順序を無視して比較する
+	service, err := apigw.NewServiceOp(client).GetByName(ctx, "backend")
+	require.NoError(t, err)
+	routeOp := apigw.NewRouteOp(client, service.ID.Value)
+	api, err := routeOp.GetByName(ctx, "api")
+	require.NoError(t, err)
+	detail, err := routeOp.Read(ctx, api.ID.Value)
+	require.NoError(t, err)
+	detail.Methods = []v1.HTTPMethod{v1.HTTPMethodPOST, v1.HTTPMethodGET}
+	require.NoError(t, routeOp.Update(ctx, detail, api.ID.Value))
+	report, err = detector.Detect(ctx, doc)
+	require.NoError(t, err)
+	assert.True(t, report.Empty(), report.String())
+
+	// コントロールパネルでの変更を模倣する
+
+	// 認可設定を省略したルートは、認可が無効であることを期待する
+	health, err := routeOp.GetByName(ctx, "health")
+	require.NoError(t, err)
+	admins, err := apigw.NewGroupOp(client).GetByName(ctx, "admins")
+	require.NoError(t, err)
+	healthExtra := apigw.NewRouteExtraOp(client, service.ID.Value, health.ID.Value)
+	require.NoError(t, healthExtra.EnableAuthorization(ctx, []v1.RouteAuthorization{{ID: admins.ID}}))
+	report, err = detector.Detect(ctx, doc)
+	require.NoError(t, err)
+	require.Len(t, report.Drifts, 1, report.String())
+	assert.Equal(t, "services[backend].routes[health].authorization.groups", report.Drifts[0].Path)
+	assert.Equal(t, drift.KindChanged, report.Drifts[0].Kind)
+	require.NoError(t, healthExtra.DisableAuthorization(ctx))
+
+	detail, err = routeOp.Read(ctx, api.ID.Value)
+	require.NoError(t, err)
+	detail.StripPath = v1.NewOptBool(false)
+	require.NoError(t, routeOp.Update(ctx, detail, api.ID.Value))
+	require.NoError(t, apigw.NewRouteExtraOp(client, service.ID.Value, api.ID.Value).UpdateRequestTransformation(ctx, &v1.RequestTransformation{
+		HttpMethod: v1.NewOptHTTPMethod(v1.HTTPMethodPUT),
+	}))
+	require.NoError(t, routeOp.Delete(ctx, health.ID.Value))
+	_, err = routeOp.Create(ctx, &v1.RouteDetail{Name: v1.NewOptName("debug"), Path: v1.NewOptString("/debug")})
+	require.NoError(t, err)
+
+	report, err = detector.Detect(ctx, doc)
+	require.NoError(t, err)
+	var lines []string
+	for _, d := range report.Drifts {
+		lines = append(lines, d.String())
+	}
+	assert.ElementsMatch(t, []string{
+		`services[backend].routes[api].stripPath: want true, got false`,
+		`services[backend].routes[api].requestTransformation.httpMethod: want "POST", got "PUT"`,
+		`services[backend].routes[health]: missing`,
+		`services[backend].routes[debug]: unexpected`,
+	}, lines)
+	assert.Equal(t, 3, report.Drifted())
+	assert.Contains(t, report.String(), "Drift: 4 differences in 3 of 4 resources.")
+
+	var buf bytes.Buffer
+	require.NoError(t, report.Write(&buf, drift.FormatJSON))
+	var decoded drift.Report
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	assert.Len(t, decoded.Drifts, 4)
+
+	buf.Reset()
+	require.NoError(t, report.Write(&buf, drift.FormatJUnit))
+	assert.Contains(t, buf.String(), `<testsuite name="apigw drift" tests="4" failures="3">`)
+	assert.Contains(t, buf.String(), `<testcase classname="drift" name="services[backend]"></testcase>`)
+	assert.Contains(t, buf.String(), `<failure message="2 differences">`)
+}
+
+func TestDetector_Oidc(t *testing.T) {
+	fake, client := apigwtest.NewClient(t)
+	ctx := t.Context()
+	require.NoError(t, apigw.NewSubscriptionOp(client).Create(ctx, fake.Plans()[0].ID.Value, "test-sub"))
+
+	// サービスが参照するOIDC認証がドキュメントにのみ定義されていても、差分として報告する
+	doc := &spec.Document{
+		Version: spec.Version,
+		Oidc: []spec.Oidc{{
+			Name:                  "test_oidc",
+			AuthenticationMethods: []v1.AuthenticationMethodsItem{v1.AuthenticationMethodsItemAccessToken},
+			Issuer:                "https://idp.example.com",
+			ClientID:              wire.Mask,
+			ClientSecret:          wire.Mask,
+		}},
+		Services: []spec.Service{{
+			Name:         "backend",
+			Subscription: "test-sub",
+			Protocol:     "https",
+			Host:         "backend.example.com",
+			Oidc:         "test_oidc",
+		}},
+	}
+	report, err := drift.NewDetector(client).Detect(ctx, doc)
+	require.NoError(t, err)
+	require.Len(t, report.Drifts, 1, report.String())
+	assert.Equal(t, "services[backend]: missing", report.Drifts[0].String())
+}
@@ -0,0 +1,174 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drift
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/sacloud/apigw-api-go/internal/jsondiff"
+)
+
+// Kind 差分の種類
+type Kind string
+
+const (
+	// KindChanged 項目の値が異なる
+	KindChanged Kind = "changed"
+	// KindMissing ドキュメントのリソースがアカウントに存在しない
+	KindMissing Kind = "missing"
+	// KindUnexpected ドキュメントにないリソースがアカウントに存在する
+	KindUnexpected Kind = "unexpected"
+)
+
+// Drift 1つの差分。Pathは"services[api].routes[v1].stripPath"のような項目のパスで、
+// リソース自体の有無の差分の場合はResourceと同じになる。
+// Want/GotはJSONとしてデコードした値で、秘匿情報は置き換えられている
+type Drift struct {
+	Resource string `json:"resource"`
+	Path     string `json:"path"`
+	Kind     Kind   `json:"kind"`
+	Want     any    `json:"want,omitempty"`
+	Got      any    `json:"got,omitempty"`
+}
+
+func (d Drift) String() string {
+	if d.Kind != KindChanged {
+		return fmt.Sprintf("%s: %s", d.Path, d.Kind)
+	}
+	return fmt.Sprintf("%s: want %s, got %s", d.Path, jsondiff.Format(d.Want), jsondiff.Format(d.Got))
+}
+
+// Report 差分の検出結果
+type Report struct {
+	// Resources 比較したリソースのパス。ドキュメントにないリソースを含む
+	Resources []string `json:"resources"`
+	Drifts    []Drift  `json:"drifts"`
+}
+
+// Empty 差分がない場合にtrueを返す
+func (r *Report) Empty() bool {
+	return len(r.Drifts) == 0
+}
+
+// Drifted 差分のあるリソースの数を返す
+func (r *Report) Drifted() int {
+	var n int
+	for _, res := range r.Resources {
+		if slices.ContainsFunc(r.Drifts, func(d Drift) bool { return d.Resource == res }) {
+			n++
+		}
+	}
+	return n
+}
+
+func (r *Report) String() string {
+	if r.Empty() {
+		return "No drift detected.\n"
+	}
+
+	var b strings.Builder
+	for _, d := range r.Drifts {
+		fmt.Fprintln(&b, d)
+	}
+	fmt.Fprintf(&b, "\nDrift: %d differences in %d of %d resources.\n", len(r.Drifts), r.Drifted(), len(r.Resources))
+	return b.String()
+}
+
+// Format 出力形式
+type Format string
+
+const (
+	// FormatText 人が読める形式
+	FormatText Format = "text"
+	// FormatJSON JSON形式
+	FormatJSON Format = "json"
+	// FormatJUnit JUnit XML形式。リソースごとにテストケースとし、差分のあるリソースを失敗とする
+	FormatJUnit Format = "junit"
+)
+
+// Write 検出結果をformatの形式でwに出力する
+func (r *Report) Write(w io.Writer, format Format) error {
+	switch format {
+	case FormatText:
+		_, err := io.WriteString(w, r.String())
+		return err
+	case FormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(r)
+	case FormatJUnit:
+		return r.writeJUnit(w)
+	}
+	return fmt.Errorf("drift: unknown format: %s", format)
+}
+
+type junitSuites struct {
+	XMLName xml.Name     `xml:"testsuites"`
+	Suites  []junitSuite `xml:"testsuite"`
+}
+
+type junitSuite struct {
+	Name     string      `xml:"name,attr"`
+	Tests    int         `xml:"tests,attr"`
+	Failures int         `xml:"failures,attr"`
+	Cases    []junitCase `xml:"testcase"`
+}
+
+type junitCase struct {
+	ClassName string        `xml:"classname,attr"`
+	Name      string        `xml:"name,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+func (r *Report) writeJUnit(w io.Writer) error {
+	suite := junitSuite{Name: "apigw drift", Tests: len(r.Resources), Failures: r.Drifted()}
+	for _, res := range r.Resources {
+		c := junitCase{ClassName: "drift", Name: res}
+		var lines []string
+		for _, d := range r.Drifts {
+			if d.Resource == res {
+				lines = append(lines, d.String())
+			}
+		}
+		if len(lines) > 0 {
+			c.Failure = &junitFailure{
+				Message: fmt.Sprintf("%d differences", len(lines)),
+				Text:    strings.Join(lines, "\n"),
+			}
+		}
+		suite.Cases = append(suite.Cases, c)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(junitSuites{Suites: []junitSuite{suite}}); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
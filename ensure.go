@@ -0,0 +1,114 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apigw
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/sacloud/apigw-api-go/diff"
+	"github.com/sacloud/apigw-api-go/internal/jsondiff"
+)
+
+// EnsureAction Ensureで行った操作
+type EnsureAction int
+
+const (
+	// EnsureUnchanged 既存のリソースが期待する状態と一致しており、何もしなかった
+	EnsureUnchanged EnsureAction = iota
+	// EnsureCreated リソースが存在しなかったため作成した
+	EnsureCreated
+	// EnsureUpdated 既存のリソースを更新した
+	EnsureUpdated
+)
+
+func (a EnsureAction) String() string {
+	switch a {
+	case EnsureUnchanged:
+		return "unchanged"
+	case EnsureCreated:
+		return "created"
+	case EnsureUpdated:
+		return "updated"
+	}
+	return fmt.Sprintf("EnsureAction(%d)", int(a))
+}
+
+// ensurer 名前による検索・作成・取得・更新の操作からEnsureを行う
+type ensurer[D, R json.Marshaler, U any, PU interface {
+	*U
+	json.Unmarshaler
+}] struct {
+	// method 検証エラーに含める操作の名前。"Route.Ensure"など
+	method string
+	// find 名前で既存のリソースを検索し、IDと比較に使う現在の値を返す
+	find   func(ctx context.Context, name string) (uuid.UUID, R, error)
+	create func(ctx context.Context, desired D) (uuid.UUID, error)
+	read   func(ctx context.Context, id uuid.UUID) (R, error)
+	update func(ctx context.Context, request PU, id uuid.UUID) error
+	// omit 比較・更新の対象から除く項目
+	omit []string
+}
+
+// ensure 名前がnameのリソースがなければdesiredで作成し、あればdesiredで設定した項目に差分がある場合のみ更新する
+func (e ensurer[D, R, U, PU]) ensure(ctx context.Context, name string, desired D) (R, EnsureAction, error) {
+	var zero R
+	if name == "" {
+		// 名前のない既存のリソースを誤って更新しないよう、名前は必須とする
+		return zero, EnsureUnchanged, invalid(e.method, []FieldError{{Path: "name", Message: "is required"}})
+	}
+	id, current, err := e.find(ctx, name)
+	if IsNotFound(err) {
+		id, err := e.create(ctx, desired)
+		if err != nil {
+			return zero, EnsureUnchanged, err
+		}
+		ret, err := e.read(ctx, id)
+		return ret, EnsureCreated, err
+	}
+	if err != nil {
+		return zero, EnsureUnchanged, err
+	}
+	request := PU(new(U))
+	changed, err := overlay(request, current, desired, e.omit...)
+	if err != nil || !changed {
+		return current, EnsureUnchanged, err
+	}
+	if err := e.update(ctx, request, id); err != nil {
+		return zero, EnsureUnchanged, err
+	}
+	ret, err := e.read(ctx, id)
+	return ret, EnsureUpdated, err
+}
+
+// overlay currentにdesiredで設定した項目を上書きした更新用のリクエストをdstに設定し、
+// currentから変更があるかを返す。keysとサーバ側で設定される項目はcurrent・desiredの双方から除く。
+// desiredで設定していない項目は現在の値を維持し、変更の有無はdiff.Equalで判定する
+func overlay[U any, PU interface {
+	*U
+	json.Unmarshaler
+}](dst PU, current, desired json.Marshaler, keys ...string) (bool, error) {
+	var base U
+	if err := jsondiff.Overlay(PU(&base), current, nil, keys...); err != nil {
+		return false, err
+	}
+	if err := jsondiff.Overlay(dst, current, desired, keys...); err != nil {
+		return false, err
+	}
+	return !diff.Equal(base, *dst), nil
+}
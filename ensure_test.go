@@ -0,0 +1,126 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apigw_test
+
+import (
+	"testing"
+
+	. "github.com/sacloud/apigw-api-go"
+	"github.com/sacloud/apigw-api-go/apigwtest"
+	v1 "github.com/sacloud/apigw-api-go/apis/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroupAPI_Ensure(t *testing.T) {
+	_, client := apigwtest.NewClient(t)
+	ctx := t.Context()
+
+	groupOp := NewGroupOp(client)
+	group, action, err := groupOp.Ensure(ctx, &v1.Group{Name: v1.NewOptName("admins"), Tags: []string{"a", "b"}})
+	require.NoError(t, err)
+	assert.Equal(t, EnsureCreated, action)
+	id := group.ID.Value
+
+	// タグの順序のみが異なる場合は更新しない
+	group, action, err = groupOp.Ensure(ctx, &v1.Group{Name: v1.NewOptName("admins"), Tags: []string{"b", "a"}})
+	require.NoError(t, err)
+	assert.Equal(t, EnsureUnchanged, action)
+	assert.Equal(t, id, group.ID.Value)
+
+	group, action, err = groupOp.Ensure(ctx, &v1.Group{Name: v1.NewOptName("admins"), Tags: []string{"c"}})
+	require.NoError(t, err)
+	assert.Equal(t, EnsureUpdated, action)
+	assert.Equal(t, id, group.ID.Value)
+	assert.Equal(t, v1.Tags{"c"}, group.Tags)
+
+	// 指定しなかった項目は現在の値を維持する
+	_, action, err = groupOp.Ensure(ctx, &v1.Group{Name: v1.NewOptName("admins")})
+	require.NoError(t, err)
+	assert.Equal(t, EnsureUnchanged, action)
+
+	// 名前を指定しない場合は検証エラーとなる
+	_, _, err = groupOp.Ensure(ctx, &v1.Group{Tags: []string{"d"}})
+	assert.True(t, IsInvalid(err), err)
+
+	// 所属するグループは比較しない
+	userOp := NewUserOp(client)
+	user, action, err := userOp.Ensure(ctx, &v1.UserDetail{Name: "alice", CustomID: v1.NewOptString("a-1")})
+	require.NoError(t, err)
+	assert.Equal(t, EnsureCreated, action)
+	require.NoError(t, NewUserExtraOp(client, user.ID.Value).UpdateGroup(ctx, "admins", true))
+	_, action, err = userOp.Ensure(ctx, &v1.UserDetail{Name: "alice", CustomID: v1.NewOptString("a-1")})
+	require.NoError(t, err)
+	assert.Equal(t, EnsureUnchanged, action)
+}
+
+func TestRouteAPI_Ensure(t *testing.T) {
+	fake, client := apigwtest.NewClient(t)
+	ctx := t.Context()
+
+	subOp := NewSubscriptionOp(client)
+	serviceOp := NewServiceOp(client)
+	var services []*v1.ServiceDetailResponse
+	for i, name := range []v1.Name{"a", "b"} {
+		require.NoError(t, subOp.Create(ctx, fake.Plans()[0].ID.Value, string(name)))
+		subs, err := subOp.List(ctx)
+		require.NoError(t, err)
+		desired := &v1.ServiceDetailRequest{
+			Name:         name,
+			Host:         "backend.example.com",
+			Protocol:     v1.ServiceDetailRequestProtocolHTTPS,
+			Subscription: v1.ServiceSubscriptionRequest{ID: subs[i].ID.Value},
+		}
+		service, action, err := serviceOp.Ensure(ctx, desired)
+		require.NoError(t, err)
+		assert.Equal(t, EnsureCreated, action)
+		_, action, err = serviceOp.Ensure(ctx, desired)
+		require.NoError(t, err)
+		assert.Equal(t, EnsureUnchanged, action)
+		services = append(services, service)
+	}
+
+	// 同じ名前のルートでもサービスごとに扱う
+	desired := &v1.RouteDetail{Name: v1.NewOptName("api"), Path: v1.NewOptString("/api"), StripPath: v1.NewOptBool(true)}
+	for _, service := range services {
+		_, action, err := NewRouteOp(client, service.ID.Value).Ensure(ctx, desired)
+		require.NoError(t, err)
+		assert.Equal(t, EnsureCreated, action)
+	}
+
+	routeOp := NewRouteOp(client, services[0].ID.Value)
+	_, action, err := routeOp.Ensure(ctx, desired)
+	require.NoError(t, err)
+	assert.Equal(t, EnsureUnchanged, action)
+
+	desired.StripPath = v1.NewOptBool(false)
+	route, action, err := routeOp.Ensure(ctx, desired)
+	require.NoError(t, err)
+	assert.Equal(t, EnsureUpdated, action)
+	assert.False(t, route.StripPath.Value)
+	assert.Equal(t, "/api", route.Path.Value)
+
+	// 名前のないルートは名前で特定できないため、更新しない
+	unnamed, err := routeOp.Create(ctx, &v1.RouteDetail{Path: v1.NewOptString("/one")})
+	require.NoError(t, err)
+	_, action, err = routeOp.Ensure(ctx, &v1.RouteDetail{Path: v1.NewOptString("/two")})
+	var apiErr *Error
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, ErrorKindInvalid, apiErr.Kind())
+	assert.Equal(t, EnsureUnchanged, action)
+	current, err := routeOp.Read(ctx, unnamed.ID.Value)
+	require.NoError(t, err)
+	assert.Equal(t, "/one", current.Path.Value)
+}
@@ -14,11 +14,60 @@
 
 package apigw
 
-import "github.com/sacloud/saclient-go"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"github.com/ogen-go/ogen/validate"
+	"github.com/sacloud/saclient-go"
+)
+
+// ErrorKind エラーの発生箇所の分類
+type ErrorKind int
+
+const (
+	// ErrorKindUnknown 分類なし。NewErrorで生成されたエラーなど
+	ErrorKindUnknown ErrorKind = iota
+	// ErrorKindTransport リクエストの送信やレスポンスの受信・解釈に失敗した
+	ErrorKindTransport
+	// ErrorKindAPI APIがエラーレスポンスを返した
+	ErrorKindAPI
+	// ErrorKindUnexpectedResponse APIが定義されていないレスポンスを返した
+	ErrorKindUnexpectedResponse
+	// ErrorKindRefused クライアントの動作モード(ReadOnly・DryRun)により、リクエストを送信しなかった
+	ErrorKindRefused
+	// ErrorKindInvalid 送信前のリクエストの検証に失敗した
+	ErrorKindInvalid
+)
+
+func (k ErrorKind) String() string {
+	switch k {
+	case ErrorKindTransport:
+		return "transport"
+	case ErrorKindAPI:
+		return "api"
+	case ErrorKindUnexpectedResponse:
+		return "unexpected-response"
+	case ErrorKindRefused:
+		return "refused"
+	case ErrorKindInvalid:
+		return "invalid"
+	}
+	return "unknown"
+}
 
 type Error struct {
 	msg string
 	err error
+
+	op      string
+	code    int
+	message string
+	kind    ErrorKind
 }
 
 func (e *Error) Error() string {
@@ -37,10 +86,156 @@ func (e *Error) Unwrap() error {
 	return e.err
 }
 
+// Op エラーが発生した操作名。"Route.Create"など
+func (e *Error) Op() string {
+	return e.op
+}
+
+// StatusCode APIが返したHTTPステータスコード。レスポンスを受け取っていない場合は0
+func (e *Error) StatusCode() int {
+	return e.code
+}
+
+// Message APIのエラーレスポンス(ErrorSchema)に含まれるメッセージ
+func (e *Error) Message() string {
+	return e.message
+}
+
+// Kind エラーの分類
+func (e *Error) Kind() ErrorKind {
+	return e.kind
+}
+
+// IsTransport 通信レベルのエラーかどうか
+func (e *Error) IsTransport() bool {
+	return e.kind == ErrorKindTransport
+}
+
+// IsAPI APIがエラーレスポンスを返したかどうか
+func (e *Error) IsAPI() bool {
+	return e.kind == ErrorKindAPI || e.kind == ErrorKindUnexpectedResponse
+}
+
 func NewError(msg string, err error) *Error {
 	return &Error{msg: msg, err: err}
 }
 
+// NewAPIError 操作methodのエラーを生成する。
+// codeが0の場合、errがあれば通信エラー、なければ想定外のレスポンスとして扱う。
+// codeが0以外の場合、errはAPIのエラーメッセージとして扱う。
 func NewAPIError(method string, code int, err error) *Error {
-	return &Error{msg: method, err: saclient.NewError(code, "", err)}
+	e := &Error{op: method, code: code}
+
+	switch {
+	case code != 0:
+		e.kind = ErrorKindAPI
+		if err != nil {
+			e.message = err.Error()
+		}
+	case err != nil:
+		e.kind = ErrorKindTransport
+		// ogenは定義されていないステータスコードをエラーとして返す
+		var unexpected *validate.UnexpectedStatusCodeError
+		if errors.As(err, &unexpected) {
+			e.kind = ErrorKindUnexpectedResponse
+			e.code = unexpected.StatusCode
+		}
+		if IsRefused(err) {
+			e.kind = ErrorKindRefused
+		}
+	default:
+		e.kind = ErrorKindUnexpectedResponse
+	}
+
+	e.msg = method
+	e.err = saclient.NewError(e.code, "", err)
+	return e
+}
+
+// NotFoundError 名前やIDに一致するリソースが見つからなかったことを表す。
+// GetByNameやResolveで返される
+type NotFoundError struct {
+	// Resource リソースの種類。"service"など
+	Resource string
+	// Name 検索した名前またはID
+	Name string
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("apigw: %s %q not found", e.Resource, e.Name)
+}
+
+// AmbiguousError 名前に一致するリソースが複数あり、1件に特定できなかったことを表す
+type AmbiguousError struct {
+	// Resource リソースの種類。"service"など
+	Resource string
+	// Name 検索した名前
+	Name string
+	// IDs 名前が一致したリソースのID
+	IDs []uuid.UUID
+}
+
+func (e *AmbiguousError) Error() string {
+	return fmt.Sprintf("apigw: %s %q is ambiguous: %d resources have the name; specify the ID instead", e.Resource, e.Name, len(e.IDs))
+}
+
+// StatusCodeOf errに含まれるHTTPステータスコードを返す。含まれていない場合は0
+func StatusCodeOf(err error) int {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.code
+	}
+	return 0
+}
+
+// IsBadRequest errが400 Bad Requestによるものかどうか
+func IsBadRequest(err error) bool {
+	return StatusCodeOf(err) == http.StatusBadRequest
+}
+
+// IsUnauthorized errが401 Unauthorizedによるものかどうか
+func IsUnauthorized(err error) bool {
+	return StatusCodeOf(err) == http.StatusUnauthorized
+}
+
+// IsNotFound errが404 Not Found、または名前による検索でリソースが見つからなかったことによるものかどうか
+func IsNotFound(err error) bool {
+	var e *NotFoundError
+	return StatusCodeOf(err) == http.StatusNotFound || errors.As(err, &e)
+}
+
+// IsAmbiguous errが名前に一致するリソースが複数あったことによるものかどうか
+func IsAmbiguous(err error) bool {
+	var e *AmbiguousError
+	return errors.As(err, &e)
+}
+
+// IsConflict errが409 Conflictによるものかどうか
+func IsConflict(err error) bool {
+	return StatusCodeOf(err) == http.StatusConflict
+}
+
+// IsTransport errが通信レベルのエラーかどうか
+func IsTransport(err error) bool {
+	var e *Error
+	return errors.As(err, &e) && e.IsTransport()
+}
+
+// IsRetryable errが再試行によって解消する可能性があるかどうか。
+// 通信エラー(contextのキャンセルを除く)、429、5xxの場合にtrueを返す
+func IsRetryable(err error) bool {
+	var e *Error
+	if !errors.As(err, &e) {
+		return false
+	}
+
+	switch {
+	case e.IsTransport():
+		return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	case e.code == http.StatusTooManyRequests:
+		return true
+	case e.code >= http.StatusInternalServerError:
+		return true
+	}
+	return false
 }
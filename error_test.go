@@ -0,0 +1,103 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apigw_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	. "github.com/sacloud/apigw-api-go"
+	"github.com/sacloud/saclient-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAPIError(t *testing.T) {
+	cases := []struct {
+		name      string
+		err       *Error
+		kind      ErrorKind
+		code      int
+		message   string
+		retryable bool
+	}{
+		{"api", NewAPIError("Route.Read", 404, errors.New("not found")), ErrorKindAPI, 404, "not found", false},
+		{"server", NewAPIError("Route.Read", 500, errors.New("boom")), ErrorKindAPI, 500, "boom", true},
+		{"transport", NewAPIError("Route.Read", 0, errors.New("connection refused")), ErrorKindTransport, 0, "", true},
+		{"canceled", NewAPIError("Route.Read", 0, context.Canceled), ErrorKindTransport, 0, "", false},
+		{"unexpected", NewAPIError("Route.Read", 0, nil), ErrorKindUnexpectedResponse, 0, "", false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, "Route.Read", tc.err.Op())
+			assert.Equal(t, tc.kind, tc.err.Kind())
+			assert.Equal(t, tc.code, tc.err.StatusCode())
+			assert.Equal(t, tc.message, tc.err.Message())
+
+			wrapped := fmt.Errorf("reconcile: %w", tc.err)
+			assert.Equal(t, tc.retryable, IsRetryable(wrapped))
+			assert.Equal(t, tc.code, StatusCodeOf(wrapped))
+		})
+	}
+
+	assert.True(t, saclient.IsNotFoundError(NewAPIError("Route.Read", 404, errors.New("not found"))))
+	assert.False(t, IsNotFound(errors.New("plain error")))
+}
+
+func TestAPIError_FromResponse(t *testing.T) {
+	status := http.StatusNotFound
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(`{"message":"group not found"}`))
+	}))
+	defer server.Close()
+
+	var theClient saclient.Client
+	require.NoError(t, theClient.SetWith(saclient.WithoutRetry()))
+	client, err := NewClientWithAPIRootURL(&theClient, server.URL)
+	require.NoError(t, err)
+
+	groupOp := NewGroupOp(client)
+
+	_, err = groupOp.Read(t.Context(), uuid.New())
+	require.Error(t, err)
+	assert.True(t, IsNotFound(err))
+	assert.False(t, IsConflict(err))
+
+	var apiErr *Error
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, "Group.Read", apiErr.Op())
+	assert.Equal(t, "group not found", apiErr.Message())
+	assert.True(t, apiErr.IsAPI())
+
+	// 定義されていないステータスコードはステータスを保持したまま想定外のレスポンスとして扱う
+	status = http.StatusServiceUnavailable
+	_, err = groupOp.Read(t.Context(), uuid.New())
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, ErrorKindUnexpectedResponse, apiErr.Kind())
+	assert.Equal(t, http.StatusServiceUnavailable, apiErr.StatusCode())
+	assert.True(t, IsRetryable(err))
+
+	server.Close()
+	_, err = groupOp.Read(t.Context(), uuid.New())
+	assert.True(t, IsTransport(err))
+}
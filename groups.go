@@ -17,6 +17,7 @@ package apigw
 import (
 	"context"
 	"errors"
+	"iter"
 
 	"github.com/google/uuid"
 	v1 "github.com/sacloud/apigw-api-go/apis/v1"
@@ -24,10 +25,22 @@ import (
 
 type GroupAPI interface {
 	List(ctx context.Context) ([]v1.Group, error)
+	// All Listの結果を1件ずつ返すイテレータ。取得に失敗した場合はエラーを1度だけ返す
+	All(ctx context.Context) iter.Seq2[v1.Group, error]
 	Create(ctx context.Context, request *v1.Group) (*v1.Group, error)
 	Read(ctx context.Context, id uuid.UUID) (*v1.Group, error)
 	Update(ctx context.Context, request *v1.Group, id uuid.UUID) error
 	Delete(ctx context.Context, id uuid.UUID) error
+	// FindByName nameが一致するものをすべて返す
+	FindByName(ctx context.Context, name string) ([]v1.Group, error)
+	// GetByName nameが一致するものを1件返す。見つからない場合は*NotFoundError、複数ある場合は*AmbiguousErrorを返す
+	GetByName(ctx context.Context, name string) (*v1.Group, error)
+	// Resolve IDまたはnameがidOrNameと一致するものを1件返す
+	Resolve(ctx context.Context, idOrName string) (*v1.Group, error)
+	// Ensure 名前がdesiredと一致するものがなければ作成し、あればdesiredで設定した項目に差分がある場合のみ更新する。
+	// 作成・更新した場合は改めて取得した結果を返す。作成・更新後の取得に失敗した場合もEnsureActionは行った操作を示す
+	// desiredの名前が空の場合はErrorKindInvalidのエラーを返す
+	Ensure(ctx context.Context, desired *v1.Group) (*v1.Group, EnsureAction, error)
 }
 
 var _ GroupAPI = (*groupOp)(nil)
@@ -58,7 +71,57 @@ func (op *groupOp) List(ctx context.Context) ([]v1.Group, error) {
 	return nil, NewAPIError("Group.List", 0, nil)
 }
 
+func (op *groupOp) All(ctx context.Context) iter.Seq2[v1.Group, error] {
+	return all(ctx, op.List)
+}
+
+func groupLookup(list func(context.Context) ([]v1.Group, error)) lookup[v1.Group] {
+	return lookup[v1.Group]{
+		resource: "group",
+		list:     list,
+		name:     func(g *v1.Group) string { return string(g.Name.Value) },
+		id:       func(g *v1.Group) uuid.UUID { return g.ID.Value },
+	}
+}
+
+func (op *groupOp) FindByName(ctx context.Context, name string) ([]v1.Group, error) {
+	return groupLookup(op.List).find(ctx, name)
+}
+
+func (op *groupOp) GetByName(ctx context.Context, name string) (*v1.Group, error) {
+	return groupLookup(op.List).get(ctx, name)
+}
+
+func (op *groupOp) Resolve(ctx context.Context, idOrName string) (*v1.Group, error) {
+	return groupLookup(op.List).resolve(ctx, idOrName)
+}
+
+func (op *groupOp) Ensure(ctx context.Context, desired *v1.Group) (*v1.Group, EnsureAction, error) {
+	return ensurer[*v1.Group, *v1.Group, v1.Group, *v1.Group]{
+		method: "Group.Ensure",
+		find: func(ctx context.Context, name string) (uuid.UUID, *v1.Group, error) {
+			found, err := op.GetByName(ctx, name)
+			if err != nil {
+				return uuid.Nil, nil, err
+			}
+			return found.ID.Value, found, nil
+		},
+		create: func(ctx context.Context, desired *v1.Group) (uuid.UUID, error) {
+			created, err := op.Create(ctx, desired)
+			if err != nil {
+				return uuid.Nil, err
+			}
+			return created.ID.Value, nil
+		},
+		read:   op.Read,
+		update: op.Update,
+	}.ensure(ctx, string(desired.Name.Value), desired)
+}
+
 func (op *groupOp) Create(ctx context.Context, request *v1.Group) (*v1.Group, error) {
+	if err := validateRequest("Group.Create", request); err != nil {
+		return nil, err
+	}
 	res, err := op.client.AddGroup(ctx, request)
 	if err != nil {
 		return nil, NewAPIError("Group.Create", 0, err)
@@ -99,6 +162,9 @@ func (op *groupOp) Read(ctx context.Context, id uuid.UUID) (*v1.Group, error) {
 }
 
 func (op *groupOp) Update(ctx context.Context, request *v1.Group, id uuid.UUID) error {
+	if err := validateRequest("Group.Update", request); err != nil {
+		return err
+	}
 	res, err := op.client.UpdateGroup(ctx, request, v1.UpdateGroupParams{GroupId: id})
 	if err != nil {
 		return NewAPIError("Group.Update", 0, err)
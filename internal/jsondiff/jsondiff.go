@@ -0,0 +1,119 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package jsondiff JSONで表現したリソースを比較・マージするための内部パッケージ
+package jsondiff
+
+import (
+	"encoding/json"
+	"slices"
+)
+
+// Merge currentにdesiredを上書きしたJSONを返す。
+// オブジェクトは再帰的にマージし、それ以外の値はdesiredの値で置き換える
+func Merge(current, desired []byte) ([]byte, error) {
+	cur, err := decode(current)
+	if err != nil {
+		return nil, err
+	}
+	des, err := decode(desired)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(merge(cur, des))
+}
+
+func merge(cur, des any) any {
+	desObj, ok := des.(map[string]any)
+	if !ok {
+		return des
+	}
+	curObj, ok := cur.(map[string]any)
+	if !ok {
+		return des
+	}
+	for key, v := range desObj {
+		curObj[key] = merge(curObj[key], v)
+	}
+	return curObj
+}
+
+// Omit JSONオブジェクトから最上位のkeysを取り除く
+func Omit(data []byte, keys ...string) ([]byte, error) {
+	var obj map[string]any
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return nil, err
+	}
+	for _, key := range keys {
+		delete(obj, key)
+	}
+	return json.Marshal(obj)
+}
+
+// ServerManaged サーバ側で設定される最上位の項目。更新時のリクエストに含めず、差分としても扱わない
+var ServerManaged = []string{"id", "createdAt", "updatedAt"}
+
+// OmitServerManaged JSONオブジェクトから最上位のkeysとServerManagedを取り除く
+func OmitServerManaged(data []byte, keys ...string) ([]byte, error) {
+	return Omit(data, slices.Concat(keys, ServerManaged)...)
+}
+
+// Overlay currentにdesiredで設定した項目を上書きした更新用のリクエストをdstに設定する。
+// keysとServerManagedはcurrent・desiredの双方から除き、desiredで設定していない項目は現在の値を維持する。
+// desiredがnilの場合はcurrentから項目を除いたものを設定する
+func Overlay(dst json.Unmarshaler, current, desired json.Marshaler, keys ...string) error {
+	data, err := current.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	if data, err = OmitServerManaged(data, keys...); err != nil {
+		return err
+	}
+	if desired != nil {
+		des, err := desired.MarshalJSON()
+		if err != nil {
+			return err
+		}
+		if des, err = OmitServerManaged(des, keys...); err != nil {
+			return err
+		}
+		if data, err = Merge(data, des); err != nil {
+			return err
+		}
+	}
+	return dst.UnmarshalJSON(data)
+}
+
+// Format 差分の値を表示用の文字列に変換する
+func Format(v any) string {
+	if v == nil {
+		return "(none)"
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "(invalid)"
+	}
+	return string(data)
+}
+
+func decode(data []byte) (any, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
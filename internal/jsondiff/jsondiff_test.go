@@ -0,0 +1,68 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsondiff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMerge(t *testing.T) {
+	merged, err := Merge(
+		[]byte(`{"name":"a","port":80,"cors":{"maxAge":10,"credentials":true},"tags":["a","b"]}`),
+		[]byte(`{"port":443,"cors":{"maxAge":20},"tags":["c"]}`),
+	)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"name":"a","port":443,"cors":{"maxAge":20,"credentials":true},"tags":["c"]}`, string(merged))
+
+	omitted, err := Omit(merged, "port", "cors")
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"name":"a","tags":["c"]}`, string(omitted))
+}
+
+func TestOmitServerManaged(t *testing.T) {
+	data := []byte(`{"id":"x","createdAt":"t","updatedAt":"t","name":"a","host":"h"}`)
+	keys := make([]string, 1, 4)
+	keys[0] = "host"
+	omitted, err := OmitServerManaged(data, keys...)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"name":"a"}`, string(omitted))
+	// 呼び出し元のスライスは変更しない
+	assert.Equal(t, []string{"host", "", "", ""}, keys[:4])
+}
+
+func TestOverlay(t *testing.T) {
+	current := raw(`{"id":"x","name":"a","port":80,"host":"h","cors":{"maxAge":10,"credentials":true}}`)
+	desired := raw(`{"port":443,"host":"other","cors":{"maxAge":20}}`)
+
+	var dst raw
+	require.NoError(t, Overlay(&dst, current, desired, "host"))
+	assert.JSONEq(t, `{"name":"a","port":443,"cors":{"maxAge":20,"credentials":true}}`, string(dst))
+
+	require.NoError(t, Overlay(&dst, current, nil, "host"))
+	assert.JSONEq(t, `{"name":"a","port":80,"cors":{"maxAge":10,"credentials":true}}`, string(dst))
+}
+
+// raw json.Marshaler・json.Unmarshalerとして扱うJSON
+type raw []byte
+
+func (r raw) MarshalJSON() ([]byte, error) { return r, nil }
+
+func (r *raw) UnmarshalJSON(data []byte) error {
+	*r = append((*r)[:0], data...)
+	return nil
+}
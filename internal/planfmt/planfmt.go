@@ -0,0 +1,92 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package planfmt applyとcascadeの計画を同じ形式で表示するための内部パッケージ
+package planfmt
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Action 計画の1つの項目で行う操作
+type Action int
+
+const (
+	// Create リソースを作成する
+	Create Action = iota
+	// Update リソースを更新する
+	Update
+	// Delete リソースを削除する
+	Delete
+	// Detach 削除するリソースへの参照を解除する
+	Detach
+)
+
+func (a Action) String() string {
+	switch a {
+	case Create:
+		return "create"
+	case Update:
+		return "update"
+	case Delete:
+		return "delete"
+	case Detach:
+		return "detach"
+	}
+	return fmt.Sprintf("Action(%d)", int(a))
+}
+
+// Symbol 一覧の行頭に表示する記号
+func (a Action) Symbol() string {
+	switch a {
+	case Create:
+		return "+"
+	case Update, Detach:
+		return "~"
+	case Delete:
+		return "-"
+	}
+	return "?"
+}
+
+// Line 計画の1つの項目。Detailsは項目の下に字下げして表示する
+type Line struct {
+	Action  Action
+	Kind    string
+	Name    string
+	Details []string
+}
+
+// Count itemsの操作ごとの件数を返す
+func Count[T any](items []T, action func(T) Action) map[Action]int {
+	counts := make(map[Action]int)
+	for _, item := range items {
+		counts[action(item)]++
+	}
+	return counts
+}
+
+// Format linesを1行ずつ並べ、空行の後に集計のsummaryを続けた文字列を返す
+func Format(lines []Line, summary string) string {
+	var b strings.Builder
+	for _, l := range lines {
+		fmt.Fprintf(&b, "%s %s %q\n", l.Action.Symbol(), l.Kind, l.Name)
+		for _, d := range l.Details {
+			fmt.Fprintf(&b, "    %s\n", d)
+		}
+	}
+	fmt.Fprintf(&b, "\n%s\n", summary)
+	return b.String()
+}
@@ -0,0 +1,42 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package planfmt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormat(t *testing.T) {
+	lines := []Line{
+		{Action: Create, Kind: "group", Name: "admins"},
+		{Action: Update, Kind: "route", Name: "backend/api", Details: []string{`path: "/api" => "/v2"`}},
+		{Action: Detach, Kind: "domain", Name: "api.example.com", Details: []string{`remove certificate "cert"`}},
+		{Action: Delete, Kind: "certificate", Name: "cert"},
+	}
+	assert.Equal(t, `+ group "admins"
+~ route "backend/api"
+    path: "/api" => "/v2"
+~ domain "api.example.com"
+    remove certificate "cert"
+- certificate "cert"
+
+Total: 4.
+`, Format(lines, "Total: 4."))
+
+	counts := Count(lines, func(l Line) Action { return l.Action })
+	assert.Equal(t, map[Action]int{Create: 1, Update: 1, Delete: 1, Detach: 1}, counts)
+}
@@ -0,0 +1,179 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package wire APIゲートウェイ APIの通信内容(HTTPリクエスト・レスポンス)を扱うための内部パッケージ
+package wire
+
+import (
+	"bytes"
+	"net/http"
+	"reflect"
+	"strings"
+
+	v1 "github.com/sacloud/apigw-api-go/apis/v1"
+)
+
+// Operation ogenが生成した各操作のHTTP上での表現
+type Operation struct {
+	Name v1.OperationName
+	// Func この操作を呼び出すapigwパッケージのメソッド。"Service.Create"など
+	// ルートの認可の無効化(RouteExtra.DisableAuthorization)も同じ操作を使うため、FuncOfで区別する
+	Func   string
+	Method string
+	// APIルートURLからの相対パス。パスパラメータは{serviceId}のように表す
+	Path string
+	// リクエストボディの型。ボディを持たない場合はnil
+	Request reflect.Type
+	// 成功時のレスポンスボディの型。ボディを持たない場合はnil
+	Response reflect.Type
+}
+
+// Mutating 操作がリソースを変更するかどうか
+func (op *Operation) Mutating() bool {
+	return op.Method != http.MethodGet
+}
+
+// FuncOf bodyを送信したapigwパッケージのメソッド
+func (op *Operation) FuncOf(body []byte) string {
+	if op.Name == v1.UpsertRouteAuthorizationOperation && bytes.Contains(body, []byte(`"isACLEnabled":false`)) {
+		return "RouteExtra.DisableAuthorization"
+	}
+	return op.Func
+}
+
+// Params pathに含まれるパスパラメータ。pathはAPIルートURLを含んでいてもよい
+func (op *Operation) Params(path string) map[string]string {
+	pattern := splitPath(op.Path)
+	segments := splitPath(path)
+	if len(segments) < len(pattern) {
+		return nil
+	}
+	segments = segments[len(segments)-len(pattern):]
+	params := map[string]string{}
+	for i, p := range pattern {
+		if strings.HasPrefix(p, "{") && strings.HasSuffix(p, "}") {
+			params[p[1:len(p)-1]] = segments[i]
+		}
+	}
+	return params
+}
+
+func typeOf[T any]() reflect.Type {
+	return reflect.TypeFor[T]()
+}
+
+// Operations APIゲートウェイ APIの全操作
+var Operations = []Operation{
+	{Name: v1.AddServiceOperation, Func: "Service.Create", Method: http.MethodPost, Path: "/services", Request: typeOf[v1.ServiceDetailRequest](), Response: typeOf[v1.AddServiceCreated]()},
+	{Name: v1.GetServicesOperation, Func: "Service.List", Method: http.MethodGet, Path: "/services", Response: typeOf[v1.GetServicesOK]()},
+	{Name: v1.GetServiceByIdOperation, Func: "Service.Read", Method: http.MethodGet, Path: "/services/{serviceId}", Response: typeOf[v1.GetServiceByIdOK]()},
+	{Name: v1.UpdateServiceOperation, Func: "Service.Update", Method: http.MethodPut, Path: "/services/{serviceId}", Request: typeOf[v1.ServiceDetail]()},
+	{Name: v1.DeleteServiceOperation, Func: "Service.Delete", Method: http.MethodDelete, Path: "/services/{serviceId}"},
+	{Name: v1.AddRouteOperation, Func: "Route.Create", Method: http.MethodPost, Path: "/services/{serviceId}/routes", Request: typeOf[v1.RouteDetail](), Response: typeOf[v1.AddRouteCreated]()},
+	{Name: v1.GetServiceRoutesOperation, Func: "Route.List", Method: http.MethodGet, Path: "/services/{serviceId}/routes", Response: typeOf[v1.GetServiceRoutesOK]()},
+	{Name: v1.GetRouteOperation, Func: "Route.Read", Method: http.MethodGet, Path: "/services/{serviceId}/routes/{routeId}", Response: typeOf[v1.GetRouteOK]()},
+	{Name: v1.UpdateRouteOperation, Func: "Route.Update", Method: http.MethodPut, Path: "/services/{serviceId}/routes/{routeId}", Request: typeOf[v1.RouteDetail]()},
+	{Name: v1.DeleteRouteOperation, Func: "Route.Delete", Method: http.MethodDelete, Path: "/services/{serviceId}/routes/{routeId}"},
+	{Name: v1.UpsertRouteAuthorizationOperation, Func: "RouteExtra.EnableAuthorization", Method: http.MethodPut, Path: "/services/{serviceId}/routes/{routeId}/authorization", Request: typeOf[v1.OptRouteAuthorizationDetail]()},
+	{Name: v1.GetRouteAuthorizationOperation, Func: "RouteExtra.ReadAuthorization", Method: http.MethodGet, Path: "/services/{serviceId}/routes/{routeId}/authorization", Response: typeOf[v1.GetRouteAuthorizationOK]()},
+	{Name: v1.UpsertRequestTransformationOperation, Func: "RouteExtra.UpdateRequestTransformation", Method: http.MethodPut, Path: "/services/{serviceId}/routes/{routeId}/request", Request: typeOf[v1.OptRequestTransformation]()},
+	{Name: v1.GetRequestTransformationOperation, Func: "RouteExtra.ReadRequestTransformation", Method: http.MethodGet, Path: "/services/{serviceId}/routes/{routeId}/request", Response: typeOf[v1.GetRequestTransformationOK]()},
+	{Name: v1.UpsertResponseTransformationOperation, Func: "RouteExtra.UpdateResponseTransformation", Method: http.MethodPut, Path: "/services/{serviceId}/routes/{routeId}/response", Request: typeOf[v1.OptResponseTransformation]()},
+	{Name: v1.GetResponseTransformationOperation, Func: "RouteExtra.ReadResponseTransformation", Method: http.MethodGet, Path: "/services/{serviceId}/routes/{routeId}/response", Response: typeOf[v1.GetResponseTransformationOK]()},
+	{Name: v1.AddUserOperation, Func: "User.Create", Method: http.MethodPost, Path: "/users", Request: typeOf[v1.UserDetail](), Response: typeOf[v1.AddUserCreated]()},
+	{Name: v1.GetUsersOperation, Func: "User.List", Method: http.MethodGet, Path: "/users", Response: typeOf[v1.GetUsersOK]()},
+	{Name: v1.GetUserOperation, Func: "User.Read", Method: http.MethodGet, Path: "/users/{userId}", Response: typeOf[v1.GetUserOK]()},
+	{Name: v1.UpdateUserOperation, Func: "User.Update", Method: http.MethodPut, Path: "/users/{userId}", Request: typeOf[v1.UserDetail]()},
+	{Name: v1.DeleteUserOperation, Func: "User.Delete", Method: http.MethodDelete, Path: "/users/{userId}"},
+	{Name: v1.GetUserGroupOperation, Func: "UserExtra.ListGroup", Method: http.MethodGet, Path: "/users/{userId}/groups", Response: typeOf[v1.GetUserGroupOK]()},
+	{Name: v1.UpdateUserGroupOperation, Func: "UserExtra.UpdateGroup", Method: http.MethodPut, Path: "/users/{userId}/groups"},
+	{Name: v1.GetUserAuthenticationOperation, Func: "UserExtra.ReadAuth", Method: http.MethodGet, Path: "/users/{userId}/authentication", Response: typeOf[v1.GetUserAuthenticationOK]()},
+	{Name: v1.UpsertUserAuthenticationOperation, Func: "UserExtra.UpdateAuth", Method: http.MethodPut, Path: "/users/{userId}/authentication", Request: typeOf[v1.OptUserAuthentication]()},
+	{Name: v1.GetGroupsOperation, Func: "Group.List", Method: http.MethodGet, Path: "/groups", Response: typeOf[v1.GetGroupsOK]()},
+	{Name: v1.AddGroupOperation, Func: "Group.Create", Method: http.MethodPost, Path: "/groups", Request: typeOf[v1.Group](), Response: typeOf[v1.AddGroupCreated]()},
+	{Name: v1.GetGroupOperation, Func: "Group.Read", Method: http.MethodGet, Path: "/groups/{groupId}", Response: typeOf[v1.GetGroupOK]()},
+	{Name: v1.UpdateGroupOperation, Func: "Group.Update", Method: http.MethodPut, Path: "/groups/{groupId}", Request: typeOf[v1.Group]()},
+	{Name: v1.DeleteGroupOperation, Func: "Group.Delete", Method: http.MethodDelete, Path: "/groups/{groupId}"},
+	{Name: v1.AddDomainOperation, Func: "Domain.Create", Method: http.MethodPost, Path: "/domains", Request: typeOf[v1.Domain](), Response: typeOf[v1.AddDomainCreated]()},
+	{Name: v1.GetDomainsOperation, Func: "Domain.List", Method: http.MethodGet, Path: "/domains", Response: typeOf[v1.GetDomainsOK]()},
+	{Name: v1.UpdateDomainOperation, Func: "Domain.Update", Method: http.MethodPut, Path: "/domains/{domainId}", Request: typeOf[v1.DomainPUT]()},
+	{Name: v1.DeleteDomainOperation, Func: "Domain.Delete", Method: http.MethodDelete, Path: "/domains/{domainId}"},
+	{Name: v1.AddCertificateOperation, Func: "Certificate.Create", Method: http.MethodPost, Path: "/certificates", Request: typeOf[v1.Certificate](), Response: typeOf[v1.AddCertificateCreated]()},
+	{Name: v1.GetCertificatesOperation, Func: "Certificate.List", Method: http.MethodGet, Path: "/certificates", Response: typeOf[v1.GetCertificatesOK]()},
+	{Name: v1.UpdateCertificateOperation, Func: "Certificate.Update", Method: http.MethodPut, Path: "/certificates/{certificateId}", Request: typeOf[v1.Certificate]()},
+	{Name: v1.DeleteCertificateOperation, Func: "Certificate.Delete", Method: http.MethodDelete, Path: "/certificates/{certificateId}"},
+	{Name: v1.GetPlansOperation, Func: "Subscription.ListPlans", Method: http.MethodGet, Path: "/plans", Response: typeOf[v1.GetPlansOK]()},
+	{Name: v1.SubscribeOperation, Func: "Subscription.Create", Method: http.MethodPost, Path: "/subscriptions", Request: typeOf[v1.SubscriptionCreate]()},
+	{Name: v1.GetSubscriptionsOperation, Func: "Subscription.List", Method: http.MethodGet, Path: "/subscriptions", Response: typeOf[v1.GetSubscriptionsOK]()},
+	{Name: v1.GetSubscriptionByIdOperation, Func: "Subscription.Read", Method: http.MethodGet, Path: "/subscriptions/{subscriptionId}", Response: typeOf[v1.GetSubscriptionByIdOK]()},
+	{Name: v1.UpdateSubscriptionOperation, Func: "Subscription.Update", Method: http.MethodPut, Path: "/subscriptions/{subscriptionId}", Request: typeOf[v1.SubscriptionUpdate]()},
+	{Name: v1.UnsubscribeOperation, Func: "Subscription.Delete", Method: http.MethodDelete, Path: "/subscriptions/{subscriptionId}"},
+	{Name: v1.AddOidcOperation, Func: "Oidc.Create", Method: http.MethodPost, Path: "/oidc", Request: typeOf[v1.Oidc](), Response: typeOf[v1.AddOidcCreated]()},
+	{Name: v1.GetOidcOperation, Func: "Oidc.List", Method: http.MethodGet, Path: "/oidc", Response: typeOf[v1.GetOidcOK]()},
+	{Name: v1.GetOidcByIdOperation, Func: "Oidc.Read", Method: http.MethodGet, Path: "/oidc/{oidcId}", Response: typeOf[v1.GetOidcByIdOK]()},
+	{Name: v1.UpdateOidcOperation, Func: "Oidc.Update", Method: http.MethodPut, Path: "/oidc/{oidcId}", Request: typeOf[v1.Oidc]()},
+	{Name: v1.DeleteOidcOperation, Func: "Oidc.Delete", Method: http.MethodDelete, Path: "/oidc/{oidcId}"},
+}
+
+// Lookup HTTPメソッドとパスから操作を特定する。
+// pathはAPIルートURLを含んでいてもよく、末尾のセグメントで照合する
+func Lookup(method, path string) (*Operation, bool) {
+	segments := splitPath(path)
+
+	var found *Operation
+	var foundLen int
+	for i := range Operations {
+		op := &Operations[i]
+		if op.Method != method {
+			continue
+		}
+		pattern := splitPath(op.Path)
+		if len(pattern) > len(segments) || len(pattern) <= foundLen {
+			continue
+		}
+		if matchSegments(pattern, segments[len(segments)-len(pattern):]) {
+			found, foundLen = op, len(pattern)
+		}
+	}
+	return found, found != nil
+}
+
+// ByName 操作名から操作を取得する
+func ByName(name v1.OperationName) (*Operation, bool) {
+	for i := range Operations {
+		if Operations[i].Name == name {
+			return &Operations[i], true
+		}
+	}
+	return nil, false
+}
+
+func splitPath(path string) []string {
+	return strings.Split(strings.Trim(path, "/"), "/")
+}
+
+func matchSegments(pattern, segments []string) bool {
+	for i, p := range pattern {
+		if strings.HasPrefix(p, "{") && strings.HasSuffix(p, "}") {
+			if segments[i] == "" {
+				return false
+			}
+			continue
+		}
+		if p != segments[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,192 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wire
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// Mask 秘匿情報を置き換える文字列
+const Mask = "********"
+
+// Redact tの型を持つJSONのボディから、`mask:"true"`タグが付いたフィールドの値を置き換える。
+// tがnil、またはボディがJSONとして解釈できない場合はそのまま返す
+func Redact(t reflect.Type, body []byte) []byte {
+	if t == nil || len(body) == 0 {
+		return body
+	}
+
+	var v any
+	if err := json.Unmarshal(body, &v); err != nil {
+		return body
+	}
+	if !redactValue(t, v) {
+		return body
+	}
+
+	ret, err := json.Marshal(v)
+	if err != nil {
+		return body
+	}
+	return ret
+}
+
+// redactValue 型情報に従ってvを走査し、秘匿情報を置き換える。置き換えた場合はtrueを返す
+func redactValue(t reflect.Type, v any) bool {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array:
+		items, ok := v.([]any)
+		if !ok {
+			return false
+		}
+		changed := false
+		for _, item := range items {
+			changed = redactValue(t.Elem(), item) || changed
+		}
+		return changed
+	case reflect.Map:
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return false
+		}
+		changed := false
+		for _, item := range obj {
+			changed = redactValue(t.Elem(), item) || changed
+		}
+		return changed
+	case reflect.Struct:
+		return redactStruct(t, v)
+	}
+	return false
+}
+
+func redactStruct(t reflect.Type, v any) bool {
+	// ogenのOptXxx/OptNilXxxは値をValueに持つ
+	if isOptional(t) {
+		f, _ := t.FieldByName("Value")
+		return redactValue(f.Type, v)
+	}
+
+	obj, ok := v.(map[string]any)
+	if !ok {
+		return false
+	}
+
+	// ogenのoneOf型はTypeフィールドと、JSONタグを持たない各候補の型のフィールドからなる
+	if isSum(t) {
+		changed := false
+		for i := range t.NumField() {
+			f := t.Field(i)
+			if f.Name != "Type" {
+				changed = redactValue(f.Type, obj) || changed
+			}
+		}
+		return changed
+	}
+
+	changed := false
+	for i := range t.NumField() {
+		f := t.Field(i)
+		name := jsonName(f)
+		if name == "" {
+			continue
+		}
+		val, ok := obj[name]
+		if !ok {
+			continue
+		}
+		if f.Tag.Get("mask") == "true" {
+			if s, ok := val.(string); ok && s != "" {
+				obj[name] = Mask
+				changed = true
+			}
+			continue
+		}
+		changed = redactValue(f.Type, val) || changed
+	}
+	return changed
+}
+
+func jsonName(f reflect.StructField) string {
+	tag := f.Tag.Get("json")
+	if tag == "" || tag == "-" {
+		return ""
+	}
+	name, _, _ := strings.Cut(tag, ",")
+	return name
+}
+
+func isOptional(t reflect.Type) bool {
+	if t.NumField() != 2 {
+		return false
+	}
+	value, ok := t.FieldByName("Value")
+	if !ok || value.Tag != "" {
+		return false
+	}
+	set, ok := t.FieldByName("Set")
+	return ok && set.Type.Kind() == reflect.Bool
+}
+
+func isSum(t reflect.Type) bool {
+	f, ok := t.FieldByName("Type")
+	return ok && f.Tag == "" && strings.HasPrefix(t.Name(), strings.TrimSuffix(f.Type.Name(), "Type"))
+}
+
+// Masked JSONのボディからMaskで置き換えられた値の位置を返す。
+// 位置はオブジェクトのキーを"."、配列の要素を"[i]"でつないだ形式となる
+func Masked(body []byte) []string {
+	var v any
+	if err := json.Unmarshal(body, &v); err != nil {
+		return nil
+	}
+	var paths []string
+	findMasked("", v, &paths)
+	return paths
+}
+
+func findMasked(path string, v any, paths *[]string) {
+	switch v := v.(type) {
+	case string:
+		if v == Mask {
+			*paths = append(*paths, path)
+		}
+	case []any:
+		for i, item := range v {
+			findMasked(fmt.Sprintf("%s[%d]", path, i), item, paths)
+		}
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		slices.Sort(keys)
+		for _, key := range keys {
+			p := key
+			if path != "" {
+				p = path + "." + key
+			}
+			findMasked(p, v[key], paths)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wire
+
+import (
+	"net/http"
+	"testing"
+
+	v1 "github.com/sacloud/apigw-api-go/apis/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookup(t *testing.T) {
+	cases := []struct {
+		method, path string
+		want         v1.OperationName
+	}{
+		{http.MethodGet, "/services", v1.GetServicesOperation},
+		{http.MethodPost, "/cloud/api/apigw/1.0/services", v1.AddServiceOperation},
+		{http.MethodGet, "/services/a/routes", v1.GetServiceRoutesOperation},
+		{http.MethodGet, "/services/a/routes/b", v1.GetRouteOperation},
+		{http.MethodPut, "/api/services/a/routes/b/authorization", v1.UpsertRouteAuthorizationOperation},
+		{http.MethodPut, "/users/a/groups", v1.UpdateUserGroupOperation},
+		{http.MethodDelete, "/oidc/a", v1.DeleteOidcOperation},
+	}
+	for _, tc := range cases {
+		op, ok := Lookup(tc.method, tc.path)
+		require.True(t, ok, "%s %s", tc.method, tc.path)
+		assert.Equal(t, tc.want, op.Name)
+	}
+
+	_, ok := Lookup(http.MethodPatch, "/services")
+	assert.False(t, ok)
+	_, ok = Lookup(http.MethodGet, "/unknown")
+	assert.False(t, ok)
+}
+
+func TestOperation(t *testing.T) {
+	op, ok := Lookup(http.MethodPut, "/api/services/a/routes/b/authorization")
+	require.True(t, ok)
+	assert.Equal(t, map[string]string{"serviceId": "a", "routeId": "b"}, op.Params("/api/services/a/routes/b/authorization"))
+	assert.Equal(t, "RouteExtra.EnableAuthorization", op.FuncOf([]byte(`{"isACLEnabled":true,"groups":[]}`)))
+	assert.Equal(t, "RouteExtra.DisableAuthorization", op.FuncOf([]byte(`{"isACLEnabled":false}`)))
+
+	for _, op := range Operations {
+		assert.NotEmpty(t, op.Func, op.Name)
+	}
+}
+
+func TestRedact(t *testing.T) {
+	op, ok := ByName(v1.UpsertUserAuthenticationOperation)
+	require.True(t, ok)
+	got := Redact(op.Request, []byte(`{"basicAuth":{"userName":"user","password":"secret"},"jwt":{"key":"k","secret":"s","algorithm":"HS256"}}`))
+	assert.JSONEq(t, `{"basicAuth":{"userName":"user","password":"********"},"jwt":{"key":"********","secret":"********","algorithm":"HS256"}}`, string(got))
+
+	// 変換設定のkeyは秘匿情報ではないため置き換えない
+	op, ok = ByName(v1.UpsertRequestTransformationOperation)
+	require.True(t, ok)
+	body := []byte(`{"add":{"headers":[{"key":"X-Key","value":"v"}]}}`)
+	assert.Equal(t, body, Redact(op.Request, body))
+
+	op, ok = ByName(v1.GetCertificatesOperation)
+	require.True(t, ok)
+	got = Redact(op.Response, []byte(`{"apigw":{"certificates":[{"name":"c","rsa":{"cert":"CERT","key":"KEY"}}]}}`))
+	assert.JSONEq(t, `{"apigw":{"certificates":[{"name":"c","rsa":{"cert":"********","key":"********"}}]}}`, string(got))
+
+	assert.Equal(t, []byte("not json"), Redact(op.Response, []byte("not json")))
+}
@@ -0,0 +1,200 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package limit APIゲートウェイ APIの呼び出し頻度と同時実行数を制限するapigw.Layer。
+// 参照(GET)と変更(POST/PUT/DELETE)の操作ごとに、トークンバケットによる頻度の制限と同時に送信するリクエスト数の上限を設定できる。
+// 制限は同じクライアントから生成した全ての*Opで共有される。複数のクライアントで共有する場合は、同じLimiterのLayerを渡す。
+//
+//	client, err := apigw.NewClient(&theClient, limit.Layer(limit.Config{
+//		Read:  limit.Limits{Rate: 10, Burst: 20, MaxInFlight: 8},
+//		Write: limit.Limits{Rate: 2, MaxInFlight: 2},
+//	}))
+package limit
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	apigw "github.com/sacloud/apigw-api-go"
+	"github.com/sacloud/apigw-api-go/internal/wire"
+)
+
+// Limits 操作の種類ごとの制限
+type Limits struct {
+	// Rate 1秒あたりのリクエスト数の上限。0の場合は制限しない
+	Rate float64
+	// Burst 一度に送信できるリクエスト数。0の場合は1
+	Burst int
+	// MaxInFlight 同時に送信するリクエスト数の上限。0の場合は制限しない。
+	// レスポンスのボディを閉じるまでを1つのリクエストとして数える
+	MaxInFlight int
+}
+
+// Config 参照と変更の操作それぞれの制限
+type Config struct {
+	// Read 参照(GET)の操作の制限
+	Read Limits
+	// Write 作成・更新・削除(POST/PUT/DELETE)の操作の制限
+	Write Limits
+}
+
+// Limiter 呼び出し頻度と同時実行数を制限する
+type Limiter struct {
+	read  *group
+	write *group
+}
+
+// NewLimiter cfgに従って制限するLimiterを生成する
+func NewLimiter(cfg Config) *Limiter {
+	return &Limiter{read: newGroup(cfg.Read), write: newGroup(cfg.Write)}
+}
+
+// Layer apigw.NewClientに渡すためのLayer。同じLimiterのLayerを渡したクライアントは制限を共有する
+func (l *Limiter) Layer() apigw.Layer {
+	return func(next apigw.Doer) apigw.Doer {
+		return &transport{limiter: l, next: next}
+	}
+}
+
+// Layer cfgに従って制限するLayer。NewLimiter(cfg).Layer()と同じ
+func Layer(cfg Config) apigw.Layer {
+	return NewLimiter(cfg).Layer()
+}
+
+// acquire reqを送信できるまで待ち、送信後に呼び出す関数を返す
+func (l *Limiter) acquire(req *http.Request) (func(), error) {
+	g := l.write
+	if !mutating(req) {
+		g = l.read
+	}
+	return g.acquire(req.Context())
+}
+
+func mutating(req *http.Request) bool {
+	if op, ok := wire.Lookup(req.Method, req.URL.Path); ok {
+		return op.Mutating()
+	}
+	return req.Method != http.MethodGet && req.Method != http.MethodHead
+}
+
+type transport struct {
+	limiter *Limiter
+	next    apigw.Doer
+}
+
+func (t *transport) Do(req *http.Request) (*http.Response, error) {
+	release, err := t.limiter.acquire(req)
+	if err != nil {
+		return nil, err
+	}
+	res, err := t.next.Do(req)
+	if err != nil {
+		release()
+		return nil, err
+	}
+	res.Body = &releaseBody{ReadCloser: res.Body, release: sync.OnceFunc(release)}
+	return res, nil
+}
+
+// releaseBody 閉じたときに同時実行数の枠を解放するボディ
+type releaseBody struct {
+	io.ReadCloser
+	release func()
+}
+
+func (b *releaseBody) Close() error {
+	defer b.release()
+	return b.ReadCloser.Close()
+}
+
+// group 同じ制限を共有する操作の集まり
+type group struct {
+	bucket    *bucket
+	semaphore chan struct{}
+}
+
+func newGroup(l Limits) *group {
+	g := &group{}
+	if l.Rate > 0 {
+		g.bucket = newBucket(l.Rate, max(l.Burst, 1))
+	}
+	if l.MaxInFlight > 0 {
+		g.semaphore = make(chan struct{}, l.MaxInFlight)
+	}
+	return g
+}
+
+func (g *group) acquire(ctx context.Context) (func(), error) {
+	release := func() {}
+	if g.semaphore != nil {
+		select {
+		case g.semaphore <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		release = func() { <-g.semaphore }
+	}
+	if g.bucket != nil {
+		if err := g.bucket.wait(ctx); err != nil {
+			release()
+			return nil, err
+		}
+	}
+	return release, nil
+}
+
+// bucket トークンバケット。トークンを先に予約し、不足分が補充されるまで待つ
+type bucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newBucket(rate float64, burst int) *bucket {
+	return &bucket{rate: rate, burst: float64(burst), tokens: float64(burst), last: time.Now()}
+}
+
+func (b *bucket) wait(ctx context.Context) error {
+	b.mu.Lock()
+	now := time.Now()
+	b.tokens = min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+	b.tokens--
+	var d time.Duration
+	if b.tokens < 0 {
+		d = time.Duration(-b.tokens / b.rate * float64(time.Second))
+	}
+	b.mu.Unlock()
+	if d == 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		// 予約したトークンを返す
+		b.mu.Lock()
+		b.tokens++
+		b.mu.Unlock()
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package limit_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	apigw "github.com/sacloud/apigw-api-go"
+	"github.com/sacloud/apigw-api-go/apigwtest"
+	"github.com/sacloud/apigw-api-go/limit"
+	"github.com/sacloud/saclient-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// slow 一定時間後に応答し、同時に処理したリクエスト数の最大値を記録するDoer
+type slow struct {
+	delay    time.Duration
+	inFlight atomic.Int32
+	peak     atomic.Int32
+}
+
+func (s *slow) Do(req *http.Request) (*http.Response, error) {
+	n := s.inFlight.Add(1)
+	for {
+		p := s.peak.Load()
+		if n <= p || s.peak.CompareAndSwap(p, n) {
+			break
+		}
+	}
+	time.Sleep(s.delay)
+	s.inFlight.Add(-1)
+	return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("{}")), Request: req}, nil
+}
+
+func send(t *testing.T, doer apigw.Doer, method, path string, n int) {
+	t.Helper()
+	var wg sync.WaitGroup
+	for range n {
+		wg.Go(func() {
+			res, err := doer.Do(httptest.NewRequest(method, path, nil))
+			if assert.NoError(t, err) {
+				_ = res.Body.Close()
+			}
+		})
+	}
+	wg.Wait()
+}
+
+func TestLimiter_MaxInFlight(t *testing.T) {
+	next := &slow{delay: 20 * time.Millisecond}
+	doer := limit.Layer(limit.Config{
+		Read:  limit.Limits{MaxInFlight: 2},
+		Write: limit.Limits{MaxInFlight: 1},
+	})(next)
+
+	send(t, doer, http.MethodGet, "/services", 8)
+	assert.Equal(t, int32(2), next.peak.Load())
+
+	next.peak.Store(0)
+	send(t, doer, http.MethodPut, "/services/a3d4e0f2-1c1b-4b7a-9f3e-2f1d4c3b2a10", 4)
+	assert.Equal(t, int32(1), next.peak.Load())
+}
+
+func TestLimiter_Rate(t *testing.T) {
+	doer := limit.Layer(limit.Config{Write: limit.Limits{Rate: 50, Burst: 2}})(&slow{})
+
+	// 読み込みは制限しない
+	start := time.Now()
+	send(t, doer, http.MethodGet, "/users", 20)
+	assert.Less(t, time.Since(start), 100*time.Millisecond)
+
+	// 2件はバースト、残りの5件は20msごと
+	start = time.Now()
+	send(t, doer, http.MethodPost, "/users", 7)
+	assert.GreaterOrEqual(t, time.Since(start), 90*time.Millisecond)
+}
+
+func TestLimiter_Canceled(t *testing.T) {
+	doer := limit.Layer(limit.Config{Read: limit.Limits{Rate: 1}})(&slow{})
+	send(t, doer, http.MethodGet, "/groups", 1)
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+	defer cancel()
+	_, err := doer.Do(httptest.NewRequestWithContext(ctx, http.MethodGet, "/groups", nil))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestLimiter_Shared(t *testing.T) {
+	fake := apigwtest.NewServer()
+	defer fake.Close()
+
+	// 同じLimiterを渡したクライアントとその全ての*Opで制限を共有する
+	limiter := limit.NewLimiter(limit.Config{Read: limit.Limits{Rate: 20, Burst: 1}})
+	var theClient saclient.Client
+	require.NoError(t, theClient.SetEnviron([]string{"SAKURA_RATE_LIMIT=1000"}))
+	c1, err := apigw.NewClientWithAPIRootURL(&theClient, fake.URL, limiter.Layer())
+	require.NoError(t, err)
+	c2, err := apigw.NewClientWithAPIRootURL(&theClient, fake.URL, limiter.Layer())
+	require.NoError(t, err)
+
+	start := time.Now()
+	for range 2 {
+		_, err := apigw.NewUserOp(c1).List(t.Context())
+		require.NoError(t, err)
+		_, err = apigw.NewGroupOp(c2).List(t.Context())
+		require.NoError(t, err)
+	}
+	assert.GreaterOrEqual(t, time.Since(start), 140*time.Millisecond)
+}
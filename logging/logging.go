@@ -0,0 +1,142 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package logging APIゲートウェイ APIとの通信をlog/slogで記録するapigw.Layer。
+// メソッド・パス・ステータスコード・所要時間とJSONのボディを記録する。
+// ボディ中の`mask:"true"`タグが付いたフィールド(パスワードや秘密鍵など)の値は置き換えて記録する。
+//
+//	logger := slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug}))
+//	client, err := apigw.NewClient(&theClient, logging.Layer(logger))
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"reflect"
+	"time"
+
+	apigw "github.com/sacloud/apigw-api-go"
+	"github.com/sacloud/apigw-api-go/internal/wire"
+)
+
+// MaxBodySize 記録するボディの最大バイト数。超えた部分は省略する
+const MaxBodySize = 16 * 1024
+
+// Level 通信を記録するログのレベル
+const Level = slog.LevelDebug
+
+// Layer loggerに通信を記録するLayer。loggerがnilの場合はslog.Default()を使う。
+// loggerでLevelが無効な場合はボディを読み込まずにリクエストを送信する
+func Layer(logger *slog.Logger) apigw.Layer {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return func(next apigw.Doer) apigw.Doer {
+		return &transport{logger: logger, next: next}
+	}
+}
+
+type transport struct {
+	logger *slog.Logger
+	next   apigw.Doer
+}
+
+func (t *transport) Do(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	if !t.logger.Enabled(ctx, Level) {
+		return t.next.Do(req)
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", req.Method),
+		slog.String("path", req.URL.Path),
+	}
+	op, known := wire.Lookup(req.Method, req.URL.Path)
+	reqBody, err := readBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+	if known {
+		attrs = append(attrs,
+			slog.String("operation", op.FuncOf(reqBody)),
+			slog.String("operation_id", string(op.Name)))
+	}
+	var reqType reflect.Type
+	if known {
+		reqType = op.Request
+	}
+	if a, ok := body("request_body", known, reqType, reqBody); ok {
+		attrs = append(attrs, a)
+	}
+
+	start := time.Now()
+	res, err := t.next.Do(req)
+	attrs = append(attrs, slog.Duration("latency", time.Since(start)))
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+		t.logger.LogAttrs(ctx, Level, "apigw request failed", attrs...)
+		return nil, err
+	}
+
+	attrs = append(attrs, slog.Int("status", res.StatusCode))
+	resBody, err := readBody(&res.Body)
+	if err != nil {
+		return nil, err
+	}
+	var resType reflect.Type
+	if known && res.StatusCode < 300 {
+		resType = op.Response
+	}
+	if a, ok := body("response_body", known, resType, resBody); ok {
+		attrs = append(attrs, a)
+	}
+	t.logger.LogAttrs(ctx, Level, "apigw request", attrs...)
+	return res, nil
+}
+
+// body 秘匿情報を置き換えたボディの属性。
+// 操作を特定できない場合は秘匿情報の位置がわからないため、ボディを記録しない
+func body(key string, known bool, t reflect.Type, data []byte) (slog.Attr, bool) {
+	switch {
+	case len(data) == 0:
+		return slog.Attr{}, false
+	case !known:
+		return slog.String(key, "(omitted: unknown operation)"), true
+	}
+	data = wire.Redact(t, data)
+	if len(data) > MaxBodySize {
+		return slog.String(key, string(data[:MaxBodySize])+"...(truncated)"), true
+	}
+	if json.Valid(data) {
+		return slog.Any(key, json.RawMessage(data)), true
+	}
+	return slog.String(key, string(data)), true
+}
+
+// readBody ボディを読み込み、再度読み込めるように戻す
+func readBody(body *io.ReadCloser) ([]byte, error) {
+	if *body == nil || *body == http.NoBody {
+		return nil, nil
+	}
+	data, err := io.ReadAll(*body)
+	_ = (*body).Close()
+	if err != nil {
+		return nil, err
+	}
+	*body = io.NopCloser(bytes.NewReader(data))
+	return data, nil
+}
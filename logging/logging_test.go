@@ -0,0 +1,99 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logging_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	apigw "github.com/sacloud/apigw-api-go"
+	"github.com/sacloud/apigw-api-go/apigwtest"
+	v1 "github.com/sacloud/apigw-api-go/apis/v1"
+	"github.com/sacloud/apigw-api-go/internal/wire"
+	"github.com/sacloud/apigw-api-go/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newClient(t *testing.T, level slog.Level) (*v1.Client, *bytes.Buffer) {
+	t.Helper()
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: level}))
+	_, client := apigwtest.NewClient(t, logging.Layer(logger))
+	return client, &buf
+}
+
+func records(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+	var ret []map[string]any
+	scanner := bufio.NewScanner(buf)
+	for scanner.Scan() {
+		var r map[string]any
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &r))
+		ret = append(ret, r)
+	}
+	return ret
+}
+
+func TestLayer(t *testing.T) {
+	client, buf := newClient(t, slog.LevelDebug)
+	ctx := t.Context()
+
+	user, err := apigw.NewUserOp(client).Create(ctx, &v1.UserDetail{Name: "alice"})
+	require.NoError(t, err)
+	extra := apigw.NewUserExtraOp(client, user.ID.Value)
+	require.NoError(t, extra.UpdateAuth(ctx, v1.UserAuthentication{
+		BasicAuth: v1.NewOptBasicAuth(v1.BasicAuth{UserName: "alice", Password: "p@ssw0rd"}),
+		HmacAuth:  v1.NewOptHmacAuth(v1.HmacAuth{UserName: "alice", Secret: "hmac-secret"}),
+	}))
+	_, err = extra.ReadAuth(ctx)
+	require.NoError(t, err)
+
+	out := buf.String()
+	assert.NotContains(t, out, "p@ssw0rd")
+	assert.NotContains(t, out, "hmac-secret")
+
+	logs := records(t, buf)
+	require.Len(t, logs, 3)
+	create := logs[0]
+	assert.Equal(t, "apigw request", create["msg"])
+	assert.Equal(t, "POST", create["method"])
+	assert.Equal(t, "/users", create["path"])
+	assert.Equal(t, "User.Create", create["operation"])
+	assert.Equal(t, "AddUser", create["operation_id"])
+	assert.EqualValues(t, 201, create["status"])
+	assert.Contains(t, create, "latency")
+	assert.Equal(t, "alice", create["request_body"].(map[string]any)["name"])
+
+	update := logs[1]
+	assert.Equal(t, "UserExtra.UpdateAuth", update["operation"])
+	basic := update["request_body"].(map[string]any)["basicAuth"].(map[string]any)
+	assert.Equal(t, "alice", basic["userName"])
+	assert.Equal(t, wire.Mask, basic["password"])
+
+	read := logs[2]
+	auth := read["response_body"].(map[string]any)["apigw"].(map[string]any)["userAuthentication"].(map[string]any)
+	assert.Equal(t, wire.Mask, auth["hmacAuth"].(map[string]any)["secret"])
+}
+
+func TestLayer_Disabled(t *testing.T) {
+	client, buf := newClient(t, slog.LevelInfo)
+	_, err := apigw.NewUserOp(client).List(t.Context())
+	require.NoError(t, err)
+	assert.Empty(t, buf.String())
+}
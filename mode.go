@@ -0,0 +1,238 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apigw
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+
+	v1 "github.com/sacloud/apigw-api-go/apis/v1"
+	"github.com/sacloud/apigw-api-go/internal/wire"
+)
+
+// ReadOnlyError 読み取り専用のクライアントで変更操作を呼び出したことを表す
+type ReadOnlyError struct {
+	Operation v1.OperationName
+	// Func 呼び出したメソッド。"Service.Create"など
+	Func string
+}
+
+func (e *ReadOnlyError) Error() string {
+	return fmt.Sprintf("apigw: %s is not allowed in read-only mode", e.Func)
+}
+
+// DryRunError ドライランのクライアントで、変更操作のリクエストが検証に失敗したことを表す
+type DryRunError struct {
+	Operation v1.OperationName
+	// Func 呼び出したメソッド。"Service.Create"など
+	Func string
+	Err  error
+}
+
+func (e *DryRunError) Error() string {
+	return fmt.Sprintf("apigw: dry-run %s: invalid request: %v", e.Func, e.Err)
+}
+
+func (e *DryRunError) Unwrap() error {
+	return e.Err
+}
+
+// ReadOnly 変更操作(GET以外)を送信せず、*ReadOnlyErrorで拒否するLayer。
+// 他のLayerより先に(外側に)指定する
+func ReadOnly() Layer {
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			op, ok := wire.Lookup(req.Method, req.URL.Path)
+			if (ok && !op.Mutating()) || (!ok && req.Method == http.MethodGet) {
+				return next.Do(req)
+			}
+
+			e := &ReadOnlyError{Func: req.Method + " " + req.URL.Path}
+			if ok {
+				body, err := readBody(req)
+				if err != nil {
+					return nil, err
+				}
+				e.Operation, e.Func = op.Name, op.FuncOf(body)
+			}
+			return nil, e
+		})
+	}
+}
+
+// DryRunEntry ドライランで送信しなかった変更操作
+type DryRunEntry struct {
+	Operation v1.OperationName
+	// Func 呼び出したメソッド。"Service.Create"など
+	Func   string
+	Method string
+	Path   string
+	// Params パスパラメータ。"serviceId"など
+	Params map[string]string
+	// Body リクエストボディ。秘匿情報もそのまま含む
+	Body json.RawMessage
+}
+
+func (e DryRunEntry) String() string {
+	return fmt.Sprintf("would %s (%s %s)", e.Func, e.Method, e.Path)
+}
+
+// DryRunRecorder ドライランで送信しなかった変更操作を記録する
+type DryRunRecorder struct {
+	// Logger 指定した場合、記録した操作をInfoレベルで出力する。ボディの秘匿情報はマスクする
+	Logger *slog.Logger
+
+	mu      sync.Mutex
+	entries []DryRunEntry
+}
+
+// Entries 記録した操作を呼び出し順に返す
+func (r *DryRunRecorder) Entries() []DryRunEntry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]DryRunEntry(nil), r.entries...)
+}
+
+// Reset 記録した操作を破棄する
+func (r *DryRunRecorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries = nil
+}
+
+// record eを記録する。tはボディの型で、ログ出力時のマスクに使う
+func (r *DryRunRecorder) record(ctx context.Context, t reflect.Type, e DryRunEntry) {
+	r.mu.Lock()
+	r.entries = append(r.entries, e)
+	r.mu.Unlock()
+
+	if r.Logger != nil {
+		r.Logger.InfoContext(ctx, "apigw dry-run", "operation", e.Func, "method", e.Method, "path", e.Path,
+			"body", string(wire.Redact(t, e.Body)))
+	}
+}
+
+// DryRun 変更操作(GET以外)を送信せず、recに記録するLayer。読み取り操作はそのまま送信する。
+// リクエストボディは生成コードのValidateで検証し、失敗した場合は*DryRunErrorを返す。
+// 成功した場合は操作が成功したものとして応答し、作成操作はリクエストの内容をそのまま返す(IDなどサーバ側で設定される項目は空となる)。
+// 他のLayerより先に(外側に)指定する
+func DryRun(rec *DryRunRecorder) Layer {
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			op, ok := wire.Lookup(req.Method, req.URL.Path)
+			if (ok && !op.Mutating()) || (!ok && req.Method == http.MethodGet) {
+				return next.Do(req)
+			}
+
+			body, err := readBody(req)
+			if err != nil {
+				return nil, err
+			}
+			entry := DryRunEntry{Func: req.Method + " " + req.URL.Path, Method: req.Method, Path: req.URL.Path, Body: json.RawMessage(body)}
+			if !ok {
+				// 未知の操作は検証せずに記録し、ボディのない成功として応答する
+				rec.record(req.Context(), nil, entry)
+				return dryRunResponse(req, &wire.Operation{}, body)
+			}
+			entry.Operation, entry.Func, entry.Params = op.Name, op.FuncOf(body), op.Params(req.URL.Path)
+			if err := validateBody(op.Request, body); err != nil {
+				return nil, &DryRunError{Operation: op.Name, Func: entry.Func, Err: err}
+			}
+			rec.record(req.Context(), op.Request, entry)
+			return dryRunResponse(req, op, body)
+		})
+	}
+}
+
+// IsReadOnly errが読み取り専用のクライアントで変更操作を拒否したことによるものかどうか
+func IsReadOnly(err error) bool {
+	var e *ReadOnlyError
+	return errors.As(err, &e)
+}
+
+// IsRefused errがクライアントの動作モード(読み取り専用・ドライラン)により、リクエストを送信せずに拒否したことによるものかどうか
+func IsRefused(err error) bool {
+	var d *DryRunError
+	return IsReadOnly(err) || errors.As(err, &d)
+}
+
+// readBody リクエストボディを読み込み、再度読み込めるように戻す
+func readBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	body, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+// validateBody bodyをtの型として解釈し、生成コードのValidateで検証する
+func validateBody(t reflect.Type, body []byte) error {
+	if t == nil || len(body) == 0 {
+		return nil
+	}
+	v := reflect.New(t).Interface()
+	if u, ok := v.(json.Unmarshaler); ok {
+		if err := u.UnmarshalJSON(body); err != nil {
+			return err
+		}
+	}
+	if v, ok := v.(interface{ Validate() error }); ok {
+		return v.Validate()
+	}
+	return nil
+}
+
+// dryRunResponse 操作が成功した場合のレスポンスを生成する。
+// 作成操作のレスポンス {"apigw": {"service": {...}}} にはリクエストボディを埋め込む
+func dryRunResponse(req *http.Request, op *wire.Operation, body []byte) (*http.Response, error) {
+	res := &http.Response{
+		StatusCode: http.StatusNoContent,
+		Header:     http.Header{},
+		Body:       http.NoBody,
+		Request:    req,
+	}
+	if op.Response == nil {
+		return res, nil
+	}
+
+	apigw, ok := op.Response.FieldByName("Apigw")
+	if !ok || apigw.Type.Kind() != reflect.Struct || apigw.Type.NumField() != 1 {
+		return nil, fmt.Errorf("apigw: dry-run %s: unsupported response type %s", op.Func, op.Response)
+	}
+	key := strings.Split(apigw.Type.Field(0).Tag.Get("json"), ",")[0]
+	data, err := json.Marshal(map[string]map[string]json.RawMessage{"apigw": {key: body}})
+	if err != nil {
+		return nil, err
+	}
+	res.StatusCode = http.StatusCreated
+	res.Header.Set("Content-Type", "application/json")
+	res.Body = io.NopCloser(bytes.NewReader(data))
+	res.ContentLength = int64(len(data))
+	return res, nil
+}
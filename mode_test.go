@@ -0,0 +1,116 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apigw_test
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	. "github.com/sacloud/apigw-api-go"
+	"github.com/sacloud/apigw-api-go/apigwtest"
+	v1 "github.com/sacloud/apigw-api-go/apis/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadOnly(t *testing.T) {
+	_, client := apigwtest.NewClient(t, ReadOnly())
+	ctx := t.Context()
+	groupOp := NewGroupOp(client)
+
+	_, err := groupOp.List(ctx)
+	require.NoError(t, err)
+
+	_, err = groupOp.Create(ctx, &v1.Group{Name: v1.NewOptName("admins")})
+	var readOnly *ReadOnlyError
+	require.ErrorAs(t, err, &readOnly)
+	assert.Equal(t, "Group.Create", readOnly.Func)
+	assert.Equal(t, v1.AddGroupOperation, readOnly.Operation)
+	assert.True(t, IsReadOnly(err))
+	assert.False(t, IsRetryable(err))
+
+	var apiErr *Error
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, ErrorKindRefused, apiErr.Kind())
+
+	err = NewSubscriptionOp(client).Delete(ctx, uuid.New())
+	assert.True(t, IsReadOnly(err))
+	groups, err := groupOp.List(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, groups)
+}
+
+func TestDryRun(t *testing.T) {
+	var logs bytes.Buffer
+	rec := &DryRunRecorder{Logger: slog.New(slog.NewTextHandler(&logs, nil))}
+	fake, client := apigwtest.NewClient(t, DryRun(rec))
+	ctx := t.Context()
+
+	created, err := NewGroupOp(client).Create(ctx, &v1.Group{Name: v1.NewOptName("admins")})
+	require.NoError(t, err)
+	assert.Equal(t, v1.Name("admins"), created.Name.Value)
+	assert.False(t, created.ID.Set)
+
+	require.NoError(t, NewSubscriptionOp(client).Create(ctx, fake.Plans()[0].ID.Value, "sub"))
+	userID := uuid.New()
+	require.NoError(t, NewUserExtraOp(client, userID).UpdateAuth(ctx, v1.UserAuthentication{
+		BasicAuth: v1.NewOptBasicAuth(v1.BasicAuth{UserName: "alice", Password: "secret"}),
+	}))
+
+	// 読み取り操作は送信する
+	groups, err := NewGroupOp(client).List(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, groups)
+	subs, err := NewSubscriptionOp(client).List(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, subs)
+
+	entries := rec.Entries()
+	require.Len(t, entries, 3)
+	assert.Equal(t, "would Group.Create (POST /groups)", entries[0].String())
+	assert.JSONEq(t, `{"name":"admins"}`, string(entries[0].Body))
+	assert.Equal(t, v1.SubscribeOperation, entries[1].Operation)
+	assert.Equal(t, "UserExtra.UpdateAuth", entries[2].Func)
+	assert.Equal(t, map[string]string{"userId": userID.String()}, entries[2].Params)
+	assert.Contains(t, string(entries[2].Body), "secret")
+
+	// ログには秘匿情報を出力しない
+	assert.Equal(t, 3, strings.Count(logs.String(), "apigw dry-run"))
+	assert.NotContains(t, logs.String(), "secret")
+
+	rec.Reset()
+	assert.Empty(t, rec.Entries())
+}
+
+func TestDryRun_Invalid(t *testing.T) {
+	rec := &DryRunRecorder{}
+	doer := DryRun(rec)(DoerFunc(func(req *http.Request) (*http.Response, error) {
+		t.Fatal("must not be sent")
+		return nil, nil
+	}))
+
+	req, err := http.NewRequest(http.MethodPost, "https://example.com/services", strings.NewReader(`{"name":"backend","protocol":"ftp","host":"backend.example.com","subscription":{"id":"`+uuid.NewString()+`"}}`))
+	require.NoError(t, err)
+	_, err = doer.Do(req)
+	var dryRun *DryRunError
+	require.ErrorAs(t, err, &dryRun)
+	assert.Equal(t, "Service.Create", dryRun.Func)
+	assert.True(t, IsRefused(err))
+	assert.Empty(t, rec.Entries())
+}
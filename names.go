@@ -0,0 +1,98 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apigw
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Resolve listで取得した一覧から、IDまたは名前がidOrNameのリソースを返す。
+// 各操作のResolveと同じく、UUIDとして解釈できる値はIDとして優先し、一致するものがなければ名前として扱う。
+// 見つからない場合は*NotFoundError、複数ある場合は*AmbiguousErrorを返す。
+// Resolveを持たないサブスクリプションやプランの検索に使う
+func Resolve[T any](ctx context.Context, resource, idOrName string, list func(context.Context) ([]T, error), name func(*T) string, id func(*T) uuid.UUID) (*T, error) {
+	return lookup[T]{resource: resource, list: list, name: name, id: id}.resolve(ctx, idOrName)
+}
+
+// lookup 一覧から名前やIDでリソースを検索する
+type lookup[T any] struct {
+	resource string
+	list     func(ctx context.Context) ([]T, error)
+	name     func(*T) string
+	id       func(*T) uuid.UUID
+}
+
+// find 名前がnameのリソースをすべて返す。見つからない場合は空のスライスを返す
+func (l lookup[T]) find(ctx context.Context, name string) ([]T, error) {
+	items, err := l.list(ctx)
+	if err != nil {
+		return nil, err
+	}
+	found := []T{}
+	for i := range items {
+		if l.name(&items[i]) == name {
+			found = append(found, items[i])
+		}
+	}
+	return found, nil
+}
+
+// get 名前がnameのリソースを1件返す
+func (l lookup[T]) get(ctx context.Context, name string) (*T, error) {
+	found, err := l.find(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return l.one(found, name)
+}
+
+// resolve IDまたは名前がidOrNameのリソースを返す。
+// UUIDとして解釈できる場合はIDを優先し、一致するものがなければ名前として扱う
+func (l lookup[T]) resolve(ctx context.Context, idOrName string) (*T, error) {
+	items, err := l.list(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if id, err := uuid.Parse(idOrName); err == nil {
+		for i := range items {
+			if l.id(&items[i]) == id {
+				return &items[i], nil
+			}
+		}
+	}
+	var found []T
+	for i := range items {
+		if l.name(&items[i]) == idOrName {
+			found = append(found, items[i])
+		}
+	}
+	return l.one(found, idOrName)
+}
+
+func (l lookup[T]) one(found []T, name string) (*T, error) {
+	switch len(found) {
+	case 0:
+		return nil, &NotFoundError{Resource: l.resource, Name: name}
+	case 1:
+		return &found[0], nil
+	}
+	ids := make([]uuid.UUID, 0, len(found))
+	for i := range found {
+		ids = append(ids, l.id(&found[i]))
+	}
+	return nil, &AmbiguousError{Resource: l.resource, Name: name, IDs: ids}
+}
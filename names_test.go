@@ -0,0 +1,132 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apigw_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	. "github.com/sacloud/apigw-api-go"
+	"github.com/sacloud/apigw-api-go/apigwtest"
+	v1 "github.com/sacloud/apigw-api-go/apis/v1"
+	"github.com/sacloud/saclient-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroupAPI_ByName(t *testing.T) {
+	_, client := apigwtest.NewClient(t)
+
+	ctx := t.Context()
+	groupOp := NewGroupOp(client)
+	admins, err := groupOp.Create(ctx, &v1.Group{Name: v1.NewOptName("admins")})
+	require.NoError(t, err)
+
+	got, err := groupOp.GetByName(ctx, "admins")
+	require.NoError(t, err)
+	assert.Equal(t, admins.ID.Value, got.ID.Value)
+
+	found, err := groupOp.FindByName(ctx, "unknown")
+	require.NoError(t, err)
+	assert.Empty(t, found)
+
+	_, err = groupOp.GetByName(ctx, "unknown")
+	var notFound *NotFoundError
+	require.ErrorAs(t, err, &notFound)
+	assert.Equal(t, "group", notFound.Resource)
+	assert.True(t, IsNotFound(err))
+
+	got, err = groupOp.Resolve(ctx, admins.ID.Value.String())
+	require.NoError(t, err)
+	assert.Equal(t, admins.ID.Value, got.ID.Value)
+}
+
+func TestGroupAPI_AmbiguousName(t *testing.T) {
+	ids := []uuid.UUID{uuid.New(), uuid.New()}
+	groups := []v1.Group{
+		{ID: v1.NewOptUUID(ids[0]), Name: v1.NewOptName("dup")},
+		{ID: v1.NewOptUUID(ids[1]), Name: v1.NewOptName("dup")},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(&v1.GetGroupsOK{Apigw: v1.GetGroupsOKApigw{Groups: groups}})
+	}))
+	defer server.Close()
+
+	var theClient saclient.Client
+	require.NoError(t, theClient.SetWith(saclient.WithoutRetry()))
+	client, err := NewClientWithAPIRootURL(&theClient, server.URL)
+	require.NoError(t, err)
+
+	ctx := t.Context()
+	groupOp := NewGroupOp(client)
+
+	found, err := groupOp.FindByName(ctx, "dup")
+	require.NoError(t, err)
+	assert.Len(t, found, 2)
+
+	_, err = groupOp.GetByName(ctx, "dup")
+	var ambiguous *AmbiguousError
+	require.ErrorAs(t, err, &ambiguous)
+	assert.Equal(t, ids, ambiguous.IDs)
+	assert.True(t, IsAmbiguous(err))
+	assert.False(t, IsNotFound(err))
+
+	// 名前が重複していてもIDでは特定できる
+	got, err := groupOp.Resolve(ctx, ids[1].String())
+	require.NoError(t, err)
+	assert.Equal(t, ids[1], got.ID.Value)
+}
+
+func TestDomainAPI_ByName(t *testing.T) {
+	_, client := apigwtest.NewClient(t)
+
+	ctx := t.Context()
+	domainOp := NewDomainOp(client)
+	created, err := domainOp.Create(ctx, &v1.Domain{DomainName: "api.example.com"})
+	require.NoError(t, err)
+
+	got, err := domainOp.GetByName(ctx, "api.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, created.ID.Value, got.ID.Value)
+
+	_, err = domainOp.Resolve(ctx, "www.example.com")
+	assert.EqualError(t, err, `apigw: domain "www.example.com" not found`)
+}
+
+func TestResolve(t *testing.T) {
+	fake, client := apigwtest.NewClient(t)
+	ctx := t.Context()
+
+	subOp := NewSubscriptionOp(client)
+	name := func(p *v1.Plan) string { return p.Name.Value }
+	id := func(p *v1.Plan) uuid.UUID { return p.ID.Value }
+	plan := fake.Plans()[0]
+	got, err := Resolve(ctx, "plan", plan.Name.Value, subOp.ListPlans, name, id)
+	require.NoError(t, err)
+	assert.Equal(t, plan.ID.Value, got.ID.Value)
+	got, err = Resolve(ctx, "plan", plan.ID.Value.String(), subOp.ListPlans, name, id)
+	require.NoError(t, err)
+	assert.Equal(t, plan.Name.Value, got.Name.Value)
+
+	// 一致しないUUIDは名前として扱い、見つからなければ*NotFoundErrorを返す
+	_, err = Resolve(ctx, "plan", uuid.NewString(), subOp.ListPlans, name, id)
+	var notFound *NotFoundError
+	require.ErrorAs(t, err, &notFound)
+	assert.Equal(t, "plan", notFound.Resource)
+}
@@ -0,0 +1,199 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apigw
+
+import (
+	"context"
+	"errors"
+	"iter"
+
+	"github.com/google/uuid"
+	v1 "github.com/sacloud/apigw-api-go/apis/v1"
+)
+
+type OidcAPI interface {
+	List(ctx context.Context) ([]v1.Oidc, error)
+	// All Listの結果を1件ずつ返すイテレータ。取得に失敗した場合はエラーを1度だけ返す
+	All(ctx context.Context) iter.Seq2[v1.Oidc, error]
+	Create(ctx context.Context, request *v1.Oidc) (*v1.Oidc, error)
+	Read(ctx context.Context, id uuid.UUID) (*v1.OidcDetail, error)
+	Update(ctx context.Context, request *v1.Oidc, id uuid.UUID) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	// FindByName nameが一致するものをすべて返す
+	FindByName(ctx context.Context, name string) ([]v1.Oidc, error)
+	// GetByName nameが一致するものを1件返す。見つからない場合は*NotFoundError、複数ある場合は*AmbiguousErrorを返す
+	GetByName(ctx context.Context, name string) (*v1.Oidc, error)
+	// Resolve IDまたはnameがidOrNameと一致するものを1件返す
+	Resolve(ctx context.Context, idOrName string) (*v1.Oidc, error)
+}
+
+var _ OidcAPI = (*oidcOp)(nil)
+
+type oidcOp struct {
+	client *v1.Client
+}
+
+func NewOidcOp(client *v1.Client) OidcAPI {
+	return &oidcOp{client: client}
+}
+
+func (op *oidcOp) List(ctx context.Context) ([]v1.Oidc, error) {
+	res, err := op.client.GetOidc(ctx)
+	if err != nil {
+		return nil, NewAPIError("Oidc.List", 0, err)
+	}
+
+	switch p := res.(type) {
+	case *v1.GetOidcOK:
+		return p.Apigw.Oidcs, nil
+	case *v1.GetOidcBadRequest:
+		return nil, NewAPIError("Oidc.List", 400, errors.New(p.Message.Value))
+	case *v1.GetOidcUnauthorized:
+		return nil, NewAPIError("Oidc.List", 401, errors.New(p.Message.Value))
+	case *v1.GetOidcNotFound:
+		return nil, NewAPIError("Oidc.List", 404, errors.New(p.Message.Value))
+	case *v1.GetOidcInternalServerError:
+		return nil, NewAPIError("Oidc.List", 500, errors.New(p.Message.Value))
+	}
+
+	return nil, NewAPIError("Oidc.List", 0, nil)
+}
+
+func (op *oidcOp) All(ctx context.Context) iter.Seq2[v1.Oidc, error] {
+	return all(ctx, op.List)
+}
+
+func oidcLookup(list func(context.Context) ([]v1.Oidc, error)) lookup[v1.Oidc] {
+	return lookup[v1.Oidc]{
+		resource: "oidc",
+		list:     list,
+		name:     func(o *v1.Oidc) string { return string(o.Name) },
+		id:       func(o *v1.Oidc) uuid.UUID { return o.ID.Value },
+	}
+}
+
+func (op *oidcOp) FindByName(ctx context.Context, name string) ([]v1.Oidc, error) {
+	return oidcLookup(op.List).find(ctx, name)
+}
+
+func (op *oidcOp) GetByName(ctx context.Context, name string) (*v1.Oidc, error) {
+	return oidcLookup(op.List).get(ctx, name)
+}
+
+func (op *oidcOp) Resolve(ctx context.Context, idOrName string) (*v1.Oidc, error) {
+	return oidcLookup(op.List).resolve(ctx, idOrName)
+}
+
+func (op *oidcOp) Create(ctx context.Context, request *v1.Oidc) (*v1.Oidc, error) {
+	if err := validateRequest("Oidc.Create", request); err != nil {
+		return nil, err
+	}
+	res, err := op.client.AddOidc(ctx, request)
+	if err != nil {
+		return nil, NewAPIError("Oidc.Create", 0, err)
+	}
+
+	switch p := res.(type) {
+	case *v1.AddOidcCreated:
+		return &p.Apigw.Oidc.Value, nil
+	case *v1.AddOidcBadRequest:
+		return nil, NewAPIError("Oidc.Create", 400, errors.New(p.Message.Value))
+	case *v1.AddOidcUnauthorized:
+		return nil, NewAPIError("Oidc.Create", 401, errors.New(p.Message.Value))
+	case *v1.AddOidcNotFound:
+		return nil, NewAPIError("Oidc.Create", 404, errors.New(p.Message.Value))
+	case *v1.AddOidcConflict:
+		return nil, NewAPIError("Oidc.Create", 409, errors.New(p.Message.Value))
+	case *v1.AddOidcUnprocessableEntity:
+		return nil, NewAPIError("Oidc.Create", 422, errors.New(p.Message.Value))
+	case *v1.AddOidcInternalServerError:
+		return nil, NewAPIError("Oidc.Create", 500, errors.New(p.Message.Value))
+	}
+
+	return nil, NewAPIError("Oidc.Create", 0, nil)
+}
+
+func (op *oidcOp) Read(ctx context.Context, id uuid.UUID) (*v1.OidcDetail, error) {
+	res, err := op.client.GetOidcById(ctx, v1.GetOidcByIdParams{OidcId: id})
+	if err != nil {
+		return nil, NewAPIError("Oidc.Read", 0, err)
+	}
+
+	switch p := res.(type) {
+	case *v1.GetOidcByIdOK:
+		return &p.Apigw.Oidc.Value, nil
+	case *v1.GetOidcByIdBadRequest:
+		return nil, NewAPIError("Oidc.Read", 400, errors.New(p.Message.Value))
+	case *v1.GetOidcByIdUnauthorized:
+		return nil, NewAPIError("Oidc.Read", 401, errors.New(p.Message.Value))
+	case *v1.GetOidcByIdNotFound:
+		return nil, NewAPIError("Oidc.Read", 404, errors.New(p.Message.Value))
+	case *v1.GetOidcByIdInternalServerError:
+		return nil, NewAPIError("Oidc.Read", 500, errors.New(p.Message.Value))
+	}
+
+	return nil, NewAPIError("Oidc.Read", 0, nil)
+}
+
+func (op *oidcOp) Update(ctx context.Context, request *v1.Oidc, id uuid.UUID) error {
+	if err := validateRequest("Oidc.Update", request); err != nil {
+		return err
+	}
+	res, err := op.client.UpdateOidc(ctx, request, v1.UpdateOidcParams{OidcId: id})
+	if err != nil {
+		return NewAPIError("Oidc.Update", 0, err)
+	}
+
+	switch p := res.(type) {
+	case *v1.UpdateOidcNoContent:
+		return nil
+	case *v1.UpdateOidcBadRequest:
+		return NewAPIError("Oidc.Update", 400, errors.New(p.Message.Value))
+	case *v1.UpdateOidcUnauthorized:
+		return NewAPIError("Oidc.Update", 401, errors.New(p.Message.Value))
+	case *v1.UpdateOidcNotFound:
+		return NewAPIError("Oidc.Update", 404, errors.New(p.Message.Value))
+	case *v1.UpdateOidcConflict:
+		return NewAPIError("Oidc.Update", 409, errors.New(p.Message.Value))
+	case *v1.UpdateOidcInternalServerError:
+		return NewAPIError("Oidc.Update", 500, errors.New(p.Message.Value))
+	}
+
+	return NewAPIError("Oidc.Update", 0, nil)
+}
+
+func (op *oidcOp) Delete(ctx context.Context, id uuid.UUID) error {
+	res, err := op.client.DeleteOidc(ctx, v1.DeleteOidcParams{OidcId: id})
+	if err != nil {
+		return NewAPIError("Oidc.Delete", 0, err)
+	}
+
+	switch p := res.(type) {
+	case *v1.DeleteOidcNoContent:
+		return nil
+	case *v1.DeleteOidcBadRequest:
+		return NewAPIError("Oidc.Delete", 400, errors.New(p.Message.Value))
+	case *v1.DeleteOidcUnauthorized:
+		return NewAPIError("Oidc.Delete", 401, errors.New(p.Message.Value))
+	case *v1.DeleteOidcNotFound:
+		return NewAPIError("Oidc.Delete", 404, errors.New(p.Message.Value))
+	case *v1.DeleteOidcConflict:
+		return NewAPIError("Oidc.Delete", 409, errors.New(p.Message.Value))
+	case *v1.DeleteOidcInternalServerError:
+		return NewAPIError("Oidc.Delete", 500, errors.New(p.Message.Value))
+	}
+
+	return NewAPIError("Oidc.Delete", 0, nil)
+}
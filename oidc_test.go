@@ -0,0 +1,67 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apigw_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	apigw "github.com/sacloud/apigw-api-go"
+	v1 "github.com/sacloud/apigw-api-go/apis/v1"
+	"github.com/sacloud/packages-go/testutil"
+	"github.com/sacloud/saclient-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOidcAPI(t *testing.T) {
+	testutil.PreCheckEnvsFunc("SAKURA_ACCESS_TOKEN", "SAKURA_ACCESS_TOKEN_SECRET",
+		"SAKURA_TEST_OIDC_ISSUER", "SAKURA_TEST_OIDC_CLIENT_ID", "SAKURA_TEST_OIDC_CLIENT_SECRET")(t)
+
+	var theClient saclient.Client
+	client, err := apigw.NewClient(&theClient)
+	require.Nil(t, err)
+
+	ctx := context.Background()
+	oidcOp := apigw.NewOidcOp(client)
+
+	req := v1.Oidc{
+		Name:                  "test_oidc",
+		AuthenticationMethods: v1.AuthenticationMethods{v1.AuthenticationMethodsItemAuthorizationCodeFlow},
+		Issuer:                os.Getenv("SAKURA_TEST_OIDC_ISSUER"),
+		ClientId:              os.Getenv("SAKURA_TEST_OIDC_CLIENT_ID"),
+		ClientSecret:          os.Getenv("SAKURA_TEST_OIDC_CLIENT_SECRET"),
+		Scopes:                []string{"openid"},
+	}
+	orig, err := oidcOp.Create(ctx, &req)
+	require.Nil(t, err)
+
+	req.Name = "test_oidc_updated"
+	err = oidcOp.Update(ctx, &req, orig.ID.Value)
+	assert.Nil(t, err)
+
+	got, err := oidcOp.Read(ctx, orig.ID.Value)
+	assert.Nil(t, err)
+	assert.Equal(t, orig.ID.Value, got.ID.Value)
+	assert.Equal(t, v1.Name("test_oidc_updated"), got.Name)
+
+	oidcs, err := oidcOp.List(ctx)
+	assert.Nil(t, err)
+	assert.Greater(t, len(oidcs), 0)
+
+	err = oidcOp.Delete(ctx, orig.ID.Value)
+	assert.Nil(t, err)
+}
@@ -0,0 +1,96 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	apigw "github.com/sacloud/apigw-api-go"
+	v1 "github.com/sacloud/apigw-api-go/apis/v1"
+	"github.com/sacloud/apigw-api-go/internal/wire"
+)
+
+// nameKeys 作成操作ごとの、リソースを一意に識別する名前のフィールド
+var nameKeys = map[v1.OperationName]string{
+	v1.AddServiceOperation:     "name",
+	v1.AddRouteOperation:       "name",
+	v1.AddUserOperation:        "name",
+	v1.AddGroupOperation:       "name",
+	v1.AddDomainOperation:      "domainName",
+	v1.AddCertificateOperation: "name",
+	v1.SubscribeOperation:      "name",
+	v1.AddOidcOperation:        "name",
+}
+
+// LookupByName 作成操作と同じパスの一覧を取得し、リクエストと同じ名前のリソースがあれば作成されたと判断する
+func LookupByName(ctx context.Context, next apigw.Doer, req *http.Request, body []byte) (bool, error) {
+	op, ok := wire.Lookup(req.Method, req.URL.Path)
+	if !ok {
+		return false, fmt.Errorf("retry: unknown operation: %s %s", req.Method, req.URL.Path)
+	}
+	key, ok := nameKeys[op.Name]
+	if !ok {
+		return false, fmt.Errorf("retry: %s is not a create operation", op.Name)
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return false, fmt.Errorf("retry: invalid request body of %s: %w", op.Name, err)
+	}
+	name, ok := fields[key].(string)
+	if !ok || name == "" {
+		return false, fmt.Errorf("retry: request body of %s has no %s", op.Name, key)
+	}
+
+	list := req.Clone(ctx)
+	list.Method = http.MethodGet
+	list.Body, list.GetBody, list.ContentLength = nil, nil, 0
+	list.Header.Del("Content-Type")
+	res, err := next.Do(list)
+	if err != nil {
+		return false, fmt.Errorf("retry: unable to list resources to check %s: %w", op.Name, err)
+	}
+	data, err := io.ReadAll(res.Body)
+	_ = res.Body.Close()
+	if err != nil {
+		return false, fmt.Errorf("retry: unable to list resources to check %s: %w", op.Name, err)
+	}
+	if res.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("retry: unable to list resources to check %s: status %d", op.Name, res.StatusCode)
+	}
+
+	// 一覧のレスポンスは {"apigw": {"services": [...]}} の形式
+	var envelope struct {
+		Apigw map[string]json.RawMessage `json:"apigw"`
+	}
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return false, fmt.Errorf("retry: invalid list response to check %s: %w", op.Name, err)
+	}
+	for _, raw := range envelope.Apigw {
+		var items []map[string]any
+		if json.Unmarshal(raw, &items) != nil {
+			continue
+		}
+		for _, item := range items {
+			if item[key] == name {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
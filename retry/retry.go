@@ -0,0 +1,236 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package retry APIゲートウェイ APIの呼び出しを指数バックオフで再試行するapigw.Layer。
+// GET/PUT/DELETEの操作は一時的なエラー(通信エラー、429、5xx)で再試行する。
+// POSTによる作成操作は、失敗した試行でリソースが作成されていないことを確認できた場合のみ再試行する。
+//
+//	var theClient saclient.Client
+//	theClient.SetWith(saclient.WithoutRetry())
+//	client, err := apigw.NewClient(&theClient, retry.Layer(retry.DefaultPolicy()))
+package retry
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+
+	apigw "github.com/sacloud/apigw-api-go"
+	v1 "github.com/sacloud/apigw-api-go/apis/v1"
+	"github.com/sacloud/apigw-api-go/internal/wire"
+)
+
+// Backoff 再試行までの待ち時間の設定
+type Backoff struct {
+	// Initial 1回目の再試行までの待ち時間
+	Initial time.Duration
+	// Max 待ち時間の上限
+	Max time.Duration
+	// Multiplier 再試行ごとに待ち時間に掛ける倍率
+	Multiplier float64
+	// Jitter 待ち時間をランダムに増減させる割合(0〜1)
+	Jitter float64
+}
+
+// DefaultBackoff 既定の待ち時間の設定
+func DefaultBackoff() Backoff {
+	return Backoff{Initial: 500 * time.Millisecond, Max: 30 * time.Second, Multiplier: 2, Jitter: 0.2}
+}
+
+// Delay n回目の再試行までの待ち時間
+func (b Backoff) Delay(n int) time.Duration {
+	d := float64(b.Initial) * math.Pow(b.Multiplier, float64(n-1))
+	if b.Jitter > 0 {
+		d *= 1 + b.Jitter*(2*rand.Float64()-1) //nolint:gosec // 待ち時間の分散に暗号論的な乱数は不要
+	}
+	if b.Max > 0 && d > float64(b.Max) {
+		return b.Max
+	}
+	return time.Duration(d)
+}
+
+// Attempt 1回の試行の結果
+type Attempt struct {
+	// Operation 操作名。特定できない場合は空
+	Operation v1.OperationName
+	// Number 何回目の試行か(1始まり)
+	Number int
+	// StatusCode レスポンスのステータスコード。レスポンスを受け取っていない場合は0
+	StatusCode int
+	// Err 通信エラー
+	Err error
+	// Retry 再試行するかどうか
+	Retry bool
+	// Wait 再試行までの待ち時間
+	Wait time.Duration
+	// Created POSTの操作が失敗したが、リソースは作成されていたため再試行しない
+	Created bool
+	// CheckErr リソースが作成されたかの確認に失敗したエラー。この場合は再試行しない
+	CheckErr error
+}
+
+// CreatedFunc POSTの操作が失敗した後に、その試行でリソースが作成されたかを確認する。
+// reqは失敗したリクエスト、bodyはそのボディ。確認のリクエストはnextで送信する
+type CreatedFunc func(ctx context.Context, next apigw.Doer, req *http.Request, body []byte) (bool, error)
+
+// Policy 再試行の方針
+type Policy struct {
+	// MaxAttempts 最初の試行を含む最大試行回数。0の場合は4
+	MaxAttempts int
+	// Backoff 再試行までの待ち時間。ゼロ値の場合はDefaultBackoff
+	Backoff Backoff
+	// Created POSTの操作を再試行する前の確認。nilの場合はPOSTの操作を再試行しない
+	Created CreatedFunc
+	// OnAttempt 各試行の後に呼び出される
+	OnAttempt func(ctx context.Context, a Attempt)
+}
+
+// DefaultPolicy 既定の方針。POSTの操作は名前による検索で作成されていないことを確認してから再試行する
+func DefaultPolicy() Policy {
+	return Policy{MaxAttempts: 4, Backoff: DefaultBackoff(), Created: LookupByName}
+}
+
+// Transport 再試行するapigw.Doer
+type Transport struct {
+	next   apigw.Doer
+	policy Policy
+}
+
+var _ apigw.Doer = (*Transport)(nil)
+
+// NewTransport nextで送信するリクエストをpolicyに従って再試行するTransportを生成する
+func NewTransport(next apigw.Doer, policy Policy) *Transport {
+	if policy.MaxAttempts <= 0 {
+		policy.MaxAttempts = 4
+	}
+	if policy.Backoff == (Backoff{}) {
+		policy.Backoff = DefaultBackoff()
+	}
+	return &Transport{next: next, policy: policy}
+}
+
+// Layer apigw.NewClientに渡すためのLayer
+func Layer(policy Policy) apigw.Layer {
+	return func(next apigw.Doer) apigw.Doer {
+		return NewTransport(next, policy)
+	}
+}
+
+// Do apigw.Doerの実装
+func (t *Transport) Do(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	var body []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var name v1.OperationName
+	if op, ok := wire.Lookup(req.Method, req.URL.Path); ok {
+		name = op.Name
+	}
+	for n := 1; ; n++ {
+		res, err := t.next.Do(withBody(req, body))
+		a := Attempt{Operation: name, Number: n, Err: err}
+		if res != nil {
+			a.StatusCode = res.StatusCode
+		}
+		if n < t.policy.MaxAttempts && retryable(ctx, res, err) {
+			a.Retry = true
+			if req.Method == http.MethodPost {
+				t.checkCreated(ctx, req, body, &a)
+			}
+		}
+		if a.Retry {
+			a.Wait = t.wait(res, n)
+		}
+		if t.policy.OnAttempt != nil {
+			t.policy.OnAttempt(ctx, a)
+		}
+		if !a.Retry {
+			return res, err
+		}
+
+		if res != nil {
+			_, _ = io.Copy(io.Discard, res.Body)
+			_ = res.Body.Close()
+		}
+		timer := time.NewTimer(a.Wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// checkCreated POSTの操作を再試行してよいかを確認する
+func (t *Transport) checkCreated(ctx context.Context, req *http.Request, body []byte, a *Attempt) {
+	if t.policy.Created == nil {
+		a.Retry = false
+		return
+	}
+	created, err := t.policy.Created(ctx, t.next, req, body)
+	switch {
+	case err != nil:
+		a.Retry, a.CheckErr = false, err
+	case created:
+		a.Retry, a.Created = false, true
+	}
+}
+
+// wait n回目の試行の後の待ち時間。Retry-Afterヘッダがあればそれに従う
+func (t *Transport) wait(res *http.Response, n int) time.Duration {
+	if res != nil {
+		if sec, err := strconv.Atoi(res.Header.Get("Retry-After")); err == nil && sec > 0 {
+			d := time.Duration(sec) * time.Second
+			if t.policy.Backoff.Max > 0 && d > t.policy.Backoff.Max {
+				d = t.policy.Backoff.Max
+			}
+			return d
+		}
+	}
+	return t.policy.Backoff.Delay(n)
+}
+
+// retryable 試行の結果が一時的なエラーかどうか。apigw.IsRetryableと同じ基準で判定する
+func retryable(ctx context.Context, res *http.Response, err error) bool {
+	if err != nil {
+		return ctx.Err() == nil && !apigw.IsRefused(err)
+	}
+	return res.StatusCode == http.StatusTooManyRequests || res.StatusCode >= http.StatusInternalServerError
+}
+
+func withBody(req *http.Request, body []byte) *http.Request {
+	r := req.Clone(req.Context())
+	if body == nil {
+		return r
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	r.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+	r.ContentLength = int64(len(body))
+	return r
+}
@@ -0,0 +1,180 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retry_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	apigw "github.com/sacloud/apigw-api-go"
+	"github.com/sacloud/apigw-api-go/apigwtest"
+	v1 "github.com/sacloud/apigw-api-go/apis/v1"
+	"github.com/sacloud/apigw-api-go/retry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// faults 指定した回数だけ500を返すLayer。forwardの場合はリクエストを処理してから500を返す
+type faults struct {
+	mu      sync.Mutex
+	method  string
+	count   int
+	forward bool
+}
+
+func (f *faults) layer(next apigw.Doer) apigw.Doer {
+	return apigw.DoerFunc(func(req *http.Request) (*http.Response, error) {
+		f.mu.Lock()
+		fail := req.Method == f.method && f.count > 0
+		if fail {
+			f.count--
+		}
+		f.mu.Unlock()
+		if !fail {
+			return next.Do(req)
+		}
+		if f.forward {
+			res, err := next.Do(req)
+			if err != nil {
+				return nil, err
+			}
+			_ = res.Body.Close()
+		}
+		return &http.Response{
+			StatusCode: http.StatusInternalServerError,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(`{"message":"internal server error"}`)),
+			Request:    req,
+		}, nil
+	})
+}
+
+func newClient(t *testing.T, f *faults, attempts *[]retry.Attempt) (*apigwtest.Server, *v1.Client) {
+	t.Helper()
+	policy := retry.DefaultPolicy()
+	policy.MaxAttempts = 3
+	policy.Backoff = retry.Backoff{Initial: time.Millisecond, Max: 10 * time.Millisecond, Multiplier: 2}
+	policy.OnAttempt = func(_ context.Context, a retry.Attempt) {
+		*attempts = append(*attempts, a)
+	}
+	return apigwtest.NewClient(t, retry.Layer(policy), f.layer)
+}
+
+func createService(t *testing.T, fake *apigwtest.Server, client *v1.Client) (*v1.ServiceDetailRequest, error) {
+	t.Helper()
+	ctx := t.Context()
+	require.NoError(t, apigw.NewSubscriptionOp(client).Create(ctx, fake.Plans()[0].ID.Value, "sub"))
+	subs, err := apigw.NewSubscriptionOp(client).List(ctx)
+	require.NoError(t, err)
+	return apigw.NewServiceOp(client).Create(ctx, &v1.ServiceDetailRequest{
+		Name:         "backend",
+		Host:         "backend.example.com",
+		Protocol:     v1.ServiceDetailRequestProtocolHTTPS,
+		Subscription: v1.ServiceSubscriptionRequest{ID: subs[0].ID.Value},
+	})
+}
+
+func TestTransport_Idempotent(t *testing.T) {
+	var attempts []retry.Attempt
+	_, client := newClient(t, &faults{method: http.MethodGet, count: 2}, &attempts)
+
+	_, err := apigw.NewGroupOp(client).List(t.Context())
+	require.NoError(t, err)
+	require.Len(t, attempts, 3)
+	assert.Equal(t, v1.GetGroupsOperation, attempts[0].Operation)
+	assert.Equal(t, http.StatusInternalServerError, attempts[0].StatusCode)
+	assert.True(t, attempts[0].Retry)
+	assert.True(t, attempts[1].Retry)
+	assert.Equal(t, http.StatusOK, attempts[2].StatusCode)
+	assert.False(t, attempts[2].Retry)
+}
+
+func TestTransport_GiveUp(t *testing.T) {
+	var attempts []retry.Attempt
+	_, client := newClient(t, &faults{method: http.MethodGet, count: 10}, &attempts)
+
+	_, err := apigw.NewGroupOp(client).List(t.Context())
+	assert.True(t, apigw.IsRetryable(err))
+	require.Len(t, attempts, 3)
+	assert.False(t, attempts[2].Retry)
+}
+
+func TestTransport_CreateNotCreated(t *testing.T) {
+	var attempts []retry.Attempt
+	f := &faults{method: http.MethodPost}
+	fake, client := newClient(t, f, &attempts)
+
+	f.count = 1 // サブスクリプションの作成で失敗し、作成されていないため再試行する
+	service, err := createService(t, fake, client)
+	require.NoError(t, err)
+	assert.Equal(t, "backend", string(service.Name))
+	require.Len(t, attempts, 4)
+	assert.Equal(t, v1.SubscribeOperation, attempts[0].Operation)
+	assert.True(t, attempts[0].Retry)
+	assert.Equal(t, v1.SubscribeOperation, attempts[1].Operation)
+	assert.Equal(t, http.StatusNoContent, attempts[1].StatusCode)
+
+	subs, err := apigw.NewSubscriptionOp(client).List(t.Context())
+	require.NoError(t, err)
+	assert.Len(t, subs, 1)
+}
+
+func TestTransport_CreateCreated(t *testing.T) {
+	var attempts []retry.Attempt
+	f := &faults{method: http.MethodPost, forward: true}
+	fake, client := newClient(t, f, &attempts)
+
+	require.NoError(t, apigw.NewSubscriptionOp(client).Create(t.Context(), fake.Plans()[0].ID.Value, "sub"))
+	subs, err := apigw.NewSubscriptionOp(client).List(t.Context())
+	require.NoError(t, err)
+
+	// サービスの作成は成功したがエラーが返るため、再試行しない
+	attempts = nil
+	f.count = 1
+	_, err = apigw.NewServiceOp(client).Create(t.Context(), &v1.ServiceDetailRequest{
+		Name:         "backend",
+		Host:         "backend.example.com",
+		Protocol:     v1.ServiceDetailRequestProtocolHTTPS,
+		Subscription: v1.ServiceSubscriptionRequest{ID: subs[0].ID.Value},
+	})
+	assert.Equal(t, http.StatusInternalServerError, apigw.StatusCodeOf(err))
+	require.Len(t, attempts, 1)
+	assert.Equal(t, v1.AddServiceOperation, attempts[0].Operation)
+	assert.False(t, attempts[0].Retry)
+	assert.True(t, attempts[0].Created)
+
+	services, err := apigw.NewServiceOp(client).List(t.Context())
+	require.NoError(t, err)
+	assert.Len(t, services, 1)
+}
+
+func TestBackoff(t *testing.T) {
+	b := retry.Backoff{Initial: 100 * time.Millisecond, Max: time.Second, Multiplier: 2}
+	assert.Equal(t, 100*time.Millisecond, b.Delay(1))
+	assert.Equal(t, 400*time.Millisecond, b.Delay(3))
+	assert.Equal(t, time.Second, b.Delay(10))
+
+	b.Jitter = 0.5
+	for range 100 {
+		d := b.Delay(2)
+		assert.GreaterOrEqual(t, d, 100*time.Millisecond)
+		assert.LessOrEqual(t, d, 300*time.Millisecond)
+	}
+}
@@ -17,6 +17,7 @@ package apigw
 import (
 	"context"
 	"errors"
+	"iter"
 
 	"github.com/google/uuid"
 	v1 "github.com/sacloud/apigw-api-go/apis/v1"
@@ -24,10 +25,26 @@ import (
 
 type RouteAPI interface {
 	List(ctx context.Context) ([]v1.Route, error)
+	// All Listの結果を1件ずつ返すイテレータ。取得に失敗した場合はエラーを1度だけ返す
+	All(ctx context.Context) iter.Seq2[v1.Route, error]
+	// Create ルートを作成する。
+	// Methodsを省略した場合や読み取り系(GET/HEAD/OPTIONS)以外のメソッドを含む場合は、送信前の検証のためにサービスを取得する。
+	// その取得に失敗した場合はルートを作成せずにエラーを返す
 	Create(ctx context.Context, request *v1.RouteDetail) (*v1.RouteDetail, error)
 	Read(ctx context.Context, id uuid.UUID) (*v1.RouteDetail, error)
+	// Update ルートを更新する。Createと同様に、送信前の検証のためにサービスを取得する場合がある
 	Update(ctx context.Context, request *v1.RouteDetail, id uuid.UUID) error
 	Delete(ctx context.Context, id uuid.UUID) error
+	// FindByName nameが一致するものをすべて返す
+	FindByName(ctx context.Context, name string) ([]v1.Route, error)
+	// GetByName nameが一致するものを1件返す。見つからない場合は*NotFoundError、複数ある場合は*AmbiguousErrorを返す
+	GetByName(ctx context.Context, name string) (*v1.Route, error)
+	// Resolve IDまたはnameがidOrNameと一致するものを1件返す
+	Resolve(ctx context.Context, idOrName string) (*v1.Route, error)
+	// Ensure 名前がdesiredと一致するものがなければ作成し、あればdesiredで設定した項目に差分がある場合のみ更新する。
+	// 作成・更新した場合は改めて取得した結果を返す。作成・更新後の取得に失敗した場合もEnsureActionは行った操作を示す
+	// desiredの名前が空の場合はErrorKindInvalidのエラーを返す
+	Ensure(ctx context.Context, desired *v1.RouteDetail) (*v1.RouteDetail, EnsureAction, error)
 }
 
 var _ RouteAPI = (*routeOp)(nil)
@@ -61,7 +78,59 @@ func (op *routeOp) List(ctx context.Context) ([]v1.Route, error) {
 	return nil, NewAPIError("Route.List", 0, nil)
 }
 
+func (op *routeOp) All(ctx context.Context) iter.Seq2[v1.Route, error] {
+	return all(ctx, op.List)
+}
+
+func routeLookup(list func(context.Context) ([]v1.Route, error)) lookup[v1.Route] {
+	return lookup[v1.Route]{
+		resource: "route",
+		list:     list,
+		name:     func(r *v1.Route) string { return string(r.Name.Value) },
+		id:       func(r *v1.Route) uuid.UUID { return r.ID.Value },
+	}
+}
+
+func (op *routeOp) FindByName(ctx context.Context, name string) ([]v1.Route, error) {
+	return routeLookup(op.List).find(ctx, name)
+}
+
+func (op *routeOp) GetByName(ctx context.Context, name string) (*v1.Route, error) {
+	return routeLookup(op.List).get(ctx, name)
+}
+
+func (op *routeOp) Resolve(ctx context.Context, idOrName string) (*v1.Route, error) {
+	return routeLookup(op.List).resolve(ctx, idOrName)
+}
+
+func (op *routeOp) Ensure(ctx context.Context, desired *v1.RouteDetail) (*v1.RouteDetail, EnsureAction, error) {
+	return ensurer[*v1.RouteDetail, *v1.RouteDetail, v1.RouteDetail, *v1.RouteDetail]{
+		method: "Route.Ensure",
+		find: func(ctx context.Context, name string) (uuid.UUID, *v1.RouteDetail, error) {
+			found, err := op.GetByName(ctx, name)
+			if err != nil {
+				return uuid.Nil, nil, err
+			}
+			current, err := op.Read(ctx, found.ID.Value)
+			return found.ID.Value, current, err
+		},
+		create: func(ctx context.Context, desired *v1.RouteDetail) (uuid.UUID, error) {
+			created, err := op.Create(ctx, desired)
+			if err != nil {
+				return uuid.Nil, err
+			}
+			return created.ID.Value, nil
+		},
+		read:   op.Read,
+		update: op.Update,
+		omit:   []string{"serviceId", "host"},
+	}.ensure(ctx, string(desired.Name.Value), desired)
+}
+
 func (op *routeOp) Create(ctx context.Context, request *v1.RouteDetail) (*v1.RouteDetail, error) {
+	if err := op.validate(ctx, "Route.Create", request); err != nil {
+		return nil, err
+	}
 	// ogenが現状arrayに対するdefaultsをサポートしてないので、代わりに実装する
 	if len(request.Methods) == 0 {
 		request.Methods = v1.HTTPMethodGET.AllValues()
@@ -109,6 +178,9 @@ func (op *routeOp) Read(ctx context.Context, id uuid.UUID) (*v1.RouteDetail, err
 }
 
 func (op *routeOp) Update(ctx context.Context, request *v1.RouteDetail, id uuid.UUID) error {
+	if err := op.validate(ctx, "Route.Update", request); err != nil {
+		return err
+	}
 	res, err := op.client.UpdateRoute(ctx, request, v1.UpdateRouteParams{ServiceId: op.serviceId, RouteId: id})
 	if err != nil {
 		return NewAPIError("Route.Update", 0, err)
@@ -152,6 +224,29 @@ func (op *routeOp) Delete(ctx context.Context, id uuid.UUID) error {
 	return NewAPIError("Route.Delete", 0, nil)
 }
 
+// validate ルートのリクエストを検証する。
+// オブジェクトストレージを転送先とするサービスでは読み取り系以外のメソッドを使用できないため、
+// ローカルでの検証に成功し、かつ該当するメソッドを含む(またはメソッドを省略した)場合に限りサービスを取得して確認する
+func (op *routeOp) validate(ctx context.Context, method string, request *v1.RouteDetail) error {
+	err := validateRequest(method, request, func() []FieldError {
+		return checkIpRestriction("ipRestrictionConfig", request.IpRestrictionConfig)
+	})
+	if err != nil || len(checkObjectStorageRoute(request.Methods)) == 0 {
+		return err
+	}
+
+	service, err := NewServiceOp(op.client).Read(ctx, op.serviceId)
+	if err != nil {
+		return err
+	}
+	if !service.ObjectStorageConfig.Set {
+		return nil
+	}
+	return validateRequest(method, request, func() []FieldError {
+		return checkObjectStorageRoute(request.Methods)
+	})
+}
+
 type RouteExtraAPI interface {
 	ReadAuthorization(ctx context.Context) (*v1.RouteAuthorizationDetailResponse, error)
 	DisableAuthorization(ctx context.Context) error
@@ -266,6 +361,9 @@ func (op *routeExtraOp) ReadRequestTransformation(ctx context.Context) (*v1.Requ
 }
 
 func (op *routeExtraOp) UpdateRequestTransformation(ctx context.Context, request *v1.RequestTransformation) error {
+	if err := validateRequest("RouteExtra.UpdateRequestTransformation", request); err != nil {
+		return err
+	}
 	res, err := op.client.UpsertRequestTransformation(ctx, v1.NewOptRequestTransformation(*request), v1.UpsertRequestTransformationParams{
 		ServiceId: op.serviceId, RouteId: op.routeId})
 	if err != nil {
@@ -308,6 +406,9 @@ func (op *routeExtraOp) ReadResponseTransformation(ctx context.Context) (*v1.Res
 }
 
 func (op *routeExtraOp) UpdateResponseTransformation(ctx context.Context, request *v1.ResponseTransformation) error {
+	if err := validateRequest("RouteExtra.UpdateResponseTransformation", request); err != nil {
+		return err
+	}
 	res, err := op.client.UpsertResponseTransformation(ctx, v1.NewOptResponseTransformation(*request), v1.UpsertResponseTransformationParams{
 		ServiceId: op.serviceId, RouteId: op.routeId})
 	if err != nil {
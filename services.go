@@ -17,6 +17,7 @@ package apigw
 import (
 	"context"
 	"errors"
+	"iter"
 
 	"github.com/google/uuid"
 	v1 "github.com/sacloud/apigw-api-go/apis/v1"
@@ -24,10 +25,22 @@ import (
 
 type ServiceAPI interface {
 	List(ctx context.Context) ([]v1.ServiceDetailResponse, error)
+	// All Listの結果を1件ずつ返すイテレータ。取得に失敗した場合はエラーを1度だけ返す
+	All(ctx context.Context) iter.Seq2[v1.ServiceDetailResponse, error]
 	Create(ctx context.Context, request *v1.ServiceDetailRequest) (*v1.ServiceDetailRequest, error)
 	Read(ctx context.Context, id uuid.UUID) (*v1.ServiceDetailResponse, error)
 	Update(ctx context.Context, request *v1.ServiceDetail, id uuid.UUID) error
 	Delete(ctx context.Context, id uuid.UUID) error
+	// FindByName nameが一致するものをすべて返す
+	FindByName(ctx context.Context, name string) ([]v1.ServiceDetailResponse, error)
+	// GetByName nameが一致するものを1件返す。見つからない場合は*NotFoundError、複数ある場合は*AmbiguousErrorを返す
+	GetByName(ctx context.Context, name string) (*v1.ServiceDetailResponse, error)
+	// Resolve IDまたはnameがidOrNameと一致するものを1件返す
+	Resolve(ctx context.Context, idOrName string) (*v1.ServiceDetailResponse, error)
+	// Ensure 名前がdesiredと一致するものがなければ作成し、あればdesiredで設定した項目に差分がある場合のみ更新する。
+	// 作成・更新した場合は改めて取得した結果を返す。作成・更新後の取得に失敗した場合もEnsureActionは行った操作を示す
+	// desiredの名前が空の場合はErrorKindInvalidのエラーを返す
+	Ensure(ctx context.Context, desired *v1.ServiceDetailRequest) (*v1.ServiceDetailResponse, EnsureAction, error)
 }
 
 var _ ServiceAPI = (*serviceOp)(nil)
@@ -60,7 +73,60 @@ func (op *serviceOp) List(ctx context.Context) ([]v1.ServiceDetailResponse, erro
 	return nil, NewAPIError("Service.List", 0, nil)
 }
 
+func (op *serviceOp) All(ctx context.Context) iter.Seq2[v1.ServiceDetailResponse, error] {
+	return all(ctx, op.List)
+}
+
+func serviceLookup(list func(context.Context) ([]v1.ServiceDetailResponse, error)) lookup[v1.ServiceDetailResponse] {
+	return lookup[v1.ServiceDetailResponse]{
+		resource: "service",
+		list:     list,
+		name:     func(s *v1.ServiceDetailResponse) string { return string(s.Name) },
+		id:       func(s *v1.ServiceDetailResponse) uuid.UUID { return s.ID.Value },
+	}
+}
+
+func (op *serviceOp) FindByName(ctx context.Context, name string) ([]v1.ServiceDetailResponse, error) {
+	return serviceLookup(op.List).find(ctx, name)
+}
+
+func (op *serviceOp) GetByName(ctx context.Context, name string) (*v1.ServiceDetailResponse, error) {
+	return serviceLookup(op.List).get(ctx, name)
+}
+
+func (op *serviceOp) Resolve(ctx context.Context, idOrName string) (*v1.ServiceDetailResponse, error) {
+	return serviceLookup(op.List).resolve(ctx, idOrName)
+}
+
+func (op *serviceOp) Ensure(ctx context.Context, desired *v1.ServiceDetailRequest) (*v1.ServiceDetailResponse, EnsureAction, error) {
+	return ensurer[*v1.ServiceDetailRequest, *v1.ServiceDetailResponse, v1.ServiceDetail, *v1.ServiceDetail]{
+		method: "Service.Ensure",
+		find: func(ctx context.Context, name string) (uuid.UUID, *v1.ServiceDetailResponse, error) {
+			found, err := op.GetByName(ctx, name)
+			if err != nil {
+				return uuid.Nil, nil, err
+			}
+			return found.ID.Value, found, nil
+		},
+		create: func(ctx context.Context, desired *v1.ServiceDetailRequest) (uuid.UUID, error) {
+			created, err := op.Create(ctx, desired)
+			if err != nil {
+				return uuid.Nil, err
+			}
+			return created.ID.Value, nil
+		},
+		read:   op.Read,
+		update: op.Update,
+		omit:   []string{"routeHost", "subscription"},
+	}.ensure(ctx, string(desired.Name), desired)
+}
+
 func (op *serviceOp) Create(ctx context.Context, request *v1.ServiceDetailRequest) (*v1.ServiceDetailRequest, error) {
+	if err := validateRequest("Service.Create", request, func() []FieldError {
+		return checkHost("host", request.Host)
+	}); err != nil {
+		return nil, err
+	}
 	res, err := op.client.AddService(ctx, request)
 	if err != nil {
 		return nil, NewAPIError("Service.Create", 0, err)
@@ -103,6 +169,11 @@ func (op *serviceOp) Read(ctx context.Context, id uuid.UUID) (*v1.ServiceDetailR
 }
 
 func (op *serviceOp) Update(ctx context.Context, request *v1.ServiceDetail, id uuid.UUID) error {
+	if err := validateRequest("Service.Update", request, func() []FieldError {
+		return checkHost("host", request.Host)
+	}); err != nil {
+		return err
+	}
 	res, err := op.client.UpdateService(ctx, request, v1.UpdateServiceParams{ServiceId: id})
 	if err != nil {
 		return NewAPIError("Service.Update", 0, err)
@@ -0,0 +1,367 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package snapshot アカウントの設定全体をspec.Documentとして書き出し、別のアカウントや環境に復元する。
+// リソース間の参照は名前で表現されるため、バックアップやレビュー、別の環境への複製に利用できる。
+package snapshot
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"reflect"
+	"slices"
+
+	"github.com/google/uuid"
+	apigw "github.com/sacloud/apigw-api-go"
+	v1 "github.com/sacloud/apigw-api-go/apis/v1"
+	"github.com/sacloud/apigw-api-go/internal/wire"
+	"github.com/sacloud/apigw-api-go/spec"
+)
+
+// Exporter アカウントの設定を書き出す
+type Exporter struct {
+	client *v1.Client
+
+	// IncludeSecrets trueの場合、パスワードや秘密鍵などの秘匿情報をそのまま書き出す。
+	// falseの場合はwire.Maskで置き換える
+	IncludeSecrets bool
+}
+
+// NewExporter Exporterを生成する
+func NewExporter(client *v1.Client) *Exporter {
+	return &Exporter{client: client}
+}
+
+// Export アカウントの全てのリソースを読み込み、ドキュメントとして返す。
+// 各リソースは名前順に並べる
+func (ex *Exporter) Export(ctx context.Context) (*spec.Document, error) {
+	doc := &spec.Document{Version: spec.Version}
+	steps := []func(ctx context.Context, doc *spec.Document) error{
+		ex.subscriptions,
+		ex.oidc,
+		ex.certificates,
+		ex.domains,
+		ex.groups,
+		ex.users,
+		ex.services,
+	}
+	for _, step := range steps {
+		if err := step(ctx, doc); err != nil {
+			return nil, err
+		}
+	}
+
+	if !ex.IncludeSecrets {
+		return redact(doc)
+	}
+	return doc, nil
+}
+
+// redact `mask:"true"`タグが付いた項目を置き換えたドキュメントを返す
+func redact(doc *spec.Document) (*spec.Document, error) {
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	var ret spec.Document
+	if err := json.Unmarshal(wire.Redact(reflect.TypeOf(doc), data), &ret); err != nil {
+		return nil, err
+	}
+	return &ret, nil
+}
+
+func (ex *Exporter) subscriptions(ctx context.Context, doc *spec.Document) error {
+	op := apigw.NewSubscriptionOp(ex.client)
+	plans, err := op.ListPlans(ctx)
+	if err != nil {
+		return err
+	}
+	planNames := make(map[uuid.UUID]string, len(plans))
+	for _, p := range plans {
+		planNames[p.ID.Value] = p.Name.Value
+	}
+
+	subscriptions, err := op.List(ctx)
+	if err != nil {
+		return err
+	}
+	for _, sub := range subscriptions {
+		doc.Subscriptions = append(doc.Subscriptions, spec.Subscription{
+			Name: string(sub.Name.Value),
+			Plan: planNames[sub.PlanId.Value],
+		})
+	}
+	sortByName(doc.Subscriptions, func(s spec.Subscription) string { return s.Name })
+	return nil
+}
+
+func (ex *Exporter) oidc(ctx context.Context, doc *spec.Document) error {
+	oidcs, err := apigw.NewOidcOp(ex.client).List(ctx)
+	if err != nil {
+		return err
+	}
+	for _, o := range oidcs {
+		doc.Oidc = append(doc.Oidc, spec.Oidc{
+			Name:                  string(o.Name),
+			AuthenticationMethods: o.AuthenticationMethods,
+			Issuer:                o.Issuer,
+			ClientID:              o.ClientId,
+			ClientSecret:          o.ClientSecret,
+			Scopes:                o.Scopes,
+			HideCredentials:       ptrBool(o.HideCredentials),
+			TokenAudiences:        o.TokenAudiences,
+			UseSession:            ptrBool(o.UseSession),
+		})
+	}
+	sortByName(doc.Oidc, func(o spec.Oidc) string { return o.Name })
+	return nil
+}
+
+func (ex *Exporter) certificates(ctx context.Context, doc *spec.Document) error {
+	certificates, err := apigw.NewCertificateOp(ex.client).List(ctx)
+	if err != nil {
+		return err
+	}
+	for _, c := range certificates {
+		doc.Certificates = append(doc.Certificates, spec.Certificate{
+			Name:  string(c.Name.Value),
+			RSA:   keyPair(c.Rsa),
+			ECDSA: keyPair(c.Ecdsa),
+		})
+	}
+	sortByName(doc.Certificates, func(c spec.Certificate) string { return c.Name })
+	return nil
+}
+
+func (ex *Exporter) domains(ctx context.Context, doc *spec.Document) error {
+	certificates, err := apigw.NewCertificateOp(ex.client).List(ctx)
+	if err != nil {
+		return err
+	}
+	domains, err := apigw.NewDomainOp(ex.client).List(ctx)
+	if err != nil {
+		return err
+	}
+	for _, d := range domains {
+		domain := spec.Domain{Name: d.DomainName}
+		if d.CertificateId.Set {
+			if i := slices.IndexFunc(certificates, func(c v1.Certificate) bool { return c.ID == d.CertificateId }); i >= 0 {
+				domain.Certificate = string(certificates[i].Name.Value)
+			} else {
+				domain.Certificate = d.CertificateName.Value
+			}
+		}
+		doc.Domains = append(doc.Domains, domain)
+	}
+	sortByName(doc.Domains, func(d spec.Domain) string { return d.Name })
+	return nil
+}
+
+func (ex *Exporter) groups(ctx context.Context, doc *spec.Document) error {
+	groups, err := apigw.NewGroupOp(ex.client).List(ctx)
+	if err != nil {
+		return err
+	}
+	for _, g := range groups {
+		doc.Groups = append(doc.Groups, spec.Group{Name: string(g.Name.Value), Tags: g.Tags})
+	}
+	sortByName(doc.Groups, func(g spec.Group) string { return g.Name })
+	return nil
+}
+
+func (ex *Exporter) users(ctx context.Context, doc *spec.Document) error {
+	userOp := apigw.NewUserOp(ex.client)
+	users, err := userOp.List(ctx)
+	if err != nil {
+		return err
+	}
+	for _, u := range users {
+		detail, err := userOp.Read(ctx, u.ID.Value)
+		if err != nil {
+			return err
+		}
+		user := spec.User{
+			Name:     string(detail.Name),
+			CustomID: detail.CustomID.Value,
+			Tags:     detail.Tags,
+		}
+		if detail.IpRestrictionConfig.Set {
+			user.IpRestrictionConfig = &detail.IpRestrictionConfig.Value
+		}
+
+		extraOp := apigw.NewUserExtraOp(ex.client, u.ID.Value)
+		groups, err := extraOp.ListGroup(ctx)
+		if err != nil {
+			return err
+		}
+		for _, g := range groups {
+			if g.IsAssigned {
+				user.Groups = append(user.Groups, string(g.Name))
+			}
+		}
+		slices.Sort(user.Groups)
+
+		auth, err := extraOp.ReadAuth(ctx)
+		if err != nil && !apigw.IsNotFound(err) {
+			return err
+		}
+		if auth != nil && (auth.BasicAuth.Set || auth.Jwt.Set || auth.HmacAuth.Set) {
+			user.Authentication = auth
+		}
+		doc.Users = append(doc.Users, user)
+	}
+	sortByName(doc.Users, func(u spec.User) string { return u.Name })
+	return nil
+}
+
+func (ex *Exporter) services(ctx context.Context, doc *spec.Document) error {
+	services, err := apigw.NewServiceOp(ex.client).List(ctx)
+	if err != nil {
+		return err
+	}
+	for _, s := range services {
+		service := spec.Service{
+			Name:           string(s.Name),
+			Tags:           s.Tags,
+			Subscription:   s.Subscription.Name,
+			Protocol:       string(s.Protocol),
+			Host:           s.Host,
+			Path:           s.Path.Value,
+			Port:           ptrInt(s.Port),
+			Retries:        ptrInt(s.Retries),
+			ConnectTimeout: ptrInt(s.ConnectTimeout),
+			WriteTimeout:   ptrInt(s.WriteTimeout),
+			ReadTimeout:    ptrInt(s.ReadTimeout),
+			Authentication: string(s.Authentication.Value),
+			Oidc:           s.Oidc.Value.Name.Value,
+		}
+		if s.CorsConfig.Set {
+			service.CorsConfig = &s.CorsConfig.Value
+		}
+		if s.ObjectStorageConfig.Set {
+			service.ObjectStorageConfig = &s.ObjectStorageConfig.Value
+		}
+		if service.Routes, err = ex.routes(ctx, s.ID.Value); err != nil {
+			return err
+		}
+		doc.Services = append(doc.Services, service)
+	}
+	sortByName(doc.Services, func(s spec.Service) string { return s.Name })
+	return nil
+}
+
+func (ex *Exporter) routes(ctx context.Context, serviceID uuid.UUID) ([]spec.Route, error) {
+	routeOp := apigw.NewRouteOp(ex.client, serviceID)
+	routes, err := routeOp.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := []spec.Route{}
+	for _, r := range routes {
+		detail, err := routeOp.Read(ctx, r.ID.Value)
+		if err != nil {
+			return nil, err
+		}
+		route := spec.Route{
+			// 名前のないルートはIDを名前とする
+			Name:              cmp.Or(string(detail.Name.Value), detail.ID.Value.String()),
+			Tags:              detail.Tags,
+			Protocols:         string(detail.Protocols.Value),
+			Path:              detail.Path.Value,
+			Hosts:             detail.Hosts,
+			Methods:           detail.Methods,
+			RegexPriority:     ptrInt(detail.RegexPriority),
+			StripPath:         ptrBool(detail.StripPath),
+			PreserveHost:      ptrBool(detail.PreserveHost),
+			RequestBuffering:  ptrBool(detail.RequestBuffering),
+			ResponseBuffering: ptrBool(detail.ResponseBuffering),
+		}
+		if detail.HttpsRedirectStatusCode.Set {
+			code := int(detail.HttpsRedirectStatusCode.Value)
+			route.HttpsRedirectStatusCode = &code
+		}
+		if detail.IpRestrictionConfig.Set {
+			route.IpRestrictionConfig = &detail.IpRestrictionConfig.Value
+		}
+
+		extraOp := apigw.NewRouteExtraOp(ex.client, serviceID, r.ID.Value)
+		// 認可設定のないルートは404を返し、認可設定を省略して書き出す
+		authz, err := extraOp.ReadAuthorization(ctx)
+		if err != nil && !apigw.IsNotFound(err) {
+			return nil, err
+		}
+		if authz != nil && authz.IsACLEnabled {
+			route.Authorization = &spec.RouteAuthorization{Groups: []spec.RouteAuthorizationGroup{}}
+			for _, g := range authz.Groups {
+				group := spec.RouteAuthorizationGroup{Name: string(g.Name.Value)}
+				if g.Enabled.Set && !g.Enabled.Value {
+					group.Enabled = &g.Enabled.Value
+				}
+				route.Authorization.Groups = append(route.Authorization.Groups, group)
+			}
+		}
+
+		reqTrans, err := extraOp.ReadRequestTransformation(ctx)
+		if err != nil && !apigw.IsNotFound(err) {
+			return nil, err
+		}
+		if reqTrans != nil && !isEmpty(reqTrans) {
+			route.RequestTransformation = reqTrans
+		}
+		resTrans, err := extraOp.ReadResponseTransformation(ctx)
+		if err != nil && !apigw.IsNotFound(err) {
+			return nil, err
+		}
+		if resTrans != nil && !isEmpty(resTrans) {
+			route.ResponseTransformation = resTrans
+		}
+
+		ret = append(ret, route)
+	}
+	sortByName(ret, func(r spec.Route) string { return r.Name })
+	return ret, nil
+}
+
+func keyPair(details v1.OptCertificateDetails) *spec.KeyPair {
+	if !details.Set {
+		return nil
+	}
+	return &spec.KeyPair{Cert: details.Value.Cert.Value, Key: details.Value.Key.Value}
+}
+
+func ptrInt(v v1.OptInt) *int {
+	if !v.Set {
+		return nil
+	}
+	return &v.Value
+}
+
+func ptrBool(v v1.OptBool) *bool {
+	if !v.Set {
+		return nil
+	}
+	return &v.Value
+}
+
+// isEmpty 設定が1つも含まれていない場合にtrueを返す
+func isEmpty(v json.Marshaler) bool {
+	data, err := v.MarshalJSON()
+	return err == nil && string(data) == "{}"
+}
+
+func sortByName[T any](items []T, name func(T) string) {
+	slices.SortStableFunc(items, func(a, b T) int { return cmp.Compare(name(a), name(b)) })
+}
@@ -0,0 +1,189 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snapshot_test
+
+import (
+	"os"
+	"testing"
+
+	apigw "github.com/sacloud/apigw-api-go"
+	"github.com/sacloud/apigw-api-go/apigwtest"
+	v1 "github.com/sacloud/apigw-api-go/apis/v1"
+	"github.com/sacloud/apigw-api-go/apply"
+	"github.com/sacloud/apigw-api-go/internal/wire"
+	"github.com/sacloud/apigw-api-go/snapshot"
+	"github.com/sacloud/apigw-api-go/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// seed アカウントにテスト用のリソースを作成する
+func seed(t *testing.T, fake *apigwtest.Server, client *v1.Client) {
+	t.Helper()
+	ctx := t.Context()
+
+	require.NoError(t, apigw.NewSubscriptionOp(client).Create(ctx, fake.Plans()[0].ID.Value, "test-sub"))
+	_, err := apigw.NewOidcOp(client).Create(ctx, &v1.Oidc{
+		Name:                  "test_oidc",
+		AuthenticationMethods: v1.AuthenticationMethods{v1.AuthenticationMethodsItemAccessToken},
+		Issuer:                "https://idp.example.com",
+		ClientId:              "client",
+		ClientSecret:          "client-secret",
+		Scopes:                []string{"openid"},
+	})
+	require.NoError(t, err)
+
+	crt, err := os.ReadFile("../testdata/rsa.crt")
+	require.NoError(t, err)
+	key, err := os.ReadFile("../testdata/rsa.key")
+	require.NoError(t, err)
+
+	port := 8443
+	doc := &spec.Document{
+		Version: spec.Version,
+		Certificates: []spec.Certificate{{
+			Name: "test-cert",
+			RSA:  &spec.KeyPair{Cert: string(crt), Key: string(key)},
+		}},
+		Domains: []spec.Domain{{Name: "api.example.com", Certificate: "test-cert"}},
+		Groups:  []spec.Group{{Name: "admins"}, {Name: "developers"}},
+		Users: []spec.User{{
+			Name:   "alice",
+			Groups: []string{"developers", "admins"},
+			Authentication: &v1.UserAuthentication{
+				BasicAuth: v1.NewOptBasicAuth(v1.BasicAuth{UserName: "alice", Password: "password"}),
+			},
+		}},
+		Services: []spec.Service{{
+			Name:         "backend",
+			Subscription: "test-sub",
+			Protocol:     "https",
+			Host:         "backend.example.com",
+			Port:         &port,
+			Oidc:         "test_oidc",
+			Routes: []spec.Route{{
+				Name:          "api",
+				Path:          "/api",
+				Authorization: &spec.RouteAuthorization{Groups: []spec.RouteAuthorizationGroup{{Name: "admins"}}},
+				RequestTransformation: &v1.RequestTransformation{
+					HttpMethod: v1.NewOptHTTPMethod(v1.HTTPMethodPOST),
+				},
+			}, {
+				Name: "health",
+				Path: "/health",
+			}},
+		}},
+	}
+	planner := apply.NewPlanner(client)
+	plan, err := planner.Plan(ctx, doc)
+	require.NoError(t, err)
+	require.NoError(t, planner.Apply(ctx, plan))
+}
+
+func TestExporter(t *testing.T) {
+	fake, client := apigwtest.NewClient(t)
+	seed(t, fake, client)
+
+	doc, err := snapshot.NewExporter(client).Export(t.Context())
+	require.NoError(t, err)
+
+	require.Len(t, doc.Subscriptions, 1)
+	assert.Equal(t, spec.Subscription{Name: "test-sub", Plan: fake.Plans()[0].Name.Value}, doc.Subscriptions[0])
+	require.Len(t, doc.Oidc, 1)
+	assert.Equal(t, wire.Mask, doc.Oidc[0].ClientSecret)
+	require.Len(t, doc.Certificates, 1)
+	assert.Equal(t, wire.Mask, doc.Certificates[0].RSA.Key)
+	assert.Equal(t, []spec.Domain{{Name: "api.example.com", Certificate: "test-cert"}}, doc.Domains)
+	require.Len(t, doc.Users, 1)
+	assert.Equal(t, []string{"admins", "developers"}, doc.Users[0].Groups)
+	assert.Equal(t, wire.Mask, doc.Users[0].Authentication.BasicAuth.Value.Password)
+
+	require.Len(t, doc.Services, 1)
+	service := doc.Services[0]
+	assert.Equal(t, "test-sub", service.Subscription)
+	assert.Equal(t, "test_oidc", service.Oidc)
+	require.Len(t, service.Routes, 2)
+	assert.Equal(t, []spec.RouteAuthorizationGroup{{Name: "admins"}}, service.Routes[0].Authorization.Groups)
+	assert.Equal(t, v1.HTTPMethodPOST, service.Routes[0].RequestTransformation.HttpMethod.Value)
+	assert.Nil(t, service.Routes[0].ResponseTransformation)
+	// 認可設定のないルートは認可設定を省略する
+	assert.Equal(t, "health", service.Routes[1].Name)
+	assert.Nil(t, service.Routes[1].Authorization)
+
+	// 書き出したドキュメントはそのまま読み込める
+	for _, format := range []spec.Format{spec.FormatYAML, spec.FormatJSON} {
+		data, err := spec.Marshal(doc, format)
+		require.NoError(t, err)
+		assert.NotContains(t, string(data), "client-secret")
+		parsed, err := spec.Parse(data)
+		require.NoError(t, err)
+		assert.Equal(t, doc, parsed)
+	}
+}
+
+func TestExporter_NoRoutes(t *testing.T) {
+	fake, client := apigwtest.NewClient(t)
+	seed(t, fake, client)
+	ctx := t.Context()
+
+	service, err := apigw.NewServiceOp(client).Resolve(ctx, "backend")
+	require.NoError(t, err)
+	routeOp := apigw.NewRouteOp(client, service.ID.Value)
+	routes, err := routeOp.List(ctx)
+	require.NoError(t, err)
+	for _, r := range routes {
+		require.NoError(t, routeOp.Delete(ctx, r.ID.Value))
+	}
+
+	exporter := snapshot.NewExporter(client)
+	exporter.IncludeSecrets = true
+	doc, err := exporter.Export(ctx)
+	require.NoError(t, err)
+
+	// ルートがないことを書き出し、読み込んだ後も空のリストとして扱う
+	for _, format := range []spec.Format{spec.FormatYAML, spec.FormatJSON} {
+		data, err := spec.Marshal(doc, format)
+		require.NoError(t, err)
+		parsed, err := spec.Parse(data)
+		require.NoError(t, err)
+		require.NotNil(t, parsed.Services[0].Routes, string(data))
+		assert.Empty(t, parsed.Services[0].Routes)
+		doc = parsed
+	}
+
+	// 後から追加されたルートは削除される
+	_, err = routeOp.Create(ctx, &v1.RouteDetail{Name: v1.NewOptName("stray"), Path: v1.NewOptString("/stray")})
+	require.NoError(t, err)
+	plan, err := apply.NewPlanner(client).Plan(ctx, doc)
+	require.NoError(t, err)
+	assert.Contains(t, plan.String(), `- route "backend/stray"`)
+}
+
+func TestExporter_IncludeSecrets(t *testing.T) {
+	fake, client := apigwtest.NewClient(t)
+	seed(t, fake, client)
+
+	exporter := snapshot.NewExporter(client)
+	exporter.IncludeSecrets = true
+	doc, err := exporter.Export(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, "client-secret", doc.Oidc[0].ClientSecret)
+	assert.Equal(t, "password", doc.Users[0].Authentication.BasicAuth.Value.Password)
+
+	// 書き出した内容は現在の状態と一致する
+	plan, err := apply.NewPlanner(client).Plan(t.Context(), doc)
+	require.NoError(t, err)
+	assert.True(t, plan.Empty(), plan.String())
+}
@@ -0,0 +1,137 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snapshot
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	v1 "github.com/sacloud/apigw-api-go/apis/v1"
+	"github.com/sacloud/apigw-api-go/apply"
+	"github.com/sacloud/apigw-api-go/spec"
+)
+
+// checkpointVersion チェックポイントファイルの形式のバージョン
+const checkpointVersion = 1
+
+// Restorer 書き出したドキュメントを別のアカウントや環境に復元する。
+// リソースは依存関係の順に作成し、参照先のIDは復元先で作成・検出したリソースのIDに置き換える。
+// 復元先に同名のリソースが既に存在する場合は、ドキュメントの内容に合わせて更新する
+type Restorer struct {
+	client *v1.Client
+
+	// Checkpoint 作成したリソースを記録するファイルのパス。
+	// 復元に失敗した場合もファイルは残り、再実行すると作成済みのリソースを引き継いで続きから復元する。
+	// 復元が完了するとファイルは削除される
+	Checkpoint string
+
+	// Rollback trueの場合、復元に失敗した時点で作成したリソースを全て削除する。
+	// Checkpointを指定した場合は以前の実行で作成したリソースも対象となる
+	Rollback bool
+}
+
+// NewRestorer Restorerを生成する
+func NewRestorer(client *v1.Client) *Restorer {
+	return &Restorer{client: client}
+}
+
+// checkpoint 復元の途中経過
+type checkpoint struct {
+	Version int              `json:"version"`
+	Created []apply.Resource `json:"created"`
+}
+
+// Restore docをアカウントに復元し、適用した計画を返す。
+// docは秘匿情報を含めて書き出したものである必要がある
+func (r *Restorer) Restore(ctx context.Context, doc *spec.Document) (*apply.Plan, error) {
+	cp, err := r.loadCheckpoint()
+	if err != nil {
+		return nil, err
+	}
+
+	planner := apply.NewPlanner(r.client)
+	plan, err := planner.Plan(ctx, doc)
+	if err != nil {
+		return nil, err
+	}
+
+	var saveErr error
+	planner.OnCreated = func(res apply.Resource) {
+		cp.Created = append(cp.Created, res)
+		if err := r.saveCheckpoint(cp); err != nil && saveErr == nil {
+			saveErr = err
+		}
+	}
+	if err := planner.Apply(ctx, plan); err != nil {
+		err = errors.Join(err, saveErr)
+		if !r.Rollback {
+			return plan, err
+		}
+		if rbErr := planner.Rollback(ctx, cp.Created); rbErr != nil {
+			return plan, errors.Join(err, rbErr)
+		}
+		return plan, errors.Join(err, r.removeCheckpoint())
+	}
+	return plan, errors.Join(saveErr, r.removeCheckpoint())
+}
+
+func (r *Restorer) loadCheckpoint() (*checkpoint, error) {
+	cp := &checkpoint{Version: checkpointVersion}
+	if r.Checkpoint == "" {
+		return cp, nil
+	}
+	data, err := os.ReadFile(filepath.Clean(r.Checkpoint))
+	if errors.Is(err, os.ErrNotExist) {
+		return cp, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("snapshot: unable to read checkpoint: %w", err)
+	}
+	if err := json.Unmarshal(data, cp); err != nil {
+		return nil, fmt.Errorf("snapshot: invalid checkpoint: %w", err)
+	}
+	if cp.Version != checkpointVersion {
+		return nil, fmt.Errorf("snapshot: unsupported checkpoint version: %d", cp.Version)
+	}
+	return cp, nil
+}
+
+func (r *Restorer) saveCheckpoint(cp *checkpoint) error {
+	if r.Checkpoint == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(cp, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(r.Checkpoint, data, 0600); err != nil {
+		return fmt.Errorf("snapshot: unable to write checkpoint: %w", err)
+	}
+	return nil
+}
+
+func (r *Restorer) removeCheckpoint() error {
+	if r.Checkpoint == "" {
+		return nil
+	}
+	if err := os.Remove(r.Checkpoint); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("snapshot: unable to remove checkpoint: %w", err)
+	}
+	return nil
+}
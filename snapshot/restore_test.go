@@ -0,0 +1,128 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snapshot_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	apigw "github.com/sacloud/apigw-api-go"
+	"github.com/sacloud/apigw-api-go/apigwtest"
+	"github.com/sacloud/apigw-api-go/snapshot"
+	"github.com/sacloud/apigw-api-go/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func exportAll(t *testing.T, exporter *snapshot.Exporter) *spec.Document {
+	t.Helper()
+	exporter.IncludeSecrets = true
+	doc, err := exporter.Export(t.Context())
+	require.NoError(t, err)
+	return doc
+}
+
+func TestRestorer(t *testing.T) {
+	srcFake, src := apigwtest.NewClient(t)
+	seed(t, srcFake, src)
+	doc := exportAll(t, snapshot.NewExporter(src))
+
+	_, dst := apigwtest.NewClient(t)
+	restorer := snapshot.NewRestorer(dst)
+	restorer.Checkpoint = filepath.Join(t.TempDir(), "checkpoint.json")
+	plan, err := restorer.Restore(t.Context(), doc)
+	require.NoError(t, err)
+	create, update, del := plan.Summary()
+	assert.Equal(t, []int{14, 0, 0}, []int{create, update, del}, plan.String())
+	assert.NoFileExists(t, restorer.Checkpoint)
+
+	// 復元先のIDは異なるが、名前による参照は同じ内容になる
+	assert.Equal(t, doc, exportAll(t, snapshot.NewExporter(dst)))
+	srcServices, err := apigw.NewServiceOp(src).List(t.Context())
+	require.NoError(t, err)
+	dstServices, err := apigw.NewServiceOp(dst).List(t.Context())
+	require.NoError(t, err)
+	assert.NotEqual(t, srcServices[0].ID, dstServices[0].ID)
+	assert.NotEqual(t, srcServices[0].Subscription.ID, dstServices[0].Subscription.ID)
+
+	// 再実行しても変更はない
+	plan, err = restorer.Restore(t.Context(), doc)
+	require.NoError(t, err)
+	assert.True(t, plan.Empty(), plan.String())
+}
+
+func TestRestorer_Masked(t *testing.T) {
+	srcFake, src := apigwtest.NewClient(t)
+	seed(t, srcFake, src)
+	doc, err := snapshot.NewExporter(src).Export(t.Context())
+	require.NoError(t, err)
+
+	_, dst := apigwtest.NewClient(t)
+	_, err = snapshot.NewRestorer(dst).Restore(t.Context(), doc)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "oidc[0].clientSecret")
+}
+
+func TestRestorer_Resume(t *testing.T) {
+	srcFake, src := apigwtest.NewClient(t)
+	seed(t, srcFake, src)
+	doc := exportAll(t, snapshot.NewExporter(src))
+
+	// サービスの作成で失敗させる
+	invalid := 70000
+	port := doc.Services[0].Port
+	doc.Services[0].Port = &invalid
+
+	_, dst := apigwtest.NewClient(t)
+	restorer := snapshot.NewRestorer(dst)
+	restorer.Checkpoint = filepath.Join(t.TempDir(), "checkpoint.json")
+	_, err := restorer.Restore(t.Context(), doc)
+	require.Error(t, err)
+	assert.FileExists(t, restorer.Checkpoint)
+
+	// 作成済みのリソースを引き継いで再開する
+	doc.Services[0].Port = port
+	plan, err := restorer.Restore(t.Context(), doc)
+	require.NoError(t, err)
+	create, _, _ := plan.Summary()
+	assert.Equal(t, 5, create, plan.String())
+	assert.NoFileExists(t, restorer.Checkpoint)
+}
+
+func TestRestorer_Rollback(t *testing.T) {
+	srcFake, src := apigwtest.NewClient(t)
+	seed(t, srcFake, src)
+	doc := exportAll(t, snapshot.NewExporter(src))
+	invalid := 70000
+	doc.Services[0].Port = &invalid
+
+	_, dst := apigwtest.NewClient(t)
+	checkpoint := filepath.Join(t.TempDir(), "checkpoint.json")
+
+	// 1回目はロールバックせずに中断し、2回目に以前の分も含めて削除する
+	restorer := snapshot.NewRestorer(dst)
+	restorer.Checkpoint = checkpoint
+	_, err := restorer.Restore(t.Context(), doc)
+	require.Error(t, err)
+
+	doc.Users = append(doc.Users, spec.User{Name: "bob"})
+	restorer.Rollback = true
+	_, err = restorer.Restore(t.Context(), doc)
+	require.Error(t, err)
+	assert.NoFileExists(t, checkpoint)
+
+	restored := exportAll(t, snapshot.NewExporter(dst))
+	assert.Equal(t, &spec.Document{Version: spec.Version}, restored)
+}
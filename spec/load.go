@@ -0,0 +1,185 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ghodss/yaml"
+)
+
+// Parse YAMLまたはJSONで記述されたドキュメントを読み込み、検証する。
+// 未知の項目が含まれる場合はエラーとなる
+func Parse(data []byte) (*Document, error) {
+	js, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("spec: invalid document: %w", err)
+	}
+
+	var doc Document
+	dec := json.NewDecoder(bytes.NewReader(js))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("spec: invalid document: %w", err)
+	}
+	if err := doc.Validate(); err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
+
+// Load pathのドキュメントを読み込む。証明書のCertFile/KeyFileはこの時点で読み込まれる
+func Load(path string) (*Document, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("spec: unable to read document: %w", err)
+	}
+	doc, err := Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	if err := doc.ResolveFiles(filepath.Dir(path)); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// ResolveFiles 証明書のCertFile/KeyFileを読み込み、Cert/Keyに設定する。相対パスはdirを基準とする
+func (doc *Document) ResolveFiles(dir string) error {
+	read := func(path string) (string, error) {
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(dir, path)
+		}
+		data, err := os.ReadFile(filepath.Clean(path))
+		if err != nil {
+			return "", fmt.Errorf("spec: unable to read file: %w", err)
+		}
+		return string(data), nil
+	}
+
+	for i := range doc.Certificates {
+		for _, pair := range []*KeyPair{doc.Certificates[i].RSA, doc.Certificates[i].ECDSA} {
+			if pair == nil {
+				continue
+			}
+			if pair.CertFile != "" {
+				v, err := read(pair.CertFile)
+				if err != nil {
+					return err
+				}
+				pair.Cert, pair.CertFile = v, ""
+			}
+			if pair.KeyFile != "" {
+				v, err := read(pair.KeyFile)
+				if err != nil {
+					return err
+				}
+				pair.Key, pair.KeyFile = v, ""
+			}
+		}
+	}
+	return nil
+}
+
+// Validate ドキュメントの構造を検証する。他のリソースへの参照の解決は行わない
+func (doc *Document) Validate() error {
+	var errs []error
+	fail := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf("spec: "+format, args...))
+	}
+	unique := func(kind string) func(i int, name string) {
+		seen := make(map[string]bool)
+		return func(i int, name string) {
+			switch {
+			case name == "":
+				fail("%s[%d]: name is required", kind, i)
+			case seen[name]:
+				fail("%s[%d]: duplicate name %q", kind, i, name)
+			}
+			seen[name] = true
+		}
+	}
+
+	if doc.Version != Version {
+		fail("unsupported version: %d", doc.Version)
+	}
+
+	check := unique("subscriptions")
+	for i, sub := range doc.Subscriptions {
+		check(i, sub.Name)
+		if sub.Plan == "" {
+			fail("subscriptions[%d]: plan is required", i)
+		}
+	}
+
+	check = unique("oidc")
+	for i, o := range doc.Oidc {
+		check(i, o.Name)
+	}
+
+	check = unique("certificates")
+	for i, c := range doc.Certificates {
+		check(i, c.Name)
+		if c.RSA == nil && c.ECDSA == nil {
+			fail("certificates[%d]: either rsa or ecdsa is required", i)
+		}
+		for _, pair := range []*KeyPair{c.RSA, c.ECDSA} {
+			if pair != nil && (pair.Cert == "" && pair.CertFile == "" || pair.Key == "" && pair.KeyFile == "") {
+				fail("certificates[%d]: both cert and key are required", i)
+			}
+		}
+	}
+
+	check = unique("domains")
+	for i, d := range doc.Domains {
+		check(i, d.Name)
+	}
+
+	check = unique("groups")
+	for i, g := range doc.Groups {
+		check(i, g.Name)
+	}
+
+	check = unique("users")
+	for i, u := range doc.Users {
+		check(i, u.Name)
+	}
+
+	check = unique("services")
+	for i, s := range doc.Services {
+		check(i, s.Name)
+		if s.Subscription == "" {
+			fail("services[%d]: subscription is required", i)
+		}
+		checkRoute := unique(fmt.Sprintf("services[%d].routes", i))
+		for j, r := range s.Routes {
+			checkRoute(j, r.Name)
+			if r.Authorization != nil {
+				for k, g := range r.Authorization.Groups {
+					if g.Name == "" {
+						fail("services[%d].routes[%d].authorization.groups[%d]: name is required", i, j, k)
+					}
+				}
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,160 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package spec APIゲートウェイの設定を宣言的に記述するドキュメント。
+// リソース間の参照はIDではなく名前で記述する。
+// 省略した項目(nilのポインタ・スライス)は管理対象外として扱い、既存の値を変更しない。
+package spec
+
+import (
+	v1 "github.com/sacloud/apigw-api-go/apis/v1"
+)
+
+// Version ドキュメントの形式のバージョン
+const Version = 1
+
+// Document APIゲートウェイの設定全体
+type Document struct {
+	Version       int            `json:"version"`
+	Subscriptions []Subscription `json:"subscriptions,omitempty"`
+	Oidc          []Oidc         `json:"oidc,omitempty"`
+	Certificates  []Certificate  `json:"certificates,omitempty"`
+	Domains       []Domain       `json:"domains,omitempty"`
+	Groups        []Group        `json:"groups,omitempty"`
+	Users         []User         `json:"users,omitempty"`
+	Services      []Service      `json:"services,omitempty"`
+}
+
+// Subscription サブスクリプション
+type Subscription struct {
+	Name string `json:"name"`
+	// Plan プラン名
+	Plan string `json:"plan"`
+}
+
+// Oidc OIDC認証
+type Oidc struct {
+	Name                  string                         `json:"name"`
+	AuthenticationMethods []v1.AuthenticationMethodsItem `json:"authenticationMethods"`
+	Issuer                string                         `json:"issuer"`
+	ClientID              string                         `json:"clientId" mask:"true"`
+	ClientSecret          string                         `json:"clientSecret" mask:"true"`
+	Scopes                []string                       `json:"scopes,omitempty"`
+	HideCredentials       *bool                          `json:"hideCredentials,omitempty"`
+	TokenAudiences        []string                       `json:"tokenAudiences,omitempty"`
+	UseSession            *bool                          `json:"useSession,omitempty"`
+}
+
+// Certificate 証明書。RSAとECDSAの少なくとも一方を指定する
+type Certificate struct {
+	Name  string   `json:"name"`
+	RSA   *KeyPair `json:"rsa,omitempty"`
+	ECDSA *KeyPair `json:"ecdsa,omitempty"`
+}
+
+// KeyPair PEM形式の証明書と秘密鍵。
+// CertFile/KeyFileを指定した場合はファイルから読み込む。相対パスはドキュメントのあるディレクトリを基準とする
+type KeyPair struct {
+	Cert     string `json:"cert,omitempty" mask:"true"`
+	CertFile string `json:"certFile,omitempty"`
+	Key      string `json:"key,omitempty" mask:"true"`
+	KeyFile  string `json:"keyFile,omitempty"`
+}
+
+// Domain 独自ドメイン
+type Domain struct {
+	Name string `json:"name"`
+	// Certificate 証明書名
+	Certificate string `json:"certificate,omitempty"`
+}
+
+// Group グループ
+type Group struct {
+	Name string   `json:"name"`
+	Tags []string `json:"tags,omitempty"`
+}
+
+// User ユーザー
+type User struct {
+	Name                string                  `json:"name"`
+	CustomID            string                  `json:"customID,omitempty"`
+	Tags                []string                `json:"tags,omitempty"`
+	IpRestrictionConfig *v1.IpRestrictionConfig `json:"ipRestrictionConfig,omitempty"`
+	// Groups 所属するグループ名のリスト。指定した場合、ここにないグループからは外れる
+	Groups []string `json:"groups,omitempty"`
+	// Authentication 認証情報
+	Authentication *v1.UserAuthentication `json:"authentication,omitempty"`
+}
+
+// Service サービス
+type Service struct {
+	Name string   `json:"name"`
+	Tags []string `json:"tags,omitempty"`
+	// Subscription サービスに紐づけるサブスクリプション名。作成後は変更できない
+	Subscription   string `json:"subscription"`
+	Protocol       string `json:"protocol"`
+	Host           string `json:"host"`
+	Path           string `json:"path,omitempty"`
+	Port           *int   `json:"port,omitempty"`
+	Retries        *int   `json:"retries,omitempty"`
+	ConnectTimeout *int   `json:"connectTimeout,omitempty"`
+	WriteTimeout   *int   `json:"writeTimeout,omitempty"`
+	ReadTimeout    *int   `json:"readTimeout,omitempty"`
+	Authentication string `json:"authentication,omitempty"`
+	// Oidc OIDC認証名
+	Oidc                string                  `json:"oidc,omitempty"`
+	CorsConfig          *v1.CorsConfig          `json:"corsConfig,omitempty"`
+	ObjectStorageConfig *v1.ObjectStorageConfig `json:"objectStorageConfig,omitempty"`
+	// Routes サービスのルート。指定した場合、ここにないルートは削除される。
+	// 空のリストはルートがないことを表すため、省略せずに書き出す
+	Routes []Route `json:"routes"`
+}
+
+// Route ルート
+type Route struct {
+	Name                    string                  `json:"name"`
+	Tags                    []string                `json:"tags,omitempty"`
+	Protocols               string                  `json:"protocols,omitempty"`
+	Path                    string                  `json:"path,omitempty"`
+	Hosts                   []string                `json:"hosts,omitempty"`
+	Methods                 []v1.HTTPMethod         `json:"methods,omitempty"`
+	HttpsRedirectStatusCode *int                    `json:"httpsRedirectStatusCode,omitempty"`
+	RegexPriority           *int                    `json:"regexPriority,omitempty"`
+	StripPath               *bool                   `json:"stripPath,omitempty"`
+	PreserveHost            *bool                   `json:"preserveHost,omitempty"`
+	RequestBuffering        *bool                   `json:"requestBuffering,omitempty"`
+	ResponseBuffering       *bool                   `json:"responseBuffering,omitempty"`
+	IpRestrictionConfig     *v1.IpRestrictionConfig `json:"ipRestrictionConfig,omitempty"`
+	// Authorization ルートの認可設定
+	Authorization          *RouteAuthorization        `json:"authorization,omitempty"`
+	RequestTransformation  *v1.RequestTransformation  `json:"requestTransformation,omitempty"`
+	ResponseTransformation *v1.ResponseTransformation `json:"responseTransformation,omitempty"`
+}
+
+// RouteAuthorization ルートの認可設定。Groupsが空の場合は認可を無効にする
+type RouteAuthorization struct {
+	Groups []RouteAuthorizationGroup `json:"groups"`
+}
+
+// RouteAuthorizationGroup ルートへのアクセスを認可するグループ
+type RouteAuthorizationGroup struct {
+	Name string `json:"name"`
+	// Enabled 省略した場合はtrue
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
+// IsEnabled Enabledの値を返す。省略時はtrue
+func (g RouteAuthorizationGroup) IsEnabled() bool {
+	return g.Enabled == nil || *g.Enabled
+}
@@ -0,0 +1,68 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec_test
+
+import (
+	"testing"
+
+	v1 "github.com/sacloud/apigw-api-go/apis/v1"
+	"github.com/sacloud/apigw-api-go/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	doc, err := spec.Parse([]byte(`
+version: 1
+services:
+  - name: backend
+    subscription: sub
+    protocol: https
+    host: backend.example.com
+    port: 8443
+    routes:
+      - name: api
+        methods: [GET, POST]
+        requestTransformation:
+          httpMethod: PUT
+`))
+	require.NoError(t, err)
+	require.Len(t, doc.Services, 1)
+	assert.Equal(t, 8443, *doc.Services[0].Port)
+	assert.Nil(t, doc.Services[0].Retries)
+	route := doc.Services[0].Routes[0]
+	assert.Equal(t, []v1.HTTPMethod{v1.HTTPMethodGET, v1.HTTPMethodPOST}, route.Methods)
+	assert.Equal(t, v1.HTTPMethodPUT, route.RequestTransformation.HttpMethod.Value)
+
+	// JSONも読み込める
+	_, err = spec.Parse([]byte(`{"version": 1, "groups": [{"name": "admins"}]}`))
+	require.NoError(t, err)
+}
+
+func TestParse_Invalid(t *testing.T) {
+	cases := map[string]string{
+		"unknown field":  "version: 1\ngroups:\n  - name: a\n    unknown: true\n",
+		"version":        "version: 2\n",
+		"duplicate name": "version: 1\ngroups:\n  - name: a\n  - name: a\n",
+		"missing key":    "version: 1\ncertificates:\n  - name: c\n    rsa:\n      cert: x\n",
+		"subscription":   "version: 1\nservices:\n  - name: s\n    protocol: https\n    host: example.com\n",
+	}
+	for name, src := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := spec.Parse([]byte(src))
+			assert.Error(t, err)
+		})
+	}
+}
@@ -17,6 +17,7 @@ package apigw
 import (
 	"context"
 	"errors"
+	"iter"
 
 	"github.com/google/uuid"
 	v1 "github.com/sacloud/apigw-api-go/apis/v1"
@@ -25,6 +26,8 @@ import (
 type SubscriptionAPI interface {
 	ListPlans(ctx context.Context) ([]v1.Plan, error)
 	List(ctx context.Context) ([]v1.Subscription, error)
+	// All Listの結果を1件ずつ返すイテレータ。取得に失敗した場合はエラーを1度だけ返す
+	All(ctx context.Context) iter.Seq2[v1.Subscription, error]
 	Create(ctx context.Context, id uuid.UUID, name string) error
 	Read(ctx context.Context, id uuid.UUID) (*v1.SubscriptionDetailResponse, error)
 	Update(ctx context.Context, id uuid.UUID, name string) error
@@ -75,6 +78,10 @@ func (op *subscriptionOp) List(ctx context.Context) ([]v1.Subscription, error) {
 	return nil, NewAPIError("Subscription.List", 0, nil)
 }
 
+func (op *subscriptionOp) All(ctx context.Context) iter.Seq2[v1.Subscription, error] {
+	return all(ctx, op.List)
+}
+
 func (op *subscriptionOp) Create(ctx context.Context, id uuid.UUID, name string) error {
 	res, err := op.client.Subscribe(ctx, &v1.SubscriptionCreate{PlanId: id, Name: name})
 	if err != nil {
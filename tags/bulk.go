@@ -0,0 +1,286 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tags
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/google/uuid"
+	apigw "github.com/sacloud/apigw-api-go"
+	v1 "github.com/sacloud/apigw-api-go/apis/v1"
+	"github.com/sacloud/apigw-api-go/internal/jsondiff"
+)
+
+// DefaultConcurrency Bulkが同時に実行する操作数の既定値
+const DefaultConcurrency = 4
+
+// Bulk タグで絞り込んだリソースに同じ操作を一括で行う。
+// 一覧の取得に失敗した場合はエラーを返し、個々のリソースに対する操作の成否はReportに記録する
+type Bulk struct {
+	client *v1.Client
+
+	// Concurrency 同時に実行する操作数の上限。0以下の場合はDefaultConcurrency
+	Concurrency int
+}
+
+// NewBulk Bulkを生成する
+func NewBulk(client *v1.Client) *Bulk {
+	return &Bulk{client: client}
+}
+
+// Result 1件のリソースに対する操作の結果
+type Result struct {
+	ID   uuid.UUID
+	Name string
+	// Skipped 変更の必要がなく、操作を行わなかった
+	Skipped bool
+	// Err 操作に失敗した場合のエラー
+	Err error
+}
+
+// Report 一括操作の結果。Resultsは一覧の順に並ぶ
+type Report struct {
+	// Resource リソースの種類。"route"など
+	Resource string
+	Results  []Result
+}
+
+// Failed 失敗した操作の結果を返す
+func (r *Report) Failed() []Result {
+	return slices.DeleteFunc(slices.Clone(r.Results), func(res Result) bool { return res.Err == nil })
+}
+
+// Err 失敗した操作のエラーをまとめて返す。全て成功した場合はnil
+func (r *Report) Err() error {
+	var errs []error
+	for _, res := range r.Failed() {
+		errs = append(errs, fmt.Errorf("%s %q: %w", r.Resource, res.Name, res.Err))
+	}
+	return errors.Join(errs...)
+}
+
+// DeleteServices タグがselに一致するサービスを削除する
+func (b *Bulk) DeleteServices(ctx context.Context, sel Selector) (*Report, error) {
+	op := apigw.NewServiceOp(b.client)
+	items, err := Services(ctx, op, sel)
+	if err != nil {
+		return nil, err
+	}
+	return run(ctx, b, "service", items, serviceRef, func(ctx context.Context, s *v1.ServiceDetailResponse) (bool, error) {
+		return false, op.Delete(ctx, s.ID.Value)
+	}), nil
+}
+
+// DeleteRoutes サービスserviceIDのルートのうち、タグがselに一致するものを削除する
+func (b *Bulk) DeleteRoutes(ctx context.Context, serviceID uuid.UUID, sel Selector) (*Report, error) {
+	op := apigw.NewRouteOp(b.client, serviceID)
+	items, err := Routes(ctx, op, sel)
+	if err != nil {
+		return nil, err
+	}
+	return run(ctx, b, "route", items, routeRef, func(ctx context.Context, r *v1.Route) (bool, error) {
+		return false, op.Delete(ctx, r.ID.Value)
+	}), nil
+}
+
+// DeleteUsers タグがselに一致するユーザーを削除する
+func (b *Bulk) DeleteUsers(ctx context.Context, sel Selector) (*Report, error) {
+	op := apigw.NewUserOp(b.client)
+	items, err := Users(ctx, op, sel)
+	if err != nil {
+		return nil, err
+	}
+	return run(ctx, b, "user", items, userRef, func(ctx context.Context, u *v1.User) (bool, error) {
+		return false, op.Delete(ctx, u.ID.Value)
+	}), nil
+}
+
+// DeleteGroups タグがselに一致するグループを削除する
+func (b *Bulk) DeleteGroups(ctx context.Context, sel Selector) (*Report, error) {
+	op := apigw.NewGroupOp(b.client)
+	items, err := Groups(ctx, op, sel)
+	if err != nil {
+		return nil, err
+	}
+	return run(ctx, b, "group", items, groupRef, func(ctx context.Context, g *v1.Group) (bool, error) {
+		return false, op.Delete(ctx, g.ID.Value)
+	}), nil
+}
+
+// RetagServices タグがselに一致するサービスのタグをeditで変更する
+func (b *Bulk) RetagServices(ctx context.Context, sel Selector, edit Edit) (*Report, error) {
+	op := apigw.NewServiceOp(b.client)
+	items, err := Services(ctx, op, sel)
+	if err != nil {
+		return nil, err
+	}
+	return run(ctx, b, "service", items, serviceRef, func(ctx context.Context, s *v1.ServiceDetailResponse) (bool, error) {
+		tags, changed := retag(s.Tags, edit)
+		if !changed {
+			return true, nil
+		}
+		var req v1.ServiceDetail
+		if err := jsondiff.Overlay(&req, s, nil, "routeHost", "subscription"); err != nil {
+			return false, err
+		}
+		req.Tags = tags
+		return false, op.Update(ctx, &req, s.ID.Value)
+	}), nil
+}
+
+// RetagRoutes サービスserviceIDのルートのうち、タグがselに一致するもののタグをeditで変更する
+func (b *Bulk) RetagRoutes(ctx context.Context, serviceID uuid.UUID, sel Selector, edit Edit) (*Report, error) {
+	op := apigw.NewRouteOp(b.client, serviceID)
+	items, err := Routes(ctx, op, sel)
+	if err != nil {
+		return nil, err
+	}
+	return run(ctx, b, "route", items, routeRef, func(ctx context.Context, r *v1.Route) (bool, error) {
+		tags, changed := retag(r.Tags, edit)
+		if !changed {
+			return true, nil
+		}
+		current, err := op.Read(ctx, r.ID.Value)
+		if err != nil {
+			return false, err
+		}
+		var req v1.RouteDetail
+		if err := jsondiff.Overlay(&req, current, nil, "serviceId", "host"); err != nil {
+			return false, err
+		}
+		req.Tags = tags
+		return false, op.Update(ctx, &req, r.ID.Value)
+	}), nil
+}
+
+// RetagUsers タグがselに一致するユーザーのタグをeditで変更する
+func (b *Bulk) RetagUsers(ctx context.Context, sel Selector, edit Edit) (*Report, error) {
+	items, err := Users(ctx, apigw.NewUserOp(b.client), sel)
+	if err != nil {
+		return nil, err
+	}
+	return b.retagUsers(ctx, items, edit), nil
+}
+
+// RetagGroupMembers グループgroupIdOrNameに所属するユーザーのタグをeditで変更する
+func (b *Bulk) RetagGroupMembers(ctx context.Context, groupIdOrName string, edit Edit) (*Report, error) {
+	group, err := apigw.NewGroupOp(b.client).Resolve(ctx, groupIdOrName)
+	if err != nil {
+		return nil, err
+	}
+	users, err := apigw.NewUserOp(b.client).List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	members := slices.DeleteFunc(users, func(u v1.User) bool {
+		return !slices.ContainsFunc(u.Groups, func(g v1.Group) bool { return g.ID.Value == group.ID.Value })
+	})
+	return b.retagUsers(ctx, members, edit), nil
+}
+
+func (b *Bulk) retagUsers(ctx context.Context, items []v1.User, edit Edit) *Report {
+	op := apigw.NewUserOp(b.client)
+	return run(ctx, b, "user", items, userRef, func(ctx context.Context, u *v1.User) (bool, error) {
+		tags, changed := retag(u.Tags, edit)
+		if !changed {
+			return true, nil
+		}
+		current, err := op.Read(ctx, u.ID.Value)
+		if err != nil {
+			return false, err
+		}
+		// 所属グループはUserExtraAPIで管理するため、更新の対象に含めない
+		var req v1.UserDetail
+		if err := jsondiff.Overlay(&req, current, nil, "groups"); err != nil {
+			return false, err
+		}
+		req.Tags = tags
+		return false, op.Update(ctx, &req, u.ID.Value)
+	})
+}
+
+// RetagGroups タグがselに一致するグループのタグをeditで変更する
+func (b *Bulk) RetagGroups(ctx context.Context, sel Selector, edit Edit) (*Report, error) {
+	op := apigw.NewGroupOp(b.client)
+	items, err := Groups(ctx, op, sel)
+	if err != nil {
+		return nil, err
+	}
+	return run(ctx, b, "group", items, groupRef, func(ctx context.Context, g *v1.Group) (bool, error) {
+		tags, changed := retag(g.Tags, edit)
+		if !changed {
+			return true, nil
+		}
+		var req v1.Group
+		if err := jsondiff.Overlay(&req, g, nil); err != nil {
+			return false, err
+		}
+		req.Tags = tags
+		return false, op.Update(ctx, &req, g.ID.Value)
+	}), nil
+}
+
+func serviceRef(s *v1.ServiceDetailResponse) (uuid.UUID, string) { return s.ID.Value, string(s.Name) }
+func routeRef(r *v1.Route) (uuid.UUID, string)                   { return r.ID.Value, string(r.Name.Value) }
+func userRef(u *v1.User) (uuid.UUID, string)                     { return u.ID.Value, string(u.Name) }
+func groupRef(g *v1.Group) (uuid.UUID, string)                   { return g.ID.Value, string(g.Name.Value) }
+
+// retag editを適用したタグと、変更があったかどうかを返す
+func retag(tags []string, edit Edit) ([]string, bool) {
+	edited := edit(tags)
+	if edited == nil {
+		edited = []string{}
+	}
+	return edited, !slices.Equal(tags, edited)
+}
+
+// run itemsのそれぞれにfnを最大b.Concurrency件ずつ並行して実行する。
+// fnは操作を行わなかった場合にtrueを返す
+func run[T any](ctx context.Context, b *Bulk, resource string, items []T, ref func(*T) (uuid.UUID, string), fn func(context.Context, *T) (bool, error)) *Report {
+	n := b.Concurrency
+	if n <= 0 {
+		n = DefaultConcurrency
+	}
+
+	report := &Report{Resource: resource, Results: make([]Result, len(items))}
+	sem := make(chan struct{}, n)
+	var wg sync.WaitGroup
+	for i := range items {
+		res := &report.Results[i]
+		res.ID, res.Name = ref(&items[i])
+		if err := ctx.Err(); err != nil {
+			res.Err = err
+			continue
+		}
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			res.Err = ctx.Err()
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer func() { <-sem; wg.Done() }()
+			res.Skipped, res.Err = fn(ctx, &items[i])
+		}()
+	}
+	wg.Wait()
+	return report
+}
@@ -0,0 +1,177 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tags_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+	"testing"
+
+	apigw "github.com/sacloud/apigw-api-go"
+	"github.com/sacloud/apigw-api-go/apigwtest"
+	v1 "github.com/sacloud/apigw-api-go/apis/v1"
+	"github.com/sacloud/apigw-api-go/tags"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createService(t *testing.T, fake *apigwtest.Server, client *v1.Client) *v1.ServiceDetailRequest {
+	t.Helper()
+	ctx := t.Context()
+
+	subOp := apigw.NewSubscriptionOp(client)
+	require.NoError(t, subOp.Create(ctx, fake.Plans()[0].ID.Value, "test-sub"))
+	subs, err := subOp.List(ctx)
+	require.NoError(t, err)
+	service, err := apigw.NewServiceOp(client).Create(ctx, &v1.ServiceDetailRequest{
+		Name:         "backend",
+		Host:         "backend.example.com",
+		Protocol:     v1.ServiceDetailRequestProtocolHTTPS,
+		Subscription: v1.ServiceSubscriptionRequest{ID: subs[0].ID.Value},
+		Tags:         []string{"env=dev"},
+	})
+	require.NoError(t, err)
+	return service
+}
+
+func TestBulk_DeleteRoutes(t *testing.T) {
+	fake, client := apigwtest.NewClient(t)
+	ctx := t.Context()
+	service := createService(t, fake, client)
+
+	routeOp := apigw.NewRouteOp(client, service.ID.Value)
+	for _, r := range []struct {
+		name string
+		tags []string
+	}{
+		{"keep", []string{"stable"}},
+		{"tmp1", []string{"ephemeral"}},
+		{"tmp2", []string{"ephemeral", "stable"}},
+	} {
+		_, err := routeOp.Create(ctx, &v1.RouteDetail{Name: v1.NewOptName(v1.Name(r.name)), Path: v1.NewOptString("/" + r.name), Tags: r.tags})
+		require.NoError(t, err)
+	}
+
+	bulk := tags.NewBulk(client)
+	bulk.Concurrency = 2
+	report, err := bulk.DeleteRoutes(ctx, service.ID.Value, tags.Has("ephemeral"))
+	require.NoError(t, err)
+	require.NoError(t, report.Err())
+	assert.Equal(t, "route", report.Resource)
+	assert.Len(t, report.Results, 2)
+
+	routes, err := routeOp.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, routes, 1)
+	assert.Equal(t, v1.Name("keep"), routes[0].Name.Value)
+}
+
+func TestBulk_Retag(t *testing.T) {
+	fake, client := apigwtest.NewClient(t)
+	ctx := t.Context()
+	service := createService(t, fake, client)
+
+	group, err := apigw.NewGroupOp(client).Create(ctx, &v1.Group{Name: v1.NewOptName("admins")})
+	require.NoError(t, err)
+	userOp := apigw.NewUserOp(client)
+	for _, name := range []string{"alice", "bob", "carol"} {
+		u, err := userOp.Create(ctx, &v1.UserDetail{Name: v1.Name(name), Tags: []string{"team=a"}})
+		require.NoError(t, err)
+		if name != "carol" {
+			require.NoError(t, apigw.NewUserExtraOp(client, u.ID.Value).UpdateGroup(ctx, "admins", true))
+		}
+	}
+
+	bulk := tags.NewBulk(client)
+	report, err := bulk.RetagGroupMembers(ctx, group.ID.Value.String(), tags.Add("admin"))
+	require.NoError(t, err)
+	require.NoError(t, report.Err())
+	assert.Len(t, report.Results, 2)
+
+	admins, err := tags.Users(ctx, userOp, tags.Has("admin"))
+	require.NoError(t, err)
+	assert.Len(t, admins, 2)
+	// タグ以外の設定や所属グループは維持する
+	for _, u := range admins {
+		assert.Equal(t, []string{"team=a", "admin"}, []string(u.Tags))
+		assert.Len(t, u.Groups, 1)
+	}
+
+	// 変更がないものは更新しない
+	report, err = bulk.RetagUsers(ctx, tags.Everything(), tags.Add("admin"))
+	require.NoError(t, err)
+	var skipped int
+	for _, r := range report.Results {
+		if r.Skipped {
+			skipped++
+		}
+	}
+	assert.Equal(t, 2, skipped)
+
+	report, err = bulk.RetagServices(ctx, tags.KeyValue("env", "dev"), tags.SetValue("env", "prod"))
+	require.NoError(t, err)
+	require.NoError(t, report.Err())
+	got, err := apigw.NewServiceOp(client).Read(ctx, service.ID.Value)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"env=prod"}, []string(got.Tags))
+	assert.Equal(t, "backend.example.com", got.Host)
+}
+
+func TestBulk_Failures(t *testing.T) {
+	var failID string
+	fail := func(next apigw.Doer) apigw.Doer {
+		return apigw.DoerFunc(func(req *http.Request) (*http.Response, error) {
+			if req.Method == http.MethodDelete && path.Base(req.URL.Path) == failID {
+				return &http.Response{
+					StatusCode: http.StatusInternalServerError,
+					Header:     http.Header{"Content-Type": []string{"application/json"}},
+					Body:       io.NopCloser(strings.NewReader(`{"message":"boom"}`)),
+					Request:    req,
+				}, nil
+			}
+			return next.Do(req)
+		})
+	}
+	_, client := apigwtest.NewClient(t, fail)
+	ctx := t.Context()
+
+	groupOp := apigw.NewGroupOp(client)
+	for _, name := range []string{"a", "b", "c"} {
+		g, err := groupOp.Create(ctx, &v1.Group{Name: v1.NewOptName(v1.Name(name)), Tags: []string{"old"}})
+		require.NoError(t, err)
+		if name == "b" {
+			failID = g.ID.Value.String()
+		}
+	}
+
+	report, err := tags.NewBulk(client).DeleteGroups(ctx, tags.Has("old"))
+	require.NoError(t, err)
+	require.Len(t, report.Results, 3)
+	failed := report.Failed()
+	require.Len(t, failed, 1)
+	assert.Equal(t, "b", failed[0].Name)
+	assert.Equal(t, http.StatusInternalServerError, apigw.StatusCodeOf(failed[0].Err))
+	assert.ErrorContains(t, report.Err(), `group "b"`)
+
+	// 取得に失敗した場合はレポートを返さない
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	report, err = tags.NewBulk(client).DeleteGroups(canceled, tags.Everything())
+	assert.Error(t, err)
+	assert.Nil(t, report)
+}
@@ -0,0 +1,103 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tags
+
+import (
+	"slices"
+	"strings"
+)
+
+// Edit タグの変更。引数のスライスは変更せず、変更後のタグを返す
+type Edit func(tags []string) []string
+
+// Add tagsを持っていなければ末尾に追加する
+func Add(tags ...string) Edit {
+	return func(have []string) []string {
+		ret := slices.Clone(have)
+		for _, t := range tags {
+			if !slices.Contains(ret, t) {
+				ret = append(ret, t)
+			}
+		}
+		return ret
+	}
+}
+
+// Remove tagsを取り除く
+func Remove(tags ...string) Edit {
+	return func(have []string) []string {
+		return slices.DeleteFunc(slices.Clone(have), func(t string) bool { return slices.Contains(tags, t) })
+	}
+}
+
+// Rename タグfromをtoに置き換える。既にtoを持っている場合はfromを取り除く
+func Rename(from, to string) Edit {
+	return func(have []string) []string {
+		i := slices.Index(have, from)
+		if i < 0 {
+			return slices.Clone(have)
+		}
+		if slices.Contains(have, to) {
+			return Remove(from)(have)
+		}
+		ret := slices.Clone(have)
+		ret[i] = to
+		return ret
+	}
+}
+
+// SetValue "key=value"形式のkeyの値をvalueにする。keyのタグが既にあれば置き換え、なければ追加する
+func SetValue(key, value string) Edit {
+	tag := key + "=" + value
+	return func(have []string) []string {
+		ret := make([]string, 0, len(have)+1)
+		set := false
+		for _, t := range have {
+			if k, _, ok := strings.Cut(t, "="); ok && k == key {
+				if !set {
+					ret = append(ret, tag)
+					set = true
+				}
+				continue
+			}
+			ret = append(ret, t)
+		}
+		if !set {
+			ret = append(ret, tag)
+		}
+		return ret
+	}
+}
+
+// RemoveKey "key=value"形式でkeyのタグを全て取り除く
+func RemoveKey(key string) Edit {
+	return func(have []string) []string {
+		return slices.DeleteFunc(slices.Clone(have), func(t string) bool {
+			k, _, ok := strings.Cut(t, "=")
+			return ok && k == key
+		})
+	}
+}
+
+// Chain editsを順に適用する
+func Chain(edits ...Edit) Edit {
+	return func(have []string) []string {
+		ret := slices.Clone(have)
+		for _, e := range edits {
+			ret = e(ret)
+		}
+		return ret
+	}
+}
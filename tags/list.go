@@ -0,0 +1,58 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tags
+
+import (
+	"context"
+
+	apigw "github.com/sacloud/apigw-api-go"
+	v1 "github.com/sacloud/apigw-api-go/apis/v1"
+)
+
+// Services タグがselに一致するサービスを返す
+func Services(ctx context.Context, op apigw.ServiceAPI, sel Selector) ([]v1.ServiceDetailResponse, error) {
+	items, err := op.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return Filter(items, func(s *v1.ServiceDetailResponse) []string { return s.Tags }, sel), nil
+}
+
+// Routes タグがselに一致するルートを返す
+func Routes(ctx context.Context, op apigw.RouteAPI, sel Selector) ([]v1.Route, error) {
+	items, err := op.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return Filter(items, func(r *v1.Route) []string { return r.Tags }, sel), nil
+}
+
+// Users タグがselに一致するユーザーを返す
+func Users(ctx context.Context, op apigw.UserAPI, sel Selector) ([]v1.User, error) {
+	items, err := op.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return Filter(items, func(u *v1.User) []string { return u.Tags }, sel), nil
+}
+
+// Groups タグがselに一致するグループを返す
+func Groups(ctx context.Context, op apigw.GroupAPI, sel Selector) ([]v1.Group, error) {
+	items, err := op.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return Filter(items, func(g *v1.Group) []string { return g.Tags }, sel), nil
+}
@@ -0,0 +1,199 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tags リソースのタグによる絞り込みと、絞り込んだリソースへの一括操作を提供する。
+//
+// タグは単純な文字列のほか、"env=prod"のような"key=value"形式の慣習にも対応する。
+//
+//	sel, err := tags.Parse("env=prod,!ephemeral")
+//	services, err := tags.Services(ctx, apigw.NewServiceOp(client), sel)
+//
+//	report, err := tags.NewBulk(client).DeleteRoutes(ctx, serviceID, tags.Has("ephemeral"))
+package tags
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Selector タグの集合に対する条件
+type Selector func(tags []string) bool
+
+// Match tagsが条件を満たすかどうか
+func (s Selector) Match(tags []string) bool {
+	return s(tags)
+}
+
+// Everything 全てに一致する
+func Everything() Selector {
+	return func([]string) bool { return true }
+}
+
+// Has tagを持つ
+func Has(tag string) Selector {
+	return func(tags []string) bool { return slices.Contains(tags, tag) }
+}
+
+// AllOf tagsを全て持つ
+func AllOf(tags ...string) Selector {
+	return func(have []string) bool {
+		for _, t := range tags {
+			if !slices.Contains(have, t) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// AnyOf tagsのいずれかを持つ
+func AnyOf(tags ...string) Selector {
+	return func(have []string) bool {
+		for _, t := range tags {
+			if slices.Contains(have, t) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// NoneOf tagsをいずれも持たない
+func NoneOf(tags ...string) Selector {
+	return Not(AnyOf(tags...))
+}
+
+// HasKey "key=value"形式でkeyのタグ、またはkeyそのもののタグを持つ
+func HasKey(key string) Selector {
+	return func(tags []string) bool {
+		for _, t := range tags {
+			if k, _, _ := strings.Cut(t, "="); k == key {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// KeyValue "key=value"のタグを持つ
+func KeyValue(key, value string) Selector {
+	return Has(key + "=" + value)
+}
+
+// Not sの否定
+func Not(s Selector) Selector {
+	return func(tags []string) bool { return !s(tags) }
+}
+
+// And selectorsを全て満たす
+func And(selectors ...Selector) Selector {
+	return func(tags []string) bool {
+		for _, s := range selectors {
+			if !s(tags) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Or selectorsのいずれかを満たす
+func Or(selectors ...Selector) Selector {
+	return func(tags []string) bool {
+		for _, s := range selectors {
+			if s(tags) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Value tagsから"key=value"形式のkeyの値を返す
+func Value(tags []string, key string) (string, bool) {
+	for _, t := range tags {
+		if k, v, ok := strings.Cut(t, "="); ok && k == key {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+// Parse 文字列で表現した条件を解釈する。
+//
+// ","で区切った条件を全て満たすものに一致する。各条件は"|"で区切った候補のいずれかを満たすものに一致する。
+// 候補は次のいずれか。
+//
+//	tag        tagを持つ
+//	!tag       tagを持たない
+//	key=value  "key=value"のタグを持つ
+//	key!=value "key=value"のタグを持たない
+//	key=*      keyのタグを持つ
+//	!key=*     keyのタグを持たない
+//
+// 空文字列は全てに一致する。
+func Parse(expr string) (Selector, error) {
+	if strings.TrimSpace(expr) == "" {
+		return Everything(), nil
+	}
+	var all []Selector
+	for _, req := range strings.Split(expr, ",") {
+		var alts []Selector
+		for _, term := range strings.Split(req, "|") {
+			s, err := parseTerm(strings.TrimSpace(term))
+			if err != nil {
+				return nil, fmt.Errorf("tags: invalid selector %q: %w", expr, err)
+			}
+			alts = append(alts, s)
+		}
+		all = append(all, Or(alts...))
+	}
+	return And(all...), nil
+}
+
+func parseTerm(term string) (Selector, error) {
+	negate := false
+	if t, ok := strings.CutPrefix(term, "!"); ok {
+		negate, term = true, t
+	} else if k, v, ok := strings.Cut(term, "!="); ok {
+		negate, term = true, k+"="+v
+	}
+	if term == "" || strings.HasPrefix(term, "=") {
+		return nil, errors.New("empty tag")
+	}
+
+	var s Selector
+	if k, ok := strings.CutSuffix(term, "=*"); ok {
+		s = HasKey(k)
+	} else {
+		s = Has(term)
+	}
+	if negate {
+		s = Not(s)
+	}
+	return s, nil
+}
+
+// Filter itemsのうち、tagsOfで取得したタグがselに一致するものを返す
+func Filter[T any](items []T, tagsOf func(*T) []string, sel Selector) []T {
+	ret := []T{}
+	for i := range items {
+		if sel(tagsOf(&items[i])) {
+			ret = append(ret, items[i])
+		}
+	}
+	return ret
+}
@@ -0,0 +1,85 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tags_test
+
+import (
+	"testing"
+
+	"github.com/sacloud/apigw-api-go/tags"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	have := []string{"web", "env=prod", "team=a"}
+	cases := []struct {
+		expr string
+		want bool
+	}{
+		{"", true},
+		{"web", true},
+		{"!web", false},
+		{"web,env=prod", true},
+		{"web,env=dev", false},
+		{"env=dev|env=prod", true},
+		{"env!=prod", false},
+		{"env=*", true},
+		{"!owner=*", true},
+		{"ephemeral|team=a, !env=dev", true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.expr, func(t *testing.T) {
+			sel, err := tags.Parse(tc.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, sel.Match(have))
+		})
+	}
+
+	for _, expr := range []string{"web,", "!", "=prod", "a||b"} {
+		_, err := tags.Parse(expr)
+		assert.Error(t, err, expr)
+	}
+}
+
+func TestSelector(t *testing.T) {
+	have := []string{"web", "env=prod"}
+	assert.True(t, tags.AllOf("web", "env=prod").Match(have))
+	assert.False(t, tags.AllOf("web", "api").Match(have))
+	assert.True(t, tags.AnyOf("api", "web").Match(have))
+	assert.True(t, tags.NoneOf("api", "ephemeral").Match(have))
+	assert.True(t, tags.KeyValue("env", "prod").Match(have))
+	assert.False(t, tags.HasKey("team").Match(have))
+	assert.True(t, tags.Or(tags.Has("api"), tags.Not(tags.HasKey("team"))).Match(have))
+
+	v, ok := tags.Value(have, "env")
+	assert.True(t, ok)
+	assert.Equal(t, "prod", v)
+}
+
+func TestEdit(t *testing.T) {
+	have := []string{"web", "env=dev", "old"}
+	edit := tags.Chain(
+		tags.Add("api", "web"),
+		tags.Remove("old"),
+		tags.SetValue("env", "prod"),
+		tags.SetValue("team", "a"),
+		tags.Rename("web", "frontend"),
+	)
+	assert.Equal(t, []string{"frontend", "env=prod", "api", "team=a"}, edit(have))
+	assert.Equal(t, []string{"web", "env=dev", "old"}, have)
+
+	assert.Equal(t, []string{"web", "old"}, tags.RemoveKey("env")(have))
+	assert.Equal(t, []string{"web", "env=dev"}, tags.Rename("old", "web")(have))
+}
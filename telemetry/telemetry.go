@@ -0,0 +1,188 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package telemetry APIゲートウェイ APIの呼び出しをOpenTelemetryで計装するapigw.Layer。
+// 各操作について、操作名・リソースのID・ステータスコードを含むスパンと、呼び出し回数・所要時間のメトリクスを記録する。
+//
+//	tel, err := telemetry.New(telemetry.Config{TracerProvider: tp, MeterProvider: mp})
+//	client, err := apigw.NewClient(&theClient, tel.Layer())
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	apigw "github.com/sacloud/apigw-api-go"
+	"github.com/sacloud/apigw-api-go/internal/wire"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ScopeName 計装のスコープ名
+const ScopeName = "github.com/sacloud/apigw-api-go/telemetry"
+
+// 記録する属性のキー
+const (
+	// AttrOperation apigwパッケージのメソッド。"Service.Create"など
+	AttrOperation = attribute.Key("apigw.operation")
+	// AttrOperationID OpenAPI定義の操作ID。"AddService"など
+	AttrOperationID = attribute.Key("apigw.operation_id")
+	// AttrMethod HTTPメソッド
+	AttrMethod = attribute.Key("http.request.method")
+	// AttrStatusCode HTTPステータスコード
+	AttrStatusCode = attribute.Key("http.response.status_code")
+	// AttrErrorType 通信エラーの種類。エラーの場合はステータスコードの代わりに記録する
+	AttrErrorType = attribute.Key("error.type")
+)
+
+// Config 計装の設定
+type Config struct {
+	// TracerProvider nilの場合はotel.GetTracerProvider()
+	TracerProvider trace.TracerProvider
+	// MeterProvider nilの場合はotel.GetMeterProvider()
+	MeterProvider metric.MeterProvider
+}
+
+// Instrumentation スパンとメトリクスを記録する
+type Instrumentation struct {
+	tracer   trace.Tracer
+	requests metric.Int64Counter
+	duration metric.Float64Histogram
+}
+
+// New cfgのプロバイダで計装するInstrumentationを生成する
+func New(cfg Config) (*Instrumentation, error) {
+	tp := cfg.TracerProvider
+	if tp == nil {
+		tp = otel.GetTracerProvider()
+	}
+	mp := cfg.MeterProvider
+	if mp == nil {
+		mp = otel.GetMeterProvider()
+	}
+
+	meter := mp.Meter(ScopeName, metric.WithInstrumentationVersion(apigw.Version))
+	requests, err := meter.Int64Counter("apigw.client.requests",
+		metric.WithDescription("Number of APIGW API operations called"),
+		metric.WithUnit("{request}"))
+	if err != nil {
+		return nil, err
+	}
+	duration, err := meter.Float64Histogram("apigw.client.duration",
+		metric.WithDescription("Duration of APIGW API operations"),
+		metric.WithUnit("s"))
+	if err != nil {
+		return nil, err
+	}
+	return &Instrumentation{
+		tracer:   tp.Tracer(ScopeName, trace.WithInstrumentationVersion(apigw.Version)),
+		requests: requests,
+		duration: duration,
+	}, nil
+}
+
+// Layer apigw.NewClientに渡すためのLayer
+func (in *Instrumentation) Layer() apigw.Layer {
+	return func(next apigw.Doer) apigw.Doer {
+		return &transport{in: in, next: next}
+	}
+}
+
+type transport struct {
+	in   *Instrumentation
+	next apigw.Doer
+}
+
+func (t *transport) Do(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	name := "apigw " + req.Method
+	attrs := []attribute.KeyValue{AttrMethod.String(req.Method)}
+	var spanAttrs []attribute.KeyValue
+	if op, ok := wire.Lookup(req.Method, req.URL.Path); ok {
+		name = op.Func
+		if op.Request != nil {
+			name = op.FuncOf(peekBody(req))
+		}
+		attrs = append(attrs, AttrOperation.String(name), AttrOperationID.String(string(op.Name)))
+		for k, v := range op.Params(req.URL.Path) {
+			spanAttrs = append(spanAttrs, paramKey(k).String(v))
+		}
+	}
+
+	ctx, span := t.in.tracer.Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+		trace.WithAttributes(spanAttrs...))
+	defer span.End()
+
+	start := time.Now()
+	res, err := t.next.Do(req.WithContext(ctx))
+	elapsed := time.Since(start).Seconds()
+
+	switch {
+	case err != nil:
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		attrs = append(attrs, AttrErrorType.String(errorType(err)))
+	default:
+		span.SetAttributes(AttrStatusCode.Int(res.StatusCode))
+		if res.StatusCode >= http.StatusBadRequest {
+			span.SetStatus(codes.Error, http.StatusText(res.StatusCode))
+			attrs = append(attrs, AttrErrorType.String(strconv.Itoa(res.StatusCode)))
+		}
+		attrs = append(attrs, AttrStatusCode.Int(res.StatusCode))
+	}
+	set := metric.WithAttributes(attrs...)
+	t.in.requests.Add(ctx, 1, set)
+	t.in.duration.Record(ctx, elapsed, set)
+	return res, err
+}
+
+// paramKey パスパラメータの属性のキー。serviceIdはapigw.service.idとなる
+func paramKey(param string) attribute.Key {
+	return attribute.Key("apigw." + strings.ToLower(strings.TrimSuffix(param, "Id")) + ".id")
+}
+
+func errorType(err error) string {
+	switch {
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	}
+	return "transport"
+}
+
+// peekBody ボディを読み込み、再度読み込めるように戻す
+func peekBody(req *http.Request) []byte {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil
+	}
+	data, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	req.Body = io.NopCloser(bytes.NewReader(data))
+	if err != nil {
+		return nil
+	}
+	return data
+}
@@ -0,0 +1,94 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry_test
+
+import (
+	"net/http"
+	"testing"
+
+	apigw "github.com/sacloud/apigw-api-go"
+	"github.com/sacloud/apigw-api-go/apigwtest"
+	v1 "github.com/sacloud/apigw-api-go/apis/v1"
+	"github.com/sacloud/apigw-api-go/telemetry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestInstrumentation(t *testing.T) {
+	spans := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(spans))
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	tel, err := telemetry.New(telemetry.Config{TracerProvider: tp, MeterProvider: mp})
+	require.NoError(t, err)
+
+	_, client := apigwtest.NewClient(t, tel.Layer())
+
+	ctx := t.Context()
+	group, err := apigw.NewGroupOp(client).Create(ctx, &v1.Group{Name: v1.NewOptName("admins")})
+	require.NoError(t, err)
+	_, err = apigw.NewGroupOp(client).Read(ctx, group.ID.Value)
+	require.NoError(t, err)
+	require.NoError(t, apigw.NewGroupOp(client).Delete(ctx, group.ID.Value))
+	_, err = apigw.NewGroupOp(client).Read(ctx, group.ID.Value)
+	require.True(t, apigw.IsNotFound(err))
+
+	got := spans.GetSpans()
+	require.Len(t, got, 4)
+	names := []string{got[0].Name, got[1].Name, got[2].Name, got[3].Name}
+	assert.Equal(t, []string{"Group.Create", "Group.Read", "Group.Delete", "Group.Read"}, names)
+
+	read := got[1]
+	assert.Equal(t, trace.SpanKindClient, read.SpanKind)
+	assert.Contains(t, read.Attributes, telemetry.AttrOperationID.String(string(v1.GetGroupOperation)))
+	assert.Contains(t, read.Attributes, attribute.String("apigw.group.id", group.ID.Value.String()))
+	assert.Contains(t, read.Attributes, telemetry.AttrStatusCode.Int(http.StatusOK))
+	assert.Equal(t, codes.Unset, read.Status.Code)
+	assert.Equal(t, codes.Error, got[3].Status.Code)
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(ctx, &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	metrics := map[string]metricdata.Metrics{}
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		metrics[m.Name] = m
+	}
+
+	counts := map[string]int64{}
+	for _, dp := range metrics["apigw.client.requests"].Data.(metricdata.Sum[int64]).DataPoints {
+		op, _ := dp.Attributes.Value(telemetry.AttrOperation)
+		status, _ := dp.Attributes.Value(telemetry.AttrStatusCode)
+		counts[op.AsString()+" "+status.Emit()] += dp.Value
+	}
+	assert.Equal(t, map[string]int64{
+		"Group.Create 201": 1,
+		"Group.Read 200":   1,
+		"Group.Delete 204": 1,
+		"Group.Read 404":   1,
+	}, counts)
+
+	var observed uint64
+	for _, dp := range metrics["apigw.client.duration"].Data.(metricdata.Histogram[float64]).DataPoints {
+		observed += dp.Count
+	}
+	assert.Equal(t, uint64(4), observed)
+}
@@ -0,0 +1,215 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transform
+
+import (
+	"fmt"
+
+	v1 "github.com/sacloud/apigw-api-go/apis/v1"
+)
+
+// RequestBuilder リクエスト変換ルールのビルダー
+type RequestBuilder struct {
+	t    v1.RequestTransformation
+	errs errs
+}
+
+// Request リクエスト変換ルールの組み立てを開始する
+func Request() *RequestBuilder {
+	return &RequestBuilder{}
+}
+
+// SetMethod 上流に送るHTTPメソッドを変更する
+func (b *RequestBuilder) SetMethod(method v1.HTTPMethod) *RequestBuilder {
+	b.errs.check("SetMethod", string(method), method)
+	b.t.HttpMethod = v1.NewOptHTTPMethod(method)
+	return b
+}
+
+// AllowBody 指定したキー以外のボディのキーを削除する
+func (b *RequestBuilder) AllowBody(keys ...string) *RequestBuilder {
+	b.t.Allow.Set = true
+	for _, k := range keys {
+		b.errs.check("AllowBody", k, v1.JSONKey(k))
+		b.t.Allow.Value.Body = append(b.t.Allow.Value.Body, v1.JSONKey(k))
+	}
+	return b
+}
+
+func (b *RequestBuilder) remove() *v1.RequestRemoveDetail {
+	b.t.Remove.Set = true
+	return &b.t.Remove.Value
+}
+
+// RemoveHeader ヘッダを削除する
+func (b *RequestBuilder) RemoveHeader(keys ...string) *RequestBuilder {
+	r := b.remove()
+	for _, k := range keys {
+		b.errs.check("RemoveHeader", k, v1.RequestHeaderKey(k))
+		r.HeaderKeys = append(r.HeaderKeys, v1.RequestHeaderKey(k))
+	}
+	return b
+}
+
+// RemoveQuery クエリパラメータを削除する
+func (b *RequestBuilder) RemoveQuery(keys ...string) *RequestBuilder {
+	r := b.remove()
+	for _, k := range keys {
+		b.errs.check("RemoveQuery", k, v1.QueryParamKey(k))
+		r.QueryParams = append(r.QueryParams, v1.QueryParamKey(k))
+	}
+	return b
+}
+
+// RemoveBody ボディのキーを削除する
+func (b *RequestBuilder) RemoveBody(keys ...string) *RequestBuilder {
+	r := b.remove()
+	for _, k := range keys {
+		b.errs.check("RemoveBody", k, v1.JSONKey(k))
+		r.Body = append(r.Body, v1.JSONKey(k))
+	}
+	return b
+}
+
+func (b *RequestBuilder) rename() *v1.RequestRenameDetail {
+	b.t.Rename.Set = true
+	return &b.t.Rename.Value
+}
+
+// RenameHeader ヘッダの名前を変更する
+func (b *RequestBuilder) RenameHeader(from, to string) *RequestBuilder {
+	b.errs.check("RenameHeader", from, v1.RequestHeaderKey(from))
+	b.errs.check("RenameHeader", to, v1.RequestHeaderKey(to))
+	r := b.rename()
+	r.Headers = append(r.Headers, v1.RequestRenameDetailHeadersItem{
+		From: v1.NewOptRequestHeaderKey(v1.RequestHeaderKey(from)),
+		To:   v1.NewOptRequestHeaderKey(v1.RequestHeaderKey(to)),
+	})
+	return b
+}
+
+// RenameQuery クエリパラメータの名前を変更する
+func (b *RequestBuilder) RenameQuery(from, to string) *RequestBuilder {
+	b.errs.check("RenameQuery", from, v1.QueryParamKey(from))
+	b.errs.check("RenameQuery", to, v1.QueryParamKey(to))
+	r := b.rename()
+	r.QueryParams = append(r.QueryParams, v1.RequestRenameDetailQueryParamsItem{
+		From: v1.NewOptQueryParamKey(v1.QueryParamKey(from)),
+		To:   v1.NewOptQueryParamKey(v1.QueryParamKey(to)),
+	})
+	return b
+}
+
+// RenameBody ボディのキーの名前を変更する
+func (b *RequestBuilder) RenameBody(from, to string) *RequestBuilder {
+	b.errs.check("RenameBody", from, v1.JSONKey(from))
+	b.errs.check("RenameBody", to, v1.JSONKey(to))
+	r := b.rename()
+	r.Body = append(r.Body, v1.RequestRenameDetailBodyItem{
+		From: v1.NewOptJSONKey(v1.JSONKey(from)),
+		To:   v1.NewOptJSONKey(v1.JSONKey(to)),
+	})
+	return b
+}
+
+// ReplaceHeader 既存のヘッダの値を置き換える
+func (b *RequestBuilder) ReplaceHeader(key, value string) *RequestBuilder {
+	return b.header("ReplaceHeader", &b.t.Replace, key, value)
+}
+
+// ReplaceQuery 既存のクエリパラメータの値を置き換える
+func (b *RequestBuilder) ReplaceQuery(key, value string) *RequestBuilder {
+	return b.query("ReplaceQuery", &b.t.Replace, key, value)
+}
+
+// ReplaceBody 既存のボディのキーの値を置き換える
+func (b *RequestBuilder) ReplaceBody(key, value string) *RequestBuilder {
+	return b.body("ReplaceBody", &b.t.Replace, key, value)
+}
+
+// AddHeader ヘッダが存在しない場合に追加する
+func (b *RequestBuilder) AddHeader(key, value string) *RequestBuilder {
+	return b.header("AddHeader", &b.t.Add, key, value)
+}
+
+// AddQuery クエリパラメータが存在しない場合に追加する
+func (b *RequestBuilder) AddQuery(key, value string) *RequestBuilder {
+	return b.query("AddQuery", &b.t.Add, key, value)
+}
+
+// AddBody ボディのキーが存在しない場合に追加する
+func (b *RequestBuilder) AddBody(key, value string) *RequestBuilder {
+	return b.body("AddBody", &b.t.Add, key, value)
+}
+
+// AppendHeader ヘッダに値を追加する。ヘッダが存在しない場合は追加する
+func (b *RequestBuilder) AppendHeader(key, value string) *RequestBuilder {
+	return b.header("AppendHeader", &b.t.Append, key, value)
+}
+
+// AppendQuery クエリパラメータに値を追加する。クエリパラメータが存在しない場合は追加する
+func (b *RequestBuilder) AppendQuery(key, value string) *RequestBuilder {
+	return b.query("AppendQuery", &b.t.Append, key, value)
+}
+
+// AppendBody ボディのキーに値を追加する。キーが存在しない場合は追加する
+func (b *RequestBuilder) AppendBody(key, value string) *RequestBuilder {
+	return b.body("AppendBody", &b.t.Append, key, value)
+}
+
+func (b *RequestBuilder) header(method string, opt *v1.OptRequestModificationDetail, key, value string) *RequestBuilder {
+	b.errs.check(method, key, v1.RequestHeaderKey(key))
+	b.errs.check(method, value, v1.RequestHeaderValue(value))
+	opt.Set = true
+	opt.Value.Headers = append(opt.Value.Headers, v1.RequestModificationDetailHeadersItem{
+		Key:   v1.NewOptRequestHeaderKey(v1.RequestHeaderKey(key)),
+		Value: v1.NewOptRequestHeaderValue(v1.RequestHeaderValue(value)),
+	})
+	return b
+}
+
+func (b *RequestBuilder) query(method string, opt *v1.OptRequestModificationDetail, key, value string) *RequestBuilder {
+	b.errs.check(method, key, v1.QueryParamKey(key))
+	b.errs.check(method, value, v1.QueryParamValue(value))
+	opt.Set = true
+	opt.Value.QueryParams = append(opt.Value.QueryParams, v1.RequestModificationDetailQueryParamsItem{
+		Key:   v1.NewOptQueryParamKey(v1.QueryParamKey(key)),
+		Value: v1.NewOptQueryParamValue(v1.QueryParamValue(value)),
+	})
+	return b
+}
+
+func (b *RequestBuilder) body(method string, opt *v1.OptRequestModificationDetail, key, value string) *RequestBuilder {
+	b.errs.check(method, key, v1.JSONKey(key))
+	opt.Set = true
+	opt.Value.Body = append(opt.Value.Body, v1.RequestModificationDetailBodyItem{
+		Key:   v1.NewOptJSONKey(v1.JSONKey(key)),
+		Value: v1.NewOptString(value),
+	})
+	return b
+}
+
+// Build 組み立てたリクエスト変換ルールを返す。
+// 不正なキーや値を指定していた場合は、全てのエラーをまとめて返す
+func (b *RequestBuilder) Build() (*v1.RequestTransformation, error) {
+	if err := b.errs.err(); err != nil {
+		return nil, err
+	}
+	t := b.t
+	if err := t.Validate(); err != nil {
+		return nil, fmt.Errorf("transform: %w", err)
+	}
+	return &t, nil
+}
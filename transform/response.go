@@ -0,0 +1,221 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transform
+
+import (
+	"fmt"
+	"slices"
+
+	v1 "github.com/sacloud/apigw-api-go/apis/v1"
+)
+
+// ResponseBuilder レスポンス変換ルールのビルダー。
+// OnStatusで指定したステータスコードは、以降に追加した削除・リネーム・置換・追加のルールに適用される。
+// APIは削除・リネームなどの種類ごとに1つのステータスコードの条件しか持てないため、
+// 同じ種類のルールを異なる条件で追加した場合はBuildでエラーとなる
+type ResponseBuilder struct {
+	t      v1.ResponseTransformation
+	status []int
+	errs   errs
+}
+
+// Response レスポンス変換ルールの組み立てを開始する
+func Response() *ResponseBuilder {
+	return &ResponseBuilder{}
+}
+
+// OnStatus 以降に追加するルールを指定したステータスコードの場合のみ適用する。
+// 引数を省略すると全てのステータスコードに適用する
+func (b *ResponseBuilder) OnStatus(codes ...int) *ResponseBuilder {
+	for _, c := range codes {
+		if c < 100 || c > 999 {
+			b.errs.add("OnStatus", "invalid status code %d", c)
+		}
+	}
+	b.status = slices.Clone(codes)
+	return b
+}
+
+// condition ルールの種類ごとのステータスコードの条件を設定し、既存の条件と異なる場合はエラーとする
+func (b *ResponseBuilder) condition(method string, set *bool, status *[]int) {
+	if !*set {
+		*set = true
+		*status = slices.Clone(b.status)
+		return
+	}
+	if !slices.Equal(*status, b.status) {
+		b.errs.add(method, "condition on %s conflicts with condition on %s set by another rule of the same kind", statusText(b.status), statusText(*status))
+	}
+}
+
+func statusText(codes []int) string {
+	if len(codes) == 0 {
+		return "all status codes"
+	}
+	return fmt.Sprintf("status codes %v", codes)
+}
+
+// AllowJSON 指定したキー以外のJSONのキーを削除する。ステータスコードの条件は適用されない
+func (b *ResponseBuilder) AllowJSON(keys ...string) *ResponseBuilder {
+	b.t.Allow.Set = true
+	for _, k := range keys {
+		b.errs.check("AllowJSON", k, v1.JSONKey(k))
+		b.t.Allow.Value.JsonKeys = append(b.t.Allow.Value.JsonKeys, v1.JSONKey(k))
+	}
+	return b
+}
+
+func (b *ResponseBuilder) remove(method string) *v1.ResponseRemoveDetail {
+	b.condition(method, &b.t.Remove.Set, &b.t.Remove.Value.IfStatusCode)
+	return &b.t.Remove.Value
+}
+
+// RemoveHeader ヘッダを削除する
+func (b *ResponseBuilder) RemoveHeader(keys ...string) *ResponseBuilder {
+	r := b.remove("RemoveHeader")
+	for _, k := range keys {
+		b.errs.check("RemoveHeader", k, v1.ResponseHeaderKey(k))
+		r.HeaderKeys = append(r.HeaderKeys, v1.ResponseHeaderKey(k))
+	}
+	return b
+}
+
+// RemoveJSON JSONのキーを削除する。キーはピリオドで繋いでネストしたキーを指定できる
+func (b *ResponseBuilder) RemoveJSON(keys ...string) *ResponseBuilder {
+	r := b.remove("RemoveJSON")
+	for _, k := range keys {
+		b.errs.check("RemoveJSON", k, v1.JSONKey(k))
+		r.JsonKeys = append(r.JsonKeys, v1.JSONKey(k))
+	}
+	return b
+}
+
+func (b *ResponseBuilder) rename(method string) *v1.ResponseRenameDetail {
+	b.condition(method, &b.t.Rename.Set, &b.t.Rename.Value.IfStatusCode)
+	return &b.t.Rename.Value
+}
+
+// RenameHeader ヘッダの名前を変更する
+func (b *ResponseBuilder) RenameHeader(from, to string) *ResponseBuilder {
+	b.errs.check("RenameHeader", from, v1.ResponseHeaderKey(from))
+	b.errs.check("RenameHeader", to, v1.ResponseHeaderKey(to))
+	r := b.rename("RenameHeader")
+	r.Headers = append(r.Headers, v1.ResponseRenameDetailHeadersItem{
+		From: v1.NewOptResponseHeaderKey(v1.ResponseHeaderKey(from)),
+		To:   v1.NewOptResponseHeaderKey(v1.ResponseHeaderKey(to)),
+	})
+	return b
+}
+
+// RenameJSON JSONのキーの名前を変更する
+func (b *ResponseBuilder) RenameJSON(from, to string) *ResponseBuilder {
+	b.errs.check("RenameJSON", from, v1.JSONKey(from))
+	b.errs.check("RenameJSON", to, v1.JSONKey(to))
+	r := b.rename("RenameJSON")
+	r.JSON = append(r.JSON, v1.ResponseRenameDetailJSONItem{
+		From: v1.NewOptJSONKey(v1.JSONKey(from)),
+		To:   v1.NewOptJSONKey(v1.JSONKey(to)),
+	})
+	return b
+}
+
+func (b *ResponseBuilder) replace(method string) *v1.ResponseReplaceDetail {
+	b.condition(method, &b.t.Replace.Set, &b.t.Replace.Value.IfStatusCode)
+	return &b.t.Replace.Value
+}
+
+// ReplaceHeader 既存のヘッダの値を置き換える
+func (b *ResponseBuilder) ReplaceHeader(key, value string) *ResponseBuilder {
+	b.errs.check("ReplaceHeader", key, v1.ResponseHeaderKey(key))
+	b.errs.check("ReplaceHeader", value, v1.RequestHeaderValue(value))
+	r := b.replace("ReplaceHeader")
+	r.Headers = append(r.Headers, v1.ResponseReplaceDetailHeadersItem{
+		Key:   v1.NewOptResponseHeaderKey(v1.ResponseHeaderKey(key)),
+		Value: v1.NewOptRequestHeaderValue(v1.RequestHeaderValue(value)),
+	})
+	return b
+}
+
+// ReplaceJSON 既存のJSONのキーの値を置き換える
+func (b *ResponseBuilder) ReplaceJSON(key, value string) *ResponseBuilder {
+	b.errs.check("ReplaceJSON", key, v1.JSONKey(key))
+	r := b.replace("ReplaceJSON")
+	r.JSON = append(r.JSON, v1.ResponseReplaceDetailJSONItem{
+		Key:   v1.NewOptJSONKey(v1.JSONKey(key)),
+		Value: v1.NewOptString(value),
+	})
+	return b
+}
+
+// ReplaceBody ボディ全体を置き換える
+func (b *ResponseBuilder) ReplaceBody(body string) *ResponseBuilder {
+	r := b.replace("ReplaceBody")
+	r.Body = v1.NewOptString(body)
+	return b
+}
+
+// AddHeader ヘッダが存在しない場合に追加する
+func (b *ResponseBuilder) AddHeader(key, value string) *ResponseBuilder {
+	return b.header("AddHeader", &b.t.Add, key, value)
+}
+
+// AddJSON JSONのキーが存在しない場合に追加する
+func (b *ResponseBuilder) AddJSON(key, value string) *ResponseBuilder {
+	return b.json("AddJSON", &b.t.Add, key, value)
+}
+
+// AppendHeader ヘッダに値を追加する。ヘッダが存在しない場合は追加する
+func (b *ResponseBuilder) AppendHeader(key, value string) *ResponseBuilder {
+	return b.header("AppendHeader", &b.t.Append, key, value)
+}
+
+// AppendJSON JSONのキーに値を追加する。キーが存在しない場合は追加する
+func (b *ResponseBuilder) AppendJSON(key, value string) *ResponseBuilder {
+	return b.json("AppendJSON", &b.t.Append, key, value)
+}
+
+func (b *ResponseBuilder) header(method string, opt *v1.OptResponseModificationDetail, key, value string) *ResponseBuilder {
+	b.errs.check(method, key, v1.ResponseHeaderKey(key))
+	b.errs.check(method, value, v1.RequestHeaderValue(value))
+	b.condition(method, &opt.Set, &opt.Value.IfStatusCode)
+	opt.Value.Headers = append(opt.Value.Headers, v1.ResponseModificationDetailHeadersItem{
+		Key:   v1.NewOptResponseHeaderKey(v1.ResponseHeaderKey(key)),
+		Value: v1.NewOptRequestHeaderValue(v1.RequestHeaderValue(value)),
+	})
+	return b
+}
+
+func (b *ResponseBuilder) json(method string, opt *v1.OptResponseModificationDetail, key, value string) *ResponseBuilder {
+	b.errs.check(method, key, v1.JSONKey(key))
+	b.condition(method, &opt.Set, &opt.Value.IfStatusCode)
+	opt.Value.JSON = append(opt.Value.JSON, v1.ResponseModificationDetailJSONItem{
+		Key:   v1.NewOptJSONKey(v1.JSONKey(key)),
+		Value: v1.NewOptString(value),
+	})
+	return b
+}
+
+// Build 組み立てたレスポンス変換ルールを返す。
+// 不正なキーや値、矛盾するステータスコードの条件を指定していた場合は、全てのエラーをまとめて返す
+func (b *ResponseBuilder) Build() (*v1.ResponseTransformation, error) {
+	if err := b.errs.err(); err != nil {
+		return nil, err
+	}
+	t := b.t
+	if err := t.Validate(); err != nil {
+		return nil, fmt.Errorf("transform: %w", err)
+	}
+	return &t, nil
+}
@@ -0,0 +1,403 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transform
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	v1 "github.com/sacloud/apigw-api-go/apis/v1"
+)
+
+// ApplyRequest リクエスト変換ルールをrに適用する。ゲートウェイと同じく次の順に適用する。
+//
+//   - ボディのキーの許可(allow)
+//   - 削除(remove)、リネーム(rename)、置換(replace)、追加(add)、値の追加(append)。それぞれヘッダ、クエリパラメータ、ボディの順
+//   - HTTPメソッドの変更
+//
+// ボディはContent-TypeがJSONで、JSONオブジェクトとして解釈できる場合のみ変換する
+func ApplyRequest(t *v1.RequestTransformation, r *http.Request) error {
+	allow := t.Allow.Value.Body
+	remove := t.Remove.Value
+	rename := t.Rename.Value
+	replace, add, appends := t.Replace.Value, t.Add.Value, t.Append.Value
+
+	if r.Header == nil {
+		r.Header = http.Header{}
+	}
+	for _, k := range remove.HeaderKeys {
+		r.Header.Del(string(k))
+	}
+	for _, item := range rename.Headers {
+		renameHeader(r.Header, string(item.From.Value), string(item.To.Value))
+	}
+	for _, item := range replace.Headers {
+		replaceHeader(r.Header, string(item.Key.Value), string(item.Value.Value))
+	}
+	for _, item := range add.Headers {
+		addHeader(r.Header, string(item.Key.Value), string(item.Value.Value))
+	}
+	for _, item := range appends.Headers {
+		r.Header.Add(string(item.Key.Value), string(item.Value.Value))
+	}
+
+	query := r.URL.Query()
+	for _, k := range remove.QueryParams {
+		query.Del(string(k))
+	}
+	for _, item := range rename.QueryParams {
+		if from := string(item.From.Value); query.Has(from) {
+			query[string(item.To.Value)] = query[from]
+			query.Del(from)
+		}
+	}
+	for _, item := range replace.QueryParams {
+		if k := string(item.Key.Value); query.Has(k) {
+			query.Set(k, string(item.Value.Value))
+		}
+	}
+	for _, item := range add.QueryParams {
+		if k := string(item.Key.Value); !query.Has(k) {
+			query.Set(k, string(item.Value.Value))
+		}
+	}
+	for _, item := range appends.QueryParams {
+		query.Add(string(item.Key.Value), string(item.Value.Value))
+	}
+	if len(remove.QueryParams) > 0 || len(rename.QueryParams) > 0 || len(replace.QueryParams) > 0 ||
+		len(add.QueryParams) > 0 || len(appends.QueryParams) > 0 {
+		r.URL.RawQuery = query.Encode()
+	}
+
+	if len(allow) > 0 || len(remove.Body) > 0 || len(rename.Body) > 0 || len(replace.Body) > 0 || len(add.Body) > 0 || len(appends.Body) > 0 {
+		body, err := readBody(r.Body)
+		if err != nil {
+			return err
+		}
+		if obj, ok := parseObject(r.Header, body); ok {
+			if len(allow) > 0 {
+				obj = obj.allow(allow)
+			}
+			for _, k := range remove.Body {
+				obj.remove(string(k))
+			}
+			for _, item := range rename.Body {
+				obj.rename(string(item.From.Value), string(item.To.Value))
+			}
+			for _, item := range replace.Body {
+				obj.replace(string(item.Key.Value), item.Value.Value)
+			}
+			for _, item := range add.Body {
+				obj.add(string(item.Key.Value), item.Value.Value)
+			}
+			for _, item := range appends.Body {
+				obj.append(string(item.Key.Value), item.Value.Value)
+			}
+			if body, err = json.Marshal(obj); err != nil {
+				return err
+			}
+		}
+		setRequestBody(r, body)
+	}
+
+	if method, ok := t.HttpMethod.Get(); ok {
+		r.Method = string(method)
+	}
+	return nil
+}
+
+// ApplyResponse レスポンス変換ルールをrに適用する。ゲートウェイと同じく次の順に適用する。
+//
+//   - 削除(remove)、リネーム(rename)、置換(replace)、追加(add)、値の追加(append)。それぞれヘッダ、JSONの順
+//   - JSONのキーの許可(allow)
+//
+// IfStatusCodeを指定したルールはr.StatusCodeが一致する場合のみ適用する。
+// replaceのBodyを適用した場合はボディ全体を置き換え、JSONの変換は行わない。
+// JSONはContent-TypeがJSONで、JSONオブジェクトとして解釈できる場合のみ変換する。キーはピリオドで繋いでネストしたキーを指定できる
+func ApplyResponse(t *v1.ResponseTransformation, r *http.Response) error {
+	match := func(set bool, codes []int) bool {
+		return set && (len(codes) == 0 || slices.Contains(codes, r.StatusCode))
+	}
+	var (
+		remove  = t.Remove.Value
+		rename  = t.Rename.Value
+		replace = t.Replace.Value
+		add     = t.Add.Value
+		appends = t.Append.Value
+		allow   = t.Allow.Value.JsonKeys
+	)
+	doRemove := match(t.Remove.Set, remove.IfStatusCode)
+	doRename := match(t.Rename.Set, rename.IfStatusCode)
+	doReplace := match(t.Replace.Set, replace.IfStatusCode)
+	doAdd := match(t.Add.Set, add.IfStatusCode)
+	doAppend := match(t.Append.Set, appends.IfStatusCode)
+
+	if r.Header == nil {
+		r.Header = http.Header{}
+	}
+	if doRemove {
+		for _, k := range remove.HeaderKeys {
+			r.Header.Del(string(k))
+		}
+	}
+	if doRename {
+		for _, item := range rename.Headers {
+			renameHeader(r.Header, string(item.From.Value), string(item.To.Value))
+		}
+	}
+	if doReplace {
+		for _, item := range replace.Headers {
+			replaceHeader(r.Header, string(item.Key.Value), string(item.Value.Value))
+		}
+	}
+	if doAdd {
+		for _, item := range add.Headers {
+			addHeader(r.Header, string(item.Key.Value), string(item.Value.Value))
+		}
+	}
+	if doAppend {
+		for _, item := range appends.Headers {
+			r.Header.Add(string(item.Key.Value), string(item.Value.Value))
+		}
+	}
+
+	if doReplace && replace.Body.Set {
+		if r.Body != nil {
+			_ = r.Body.Close()
+		}
+		setResponseBody(r, []byte(replace.Body.Value))
+		return nil
+	}
+	hasJSON := (doRemove && len(remove.JsonKeys) > 0) || (doRename && len(rename.JSON) > 0) ||
+		(doReplace && len(replace.JSON) > 0) || (doAdd && len(add.JSON) > 0) ||
+		(doAppend && len(appends.JSON) > 0) || len(allow) > 0
+	if !hasJSON {
+		return nil
+	}
+	body, err := readBody(r.Body)
+	if err != nil {
+		return err
+	}
+	if obj, ok := parseObject(r.Header, body); ok {
+		if doRemove {
+			for _, k := range remove.JsonKeys {
+				obj.remove(string(k))
+			}
+		}
+		if doRename {
+			for _, item := range rename.JSON {
+				obj.rename(string(item.From.Value), string(item.To.Value))
+			}
+		}
+		if doReplace {
+			for _, item := range replace.JSON {
+				obj.replace(string(item.Key.Value), item.Value.Value)
+			}
+		}
+		if doAdd {
+			for _, item := range add.JSON {
+				obj.add(string(item.Key.Value), item.Value.Value)
+			}
+		}
+		if doAppend {
+			for _, item := range appends.JSON {
+				obj.append(string(item.Key.Value), item.Value.Value)
+			}
+		}
+		if len(allow) > 0 {
+			obj = obj.allow(allow)
+		}
+		if body, err = json.Marshal(obj); err != nil {
+			return err
+		}
+	}
+	setResponseBody(r, body)
+	return nil
+}
+
+func renameHeader(h http.Header, from, to string) {
+	if values := h.Values(from); len(values) > 0 {
+		h.Del(from)
+		h[http.CanonicalHeaderKey(to)] = values
+	}
+}
+
+func replaceHeader(h http.Header, key, value string) {
+	if len(h.Values(key)) > 0 {
+		h.Set(key, value)
+	}
+}
+
+func addHeader(h http.Header, key, value string) {
+	if len(h.Values(key)) == 0 {
+		h.Set(key, value)
+	}
+}
+
+func readBody(body io.ReadCloser) ([]byte, error) {
+	if body == nil || body == http.NoBody {
+		return nil, nil
+	}
+	data, err := io.ReadAll(body)
+	_ = body.Close()
+	return data, err
+}
+
+func setRequestBody(r *http.Request, body []byte) {
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	r.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+	r.ContentLength = int64(len(body))
+	if r.Header.Get("Content-Length") != "" {
+		r.Header.Set("Content-Length", strconv.Itoa(len(body)))
+	}
+}
+
+func setResponseBody(r *http.Response, body []byte) {
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	r.ContentLength = int64(len(body))
+	if r.Header.Get("Content-Length") != "" {
+		r.Header.Set("Content-Length", strconv.Itoa(len(body)))
+	}
+}
+
+// object JSONオブジェクトのボディ
+type object map[string]any
+
+// parseObject Content-TypeがJSONの場合にボディをJSONオブジェクトとして読み込む。
+// 空のボディは空のオブジェクトとして扱う
+func parseObject(h http.Header, body []byte) (object, bool) {
+	mediaType, _, err := mime.ParseMediaType(h.Get("Content-Type"))
+	if err != nil || (mediaType != "application/json" && !strings.HasSuffix(mediaType, "+json")) {
+		return nil, false
+	}
+	obj := object{}
+	if len(bytes.TrimSpace(body)) == 0 {
+		return obj, true
+	}
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	if err := dec.Decode(&obj); err != nil {
+		return nil, false
+	}
+	return obj, true
+}
+
+// splitKey ピリオドで繋いだキーを分割する。バックスラッシュでエスケープしたピリオドは分割しない
+func splitKey(key string) []string {
+	var parts []string
+	var b strings.Builder
+	for i := 0; i < len(key); i++ {
+		switch {
+		case key[i] == '\\' && i+1 < len(key) && key[i+1] == '.':
+			b.WriteByte('.')
+			i++
+		case key[i] == '.':
+			parts = append(parts, b.String())
+			b.Reset()
+		default:
+			b.WriteByte(key[i])
+		}
+	}
+	return append(parts, b.String())
+}
+
+// parent keyの親のオブジェクトと末尾のキーを返す。createの場合は途中のオブジェクトを作成する
+func (o object) parent(key string, create bool) (map[string]any, string, bool) {
+	parts := splitKey(key)
+	m := map[string]any(o)
+	for _, p := range parts[:len(parts)-1] {
+		child, ok := m[p].(map[string]any)
+		if !ok {
+			if !create {
+				return nil, "", false
+			}
+			child = map[string]any{}
+			m[p] = child
+		}
+		m = child
+	}
+	return m, parts[len(parts)-1], true
+}
+
+func (o object) get(key string) (any, bool) {
+	m, name, ok := o.parent(key, false)
+	if !ok {
+		return nil, false
+	}
+	v, ok := m[name]
+	return v, ok
+}
+
+func (o object) set(key string, value any) {
+	m, name, _ := o.parent(key, true)
+	m[name] = value
+}
+
+func (o object) remove(key string) {
+	if m, name, ok := o.parent(key, false); ok {
+		delete(m, name)
+	}
+}
+
+func (o object) rename(from, to string) {
+	if v, ok := o.get(from); ok {
+		o.remove(from)
+		o.set(to, v)
+	}
+}
+
+func (o object) replace(key string, value string) {
+	if _, ok := o.get(key); ok {
+		o.set(key, value)
+	}
+}
+
+func (o object) add(key string, value string) {
+	if _, ok := o.get(key); !ok {
+		o.set(key, value)
+	}
+}
+
+// append 既存の値を配列にして値を追加する
+func (o object) append(key string, value string) {
+	v, _ := o.get(key)
+	switch v := v.(type) {
+	case nil:
+		o.set(key, value)
+	case []any:
+		o.set(key, append(v, value))
+	default:
+		o.set(key, []any{v, value})
+	}
+}
+
+// allow 指定したキーのみを残したオブジェクトを返す
+func (o object) allow(keys []v1.JSONKey) object {
+	allowed := object{}
+	for _, k := range keys {
+		if v, ok := o.get(string(k)); ok {
+			allowed.set(string(k), v)
+		}
+	}
+	return allowed
+}
@@ -0,0 +1,140 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transform_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	v1 "github.com/sacloud/apigw-api-go/apis/v1"
+	"github.com/sacloud/apigw-api-go/transform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyRequest(t *testing.T) {
+	rules, err := transform.Request().
+		AllowBody("name", "debug", "tags", "q").
+		RemoveHeader("X-Debug").
+		RemoveBody("debug").
+		RenameHeader("X-Old", "X-New").
+		RenameQuery("q", "query").
+		RenameBody("q", "query").
+		ReplaceHeader("User-Agent", "gateway").
+		ReplaceQuery("page", "1").
+		ReplaceQuery("missing", "x").
+		ReplaceBody("name", "replaced").
+		AddHeader("X-Source", "gateway").
+		AddHeader("Accept", "text/plain").
+		AddQuery("lang", "ja").
+		AddBody("name", "ignored").
+		AddBody("source", "gateway").
+		AppendHeader("Accept", "application/xml").
+		AppendQuery("tag", "b").
+		AppendBody("tags", "b").
+		SetMethod(v1.HTTPMethodPOST).
+		Build()
+	require.NoError(t, err)
+
+	r := httptest.NewRequest(http.MethodGet, "/api?q=go&page=3&tag=a",
+		strings.NewReader(`{"name":"alice","debug":true,"tags":"a","q":"x","secret":"s"}`))
+	r.Header.Set("Content-Type", "application/json")
+	r.Header.Set("X-Debug", "1")
+	r.Header.Set("X-Old", "old")
+	r.Header.Set("User-Agent", "curl")
+	r.Header.Set("Accept", "application/json")
+	require.NoError(t, transform.ApplyRequest(rules, r))
+
+	assert.Equal(t, http.MethodPost, r.Method)
+	assert.Empty(t, r.Header.Get("X-Debug"))
+	assert.Empty(t, r.Header.Get("X-Old"))
+	assert.Equal(t, "old", r.Header.Get("X-New"))
+	assert.Equal(t, "gateway", r.Header.Get("User-Agent"))
+	assert.Equal(t, "gateway", r.Header.Get("X-Source"))
+	assert.Equal(t, []string{"application/json", "application/xml"}, r.Header.Values("Accept"))
+	assert.Equal(t, "lang=ja&page=1&query=go&tag=a&tag=b", r.URL.RawQuery)
+
+	body, err := io.ReadAll(r.Body)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"name":"replaced","tags":["a","b"],"query":"x","source":"gateway"}`, string(body))
+	assert.Equal(t, int64(len(body)), r.ContentLength)
+}
+
+func TestApplyRequest_NonJSON(t *testing.T) {
+	rules, err := transform.Request().RemoveBody("debug").Build()
+	require.NoError(t, err)
+
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("debug=true"))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	require.NoError(t, transform.ApplyRequest(rules, r))
+	body, err := io.ReadAll(r.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "debug=true", string(body))
+}
+
+func TestApplyRequest_NilHeader(t *testing.T) {
+	rules, err := transform.Request().AddHeader("X-Source", "gateway").AppendHeader("Accept", "text/plain").Build()
+	require.NoError(t, err)
+
+	u, err := url.Parse("http://example.com/api")
+	require.NoError(t, err)
+	r := &http.Request{URL: u}
+	require.NoError(t, transform.ApplyRequest(rules, r))
+	assert.Equal(t, "gateway", r.Header.Get("X-Source"))
+	assert.Equal(t, "text/plain", r.Header.Get("Accept"))
+}
+
+func TestApplyResponse(t *testing.T) {
+	rules, err := transform.Response().
+		AllowJSON("data", "error").
+		RemoveHeader("Server").
+		RemoveJSON("data.internal").
+		RenameJSON("data.id", "data.userId").
+		AddJSON("data.meta.source", "gateway").
+		OnStatus(404).
+		ReplaceBody(`{"error":"not found"}`).
+		ReplaceHeader("Content-Type", "application/problem+json").
+		Build()
+	require.NoError(t, err)
+
+	newResponse := func(status int) *http.Response {
+		rec := httptest.NewRecorder()
+		rec.Header().Set("Content-Type", "application/json")
+		rec.Header().Set("Server", "upstream")
+		rec.WriteHeader(status)
+		_, _ = rec.WriteString(`{"data":{"id":1,"internal":"x"},"debug":true}`)
+		return rec.Result()
+	}
+
+	res := newResponse(http.StatusOK)
+	require.NoError(t, transform.ApplyResponse(rules, res))
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"data":{"userId":1,"meta":{"source":"gateway"}}}`, string(body))
+	assert.Empty(t, res.Header.Get("Server"))
+	assert.Equal(t, "application/json", res.Header.Get("Content-Type"))
+
+	// 404の場合のみボディを置き換える
+	res = newResponse(http.StatusNotFound)
+	require.NoError(t, transform.ApplyResponse(rules, res))
+	body, err = io.ReadAll(res.Body)
+	require.NoError(t, err)
+	assert.Equal(t, `{"error":"not found"}`, string(body))
+	assert.Equal(t, "application/problem+json", res.Header.Get("Content-Type"))
+}
@@ -0,0 +1,49 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package transform ルートのリクエスト・レスポンス変換ルールを組み立てるビルダー。
+// キーや値の形式はビルダーで検証し、RouteExtraAPI.UpdateRequestTransformation/UpdateResponseTransformationにそのまま渡せる値を返す。
+// ApplyRequest/ApplyResponseはゲートウェイと同じ規則で変換ルールを*http.Request/*http.Responseに適用するため、
+// ルールをデプロイする前にテストできる。
+//
+//	req, err := transform.Request().RemoveHeader("X-Debug").RenameQuery("q", "query").SetMethod(v1.HTTPMethodPOST).Build()
+//	res, err := transform.Response().OnStatus(404).ReplaceBody(`{"error":"not found"}`).Build()
+package transform
+
+import (
+	"errors"
+	"fmt"
+)
+
+// validator 生成されたキー・値の型が実装する検証メソッド
+type validator interface {
+	Validate() error
+}
+
+// errs 組み立て中に検出したエラーを保持する。エラーはBuildでまとめて返す
+type errs []error
+
+func (e *errs) check(method string, value string, v validator) {
+	if err := v.Validate(); err != nil {
+		*e = append(*e, fmt.Errorf("transform: %s: %q: %w", method, value, err))
+	}
+}
+
+func (e *errs) add(method string, format string, args ...any) {
+	*e = append(*e, fmt.Errorf("transform: %s: "+format, append([]any{method}, args...)...))
+}
+
+func (e errs) err() error {
+	return errors.Join(e...)
+}
@@ -0,0 +1,95 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transform_test
+
+import (
+	"testing"
+
+	v1 "github.com/sacloud/apigw-api-go/apis/v1"
+	"github.com/sacloud/apigw-api-go/transform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequest(t *testing.T) {
+	req, err := transform.Request().
+		RemoveHeader("X-Debug").
+		RenameQuery("q", "query").
+		AddBody("k", "v").
+		AddHeader("X-Source", "gateway").
+		SetMethod(v1.HTTPMethodPOST).
+		Build()
+	require.NoError(t, err)
+
+	data, err := req.MarshalJSON()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"httpMethod": "POST",
+		"remove": {"headerKeys": ["X-Debug"]},
+		"rename": {"queryParams": [{"from": "q", "to": "query"}]},
+		"add": {
+			"headers": [{"key": "X-Source", "value": "gateway"}],
+			"body": [{"key": "k", "value": "v"}]
+		}
+	}`, string(data))
+}
+
+func TestRequest_Invalid(t *testing.T) {
+	_, err := transform.Request().
+		RemoveHeader("X Debug").
+		RenameBody("a..b", "c").
+		AddHeader("X-Ok", "\n").
+		SetMethod("FETCH").
+		Build()
+	require.Error(t, err)
+	assert.ErrorContains(t, err, `RemoveHeader: "X Debug"`)
+	assert.ErrorContains(t, err, `RenameBody: "a..b"`)
+	assert.ErrorContains(t, err, `AddHeader: "\n"`)
+	assert.ErrorContains(t, err, `SetMethod: "FETCH"`)
+}
+
+func TestResponse(t *testing.T) {
+	res, err := transform.Response().
+		RemoveHeader("Server").
+		OnStatus(404).
+		ReplaceBody(`{"error":"not found"}`).
+		RenameJSON("a.b", "c").
+		OnStatus().
+		AddHeader("X-Gateway", "apigw").
+		Build()
+	require.NoError(t, err)
+
+	data, err := res.MarshalJSON()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"remove": {"headerKeys": ["Server"]},
+		"replace": {"ifStatusCode": [404], "body": "{\"error\":\"not found\"}"},
+		"rename": {"ifStatusCode": [404], "json": [{"from": "a.b", "to": "c"}]},
+		"add": {"headers": [{"key": "X-Gateway", "value": "apigw"}]}
+	}`, string(data))
+}
+
+func TestResponse_Invalid(t *testing.T) {
+	_, err := transform.Response().
+		RemoveHeader("X_Debug").
+		OnStatus(42).
+		OnStatus(500).
+		RemoveJSON("secret").
+		Build()
+	require.Error(t, err)
+	assert.ErrorContains(t, err, `RemoveHeader: "X_Debug"`)
+	assert.ErrorContains(t, err, "invalid status code 42")
+	assert.ErrorContains(t, err, "RemoveJSON: condition on status codes [500] conflicts with condition on all status codes")
+}
@@ -18,6 +18,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"iter"
 
 	"github.com/google/uuid"
 	v1 "github.com/sacloud/apigw-api-go/apis/v1"
@@ -25,10 +26,22 @@ import (
 
 type UserAPI interface {
 	List(ctx context.Context) ([]v1.User, error)
+	// All Listの結果を1件ずつ返すイテレータ。取得に失敗した場合はエラーを1度だけ返す
+	All(ctx context.Context) iter.Seq2[v1.User, error]
 	Create(ctx context.Context, request *v1.UserDetail) (*v1.UserDetail, error)
 	Read(ctx context.Context, id uuid.UUID) (*v1.UserDetail, error)
 	Update(ctx context.Context, request *v1.UserDetail, id uuid.UUID) error
 	Delete(ctx context.Context, id uuid.UUID) error
+	// FindByName nameが一致するものをすべて返す
+	FindByName(ctx context.Context, name string) ([]v1.User, error)
+	// GetByName nameが一致するものを1件返す。見つからない場合は*NotFoundError、複数ある場合は*AmbiguousErrorを返す
+	GetByName(ctx context.Context, name string) (*v1.User, error)
+	// Resolve IDまたはnameがidOrNameと一致するものを1件返す
+	Resolve(ctx context.Context, idOrName string) (*v1.User, error)
+	// Ensure 名前がdesiredと一致するものがなければ作成し、あればdesiredで設定した項目に差分がある場合のみ更新する。
+	// 作成・更新した場合は改めて取得した結果を返す。作成・更新後の取得に失敗した場合もEnsureActionは行った操作を示す
+	// desiredの名前が空の場合はErrorKindInvalidのエラーを返す
+	Ensure(ctx context.Context, desired *v1.UserDetail) (*v1.UserDetail, EnsureAction, error)
 }
 
 var _ UserAPI = (*userOp)(nil)
@@ -59,7 +72,61 @@ func (op *userOp) List(ctx context.Context) ([]v1.User, error) {
 	return nil, NewAPIError("User.List", 0, nil)
 }
 
+func (op *userOp) All(ctx context.Context) iter.Seq2[v1.User, error] {
+	return all(ctx, op.List)
+}
+
+func userLookup(list func(context.Context) ([]v1.User, error)) lookup[v1.User] {
+	return lookup[v1.User]{
+		resource: "user",
+		list:     list,
+		name:     func(u *v1.User) string { return string(u.Name) },
+		id:       func(u *v1.User) uuid.UUID { return u.ID.Value },
+	}
+}
+
+func (op *userOp) FindByName(ctx context.Context, name string) ([]v1.User, error) {
+	return userLookup(op.List).find(ctx, name)
+}
+
+func (op *userOp) GetByName(ctx context.Context, name string) (*v1.User, error) {
+	return userLookup(op.List).get(ctx, name)
+}
+
+func (op *userOp) Resolve(ctx context.Context, idOrName string) (*v1.User, error) {
+	return userLookup(op.List).resolve(ctx, idOrName)
+}
+
+func (op *userOp) Ensure(ctx context.Context, desired *v1.UserDetail) (*v1.UserDetail, EnsureAction, error) {
+	return ensurer[*v1.UserDetail, *v1.UserDetail, v1.UserDetail, *v1.UserDetail]{
+		method: "User.Ensure",
+		find: func(ctx context.Context, name string) (uuid.UUID, *v1.UserDetail, error) {
+			found, err := op.GetByName(ctx, name)
+			if err != nil {
+				return uuid.Nil, nil, err
+			}
+			current, err := op.Read(ctx, found.ID.Value)
+			return found.ID.Value, current, err
+		},
+		create: func(ctx context.Context, desired *v1.UserDetail) (uuid.UUID, error) {
+			created, err := op.Create(ctx, desired)
+			if err != nil {
+				return uuid.Nil, err
+			}
+			return created.ID.Value, nil
+		},
+		read:   op.Read,
+		update: op.Update,
+		omit:   []string{"groups"},
+	}.ensure(ctx, string(desired.Name), desired)
+}
+
 func (op *userOp) Create(ctx context.Context, request *v1.UserDetail) (*v1.UserDetail, error) {
+	if err := validateRequest("User.Create", request, func() []FieldError {
+		return checkIpRestriction("ipRestrictionConfig", request.IpRestrictionConfig)
+	}); err != nil {
+		return nil, err
+	}
 	res, err := op.client.AddUser(ctx, request)
 	if err != nil {
 		return nil, NewAPIError("User.Create", 0, err)
@@ -100,6 +167,11 @@ func (op *userOp) Read(ctx context.Context, id uuid.UUID) (*v1.UserDetail, error
 }
 
 func (op *userOp) Update(ctx context.Context, request *v1.UserDetail, id uuid.UUID) error {
+	if err := validateRequest("User.Update", request, func() []FieldError {
+		return checkIpRestriction("ipRestrictionConfig", request.IpRestrictionConfig)
+	}); err != nil {
+		return err
+	}
 	res, err := op.client.UpdateUser(ctx, request, v1.UpdateUserParams{UserId: id})
 	if err != nil {
 		return NewAPIError("User.Update", 0, err)
@@ -227,6 +299,9 @@ func (op *userExtraOp) ReadAuth(ctx context.Context) (*v1.UserAuthentication, er
 }
 
 func (op *userExtraOp) UpdateAuth(ctx context.Context, request v1.UserAuthentication) error {
+	if err := validateRequest("UserExtra.UpdateAuth", &request); err != nil {
+		return err
+	}
 	res, err := op.client.UpsertUserAuthentication(ctx, v1.NewOptUserAuthentication(request),
 		v1.UpsertUserAuthenticationParams{UserId: op.userId})
 	if err != nil {
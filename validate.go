@@ -0,0 +1,167 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apigw
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/ogen-go/ogen/validate"
+	v1 "github.com/sacloud/apigw-api-go/apis/v1"
+)
+
+// FieldError 検証に失敗した項目
+type FieldError struct {
+	// Path 項目の位置。オブジェクトのキーを"."、配列の要素を"[i]"でつないだ形式。"ipRestrictionConfig.ips[0]"など
+	Path    string
+	Message string
+}
+
+func (f FieldError) String() string {
+	if f.Path == "" {
+		return f.Message
+	}
+	return f.Path + ": " + f.Message
+}
+
+// ValidationError 送信前のリクエストの検証に失敗したことを表す。
+// 生成コードのValidateによる検証と、OpenAPIの定義では表現できないこのライブラリ独自の検証の結果を含む
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, 0, len(e.Fields))
+	for _, f := range e.Fields {
+		msgs = append(msgs, f.String())
+	}
+	return "invalid request: " + strings.Join(msgs, "; ")
+}
+
+// IsInvalid errが送信前のリクエストの検証に失敗したことによるものかどうか
+func IsInvalid(err error) bool {
+	var e *ValidationError
+	return errors.As(err, &e)
+}
+
+type validator interface {
+	Validate() error
+}
+
+// validateRequest 操作methodのリクエストを送信前に検証する。
+// checksはrequestがnilでない場合のみ呼び出し、生成コードでは表現できない検証の結果を返す
+func validateRequest(method string, request validator, checks ...func() []FieldError) error {
+	if v := reflect.ValueOf(request); v.Kind() == reflect.Pointer && v.IsNil() {
+		return invalid(method, []FieldError{{Message: "request is nil"}})
+	}
+
+	fields := fieldErrors("", request.Validate())
+	for _, check := range checks {
+		for _, f := range check() {
+			// 生成コードが同じ項目のエラーを報告している場合はそちらを優先する
+			if !slices.ContainsFunc(fields, func(g FieldError) bool { return g.Path == f.Path }) {
+				fields = append(fields, f)
+			}
+		}
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+	return invalid(method, fields)
+}
+
+func invalid(method string, fields []FieldError) *Error {
+	return &Error{msg: method, err: &ValidationError{Fields: fields}, op: method, kind: ErrorKindInvalid}
+}
+
+// fieldErrors ogenの検証エラーを項目ごとのエラーに展開する
+func fieldErrors(prefix string, err error) []FieldError {
+	if err == nil {
+		return nil
+	}
+	var verr *validate.Error
+	if !errors.As(err, &verr) {
+		return []FieldError{{Path: prefix, Message: err.Error()}}
+	}
+
+	var ret []FieldError
+	for _, f := range verr.Fields {
+		path := f.Name
+		switch {
+		case prefix == "":
+		case strings.HasPrefix(f.Name, "["):
+			path = prefix + f.Name
+		default:
+			path = prefix + "." + f.Name
+		}
+		ret = append(ret, fieldErrors(path, f.Error)...)
+	}
+	return ret
+}
+
+// checkHost 転送先のホストがプライベートアドレスやループバックアドレスでないことを検証する
+func checkHost(path, host string) []FieldError {
+	if strings.EqualFold(host, "localhost") {
+		return []FieldError{{Path: path, Message: "must not be localhost"}}
+	}
+	addr, err := netip.ParseAddr(strings.Trim(host, "[]"))
+	if err != nil {
+		return nil
+	}
+	addr = addr.Unmap()
+	switch {
+	case addr.IsLoopback():
+		return []FieldError{{Path: path, Message: fmt.Sprintf("must not be a loopback address: %s", host)}}
+	case addr.IsPrivate(), addr.IsLinkLocalUnicast(), addr.IsUnspecified():
+		return []FieldError{{Path: path, Message: fmt.Sprintf("must not be a private address: %s", host)}}
+	}
+	return nil
+}
+
+// checkIpRestriction IP制限の対象がIPv4アドレスのみであることを検証する
+func checkIpRestriction(path string, config v1.OptIpRestrictionConfig) []FieldError {
+	if !config.Set {
+		return nil
+	}
+	var ret []FieldError
+	for i, ip := range config.Value.Ips {
+		if addr, err := netip.ParseAddr(ip); err != nil || !addr.Is4() {
+			ret = append(ret, FieldError{Path: fmt.Sprintf("%s.ips[%d]", path, i), Message: fmt.Sprintf("must be an IPv4 address: %q", ip)})
+		}
+	}
+	return ret
+}
+
+// objectStorageMethods オブジェクトストレージを転送先とするサービスのルートで使用できるメソッド
+var objectStorageMethods = []v1.HTTPMethod{v1.HTTPMethodGET, v1.HTTPMethodHEAD, v1.HTTPMethodOPTIONS}
+
+// checkObjectStorageRoute オブジェクトストレージを転送先とするサービスのルートが読み取り系のメソッドのみであることを検証する。
+// メソッドを省略した場合は全てのメソッドが対象となるため、エラーとする
+func checkObjectStorageRoute(methods []v1.HTTPMethod) []FieldError {
+	if len(methods) == 0 {
+		return []FieldError{{Path: "methods", Message: "must be specified for an object storage service; only GET, HEAD and OPTIONS are allowed"}}
+	}
+	var ret []FieldError
+	for i, m := range methods {
+		if !slices.Contains(objectStorageMethods, m) {
+			ret = append(ret, FieldError{Path: fmt.Sprintf("methods[%d]", i), Message: fmt.Sprintf("%s is not allowed for an object storage service; only GET, HEAD and OPTIONS are allowed", m)})
+		}
+	}
+	return ret
+}
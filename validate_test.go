@@ -0,0 +1,157 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apigw_test
+
+import (
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/google/uuid"
+	. "github.com/sacloud/apigw-api-go"
+	"github.com/sacloud/apigw-api-go/apigwtest"
+	v1 "github.com/sacloud/apigw-api-go/apis/v1"
+	"github.com/sacloud/saclient-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidation(t *testing.T) {
+	tracker := newMockRequestTracker()
+	defer tracker.Close()
+
+	var theClient saclient.Client
+	client, err := NewClientWithAPIRootURL(&theClient, tracker.URL())
+	require.NoError(t, err)
+	ctx := t.Context()
+
+	cases := []struct {
+		name   string
+		call   func() error
+		fields []string
+	}{
+		{"service", func() error {
+			_, err := NewServiceOp(client).Create(ctx, &v1.ServiceDetailRequest{
+				Name:     "invalid name",
+				Host:     "192.168.0.1",
+				Protocol: v1.ServiceDetailRequestProtocolHTTPS,
+			})
+			return err
+		}, []string{"name", "host"}},
+		{"loopback", func() error {
+			return NewServiceOp(client).Update(ctx, &v1.ServiceDetail{Name: "backend", Host: "::1", Protocol: v1.ServiceDetailProtocolHTTP}, uuid.New())
+		}, []string{"host"}},
+		{"ip restriction", func() error {
+			_, err := NewUserOp(client).Create(ctx, &v1.UserDetail{
+				Name: "alice",
+				IpRestrictionConfig: v1.NewOptIpRestrictionConfig(v1.IpRestrictionConfig{
+					Protocols:    v1.IpRestrictionConfigProtocolsHTTPS,
+					RestrictedBy: v1.IpRestrictionConfigRestrictedByAllowIps,
+					Ips:          []string{"192.0.2.1", "2001:db8::1"},
+				}),
+			})
+			return err
+		}, []string{"ipRestrictionConfig.ips[1]"}},
+		{"nil", func() error {
+			_, err := NewGroupOp(client).Create(ctx, nil)
+			return err
+		}, []string{""}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.call()
+			var verr *ValidationError
+			require.ErrorAs(t, err, &verr)
+			var paths []string
+			for _, f := range verr.Fields {
+				paths = append(paths, f.Path)
+			}
+			assert.Equal(t, tc.fields, paths)
+
+			var apiErr *Error
+			require.ErrorAs(t, err, &apiErr)
+			assert.Equal(t, ErrorKindInvalid, apiErr.Kind())
+			assert.True(t, IsInvalid(err))
+			assert.False(t, IsRetryable(err))
+		})
+	}
+	assert.Empty(t, tracker.Requests())
+}
+
+func TestValidation_ObjectStorageRoute(t *testing.T) {
+	fake, client := apigwtest.NewClient(t)
+	ctx := t.Context()
+
+	subOp := NewSubscriptionOp(client)
+	require.NoError(t, subOp.Create(ctx, fake.Plans()[0].ID.Value, "sub"))
+	subs, err := subOp.List(ctx)
+	require.NoError(t, err)
+	service, err := NewServiceOp(client).Create(ctx, &v1.ServiceDetailRequest{
+		Name:         "assets",
+		Host:         "assets.example.com",
+		Protocol:     v1.ServiceDetailRequestProtocolHTTPS,
+		Subscription: v1.ServiceSubscriptionRequest{ID: subs[0].ID.Value},
+		ObjectStorageConfig: v1.NewOptObjectStorageConfig(v1.ObjectStorageConfig{
+			BucketName: "bucket", Endpoint: "https://s3.example.com", Region: "jp-north-1",
+			AccessKeyID: "key", SecretAccessKey: "secret",
+		}),
+	})
+	require.NoError(t, err)
+
+	routeOp := NewRouteOp(client, service.ID.Value)
+	_, err = routeOp.Create(ctx, &v1.RouteDetail{Name: v1.NewOptName("upload"), Methods: []v1.HTTPMethod{v1.HTTPMethodGET, v1.HTTPMethodPOST}})
+	var verr *ValidationError
+	require.ErrorAs(t, err, &verr)
+	require.Len(t, verr.Fields, 1)
+	assert.Equal(t, "methods[1]", verr.Fields[0].Path)
+
+	_, err = routeOp.Create(ctx, &v1.RouteDetail{Name: v1.NewOptName("all")})
+	assert.True(t, IsInvalid(err))
+
+	_, err = routeOp.Create(ctx, &v1.RouteDetail{Name: v1.NewOptName("read"), Methods: []v1.HTTPMethod{v1.HTTPMethodGET, v1.HTTPMethodHEAD}})
+	assert.NoError(t, err)
+}
+
+func TestValidation_RouteServiceRead(t *testing.T) {
+	// サービスの取得の回数を数える
+	var reads atomic.Int32
+	count := func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			if req.Method == http.MethodGet && !strings.Contains(req.URL.Path, "/routes") {
+				reads.Add(1)
+			}
+			return next.Do(req)
+		})
+	}
+	_, client := apigwtest.NewClient(t, count)
+	ctx := t.Context()
+	routeOp := NewRouteOp(client, uuid.New())
+
+	// 読み取り系のメソッドのみの場合と、ローカルでの検証に失敗した場合はサービスを取得しない
+	_, err := routeOp.Create(ctx, &v1.RouteDetail{Name: v1.NewOptName("read"), Methods: []v1.HTTPMethod{v1.HTTPMethodGET}})
+	assert.True(t, IsNotFound(err))
+	_, err = routeOp.Create(ctx, &v1.RouteDetail{
+		Name:                v1.NewOptName("write"),
+		Methods:             []v1.HTTPMethod{v1.HTTPMethodPOST},
+		IpRestrictionConfig: v1.NewOptIpRestrictionConfig(v1.IpRestrictionConfig{Protocols: v1.IpRestrictionConfigProtocolsHTTPHTTPS, RestrictedBy: v1.IpRestrictionConfigRestrictedByAllowIps, Ips: []string{"::1"}}),
+	})
+	assert.True(t, IsInvalid(err))
+	assert.Zero(t, reads.Load())
+
+	_, err = routeOp.Create(ctx, &v1.RouteDetail{Name: v1.NewOptName("write"), Methods: []v1.HTTPMethod{v1.HTTPMethodPOST}})
+	assert.True(t, IsNotFound(err))
+	assert.EqualValues(t, 1, reads.Load())
+}
@@ -0,0 +1,129 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apigw
+
+import (
+	"context"
+	"iter"
+	"sync"
+
+	v1 "github.com/sacloud/apigw-api-go/apis/v1"
+)
+
+// Walker 複数の種類のリソースにまたがる一覧を走査する
+type Walker struct {
+	client *v1.Client
+
+	// Concurrency サービスごとのルートなど、親リソースごとの一覧を同時に取得する数。
+	// 1以下の場合は親リソースごとに順に取得する。並行して取得した場合も、結果は親リソースの順に返す
+	Concurrency int
+}
+
+// NewWalker Walkerを生成する
+func NewWalker(client *v1.Client) *Walker {
+	return &Walker{client: client}
+}
+
+// ServiceRoute ルートと、ルートが属するサービス
+type ServiceRoute struct {
+	Service *v1.ServiceDetailResponse
+	Route   v1.Route
+}
+
+// AllRoutes 全てのサービスの全てのルートを、サービスの一覧の順に返すイテレータ。
+// サービスの一覧の取得に失敗した場合はエラーを1度返して終了する。
+// あるサービスのルートの取得に失敗した場合は、Serviceのみを設定したServiceRouteとエラーを返し、次のサービスに進む
+func (w *Walker) AllRoutes(ctx context.Context) iter.Seq2[ServiceRoute, error] {
+	return func(yield func(ServiceRoute, error) bool) {
+		services, err := NewServiceOp(w.client).List(ctx)
+		if err != nil {
+			yield(ServiceRoute{}, err)
+			return
+		}
+
+		get, stop := prefetch(ctx, w.Concurrency, len(services), func(ctx context.Context, i int) ([]v1.Route, error) {
+			return NewRouteOp(w.client, services[i].ID.Value).List(ctx)
+		})
+		defer stop()
+		for i := range services {
+			service := &services[i]
+			r := get(i)
+			if r.err != nil {
+				if !yield(ServiceRoute{Service: service}, r.err) {
+					return
+				}
+				continue
+			}
+			for _, route := range r.items {
+				if !yield(ServiceRoute{Service: service, Route: route}, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+type fetched[T any] struct {
+	items []T
+	err   error
+}
+
+// prefetch n件の親リソースそれぞれについてfetchを呼び出し、i番目の結果を返す関数getを返す。
+// concurrencyが1以下の場合はgetの呼び出し時にfetchを呼び出す。
+// それ以外の場合は最大concurrency件を並行して先に呼び出し、getは結果を待つ。
+// stopは未実行の呼び出しを取りやめ、実行中の呼び出しの終了を待つ
+func prefetch[T any](ctx context.Context, concurrency, n int, fetch func(ctx context.Context, i int) ([]T, error)) (get func(i int) fetched[T], stop func()) {
+	if concurrency <= 1 {
+		return func(i int) fetched[T] {
+			items, err := fetch(ctx, i)
+			return fetched[T]{items, err}
+		}, func() {}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	results := make([]chan fetched[T], n)
+	for i := range results {
+		results[i] = make(chan fetched[T], 1)
+	}
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := range n {
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				for ; i < n; i++ {
+					results[i] <- fetched[T]{err: ctx.Err()}
+				}
+				return
+			}
+			wg.Add(1)
+			go func() {
+				defer func() { <-sem; wg.Done() }()
+				items, err := fetch(ctx, i)
+				results[i] <- fetched[T]{items, err}
+			}()
+		}
+	}()
+
+	get = func(i int) fetched[T] { return <-results[i] }
+	stop = func() {
+		cancel()
+		wg.Wait()
+	}
+	return get, stop
+}
@@ -0,0 +1,121 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apigw_test
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/sacloud/apigw-api-go"
+	"github.com/sacloud/apigw-api-go/apigwtest"
+	v1 "github.com/sacloud/apigw-api-go/apis/v1"
+	"github.com/sacloud/saclient-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAll(t *testing.T) {
+	_, client := apigwtest.NewClient(t)
+	ctx := t.Context()
+
+	groupOp := NewGroupOp(client)
+	for _, name := range []v1.Name{"a", "b", "c"} {
+		_, err := groupOp.Create(ctx, &v1.Group{Name: v1.NewOptName(name)})
+		require.NoError(t, err)
+	}
+
+	var names []v1.Name
+	for g, err := range groupOp.All(ctx) {
+		require.NoError(t, err)
+		names = append(names, g.Name.Value)
+		if len(names) == 2 {
+			break
+		}
+	}
+	assert.Len(t, names, 2)
+
+	// 取得に失敗した場合はエラーを1度だけ返す
+	tracker := newMockRequestTracker()
+	defer tracker.Close()
+	var theClient saclient.Client
+	require.NoError(t, theClient.SetEnviron([]string{"SAKURA_RATE_LIMIT=1000"}))
+	broken, err := NewClientWithAPIRootURL(&theClient, tracker.URL())
+	require.NoError(t, err)
+	var errs int
+	for _, err := range NewServiceOp(broken).All(ctx) {
+		assert.Error(t, err)
+		errs++
+	}
+	assert.Equal(t, 1, errs)
+}
+
+func TestWalker_AllRoutes(t *testing.T) {
+	fake, client := apigwtest.NewClient(t)
+	ctx := t.Context()
+
+	// サービスはサブスクリプションごとに1つ作成できる
+	subOp := NewSubscriptionOp(client)
+	for i := range 4 {
+		require.NoError(t, subOp.Create(ctx, fake.Plans()[0].ID.Value, fmt.Sprintf("sub%d", i)))
+	}
+	subs, err := subOp.List(ctx)
+	require.NoError(t, err)
+
+	var want []string
+	for i := range 4 {
+		service, err := NewServiceOp(client).Create(ctx, &v1.ServiceDetailRequest{
+			Name:         v1.Name(fmt.Sprintf("svc%d", i)),
+			Host:         "backend.example.com",
+			Protocol:     v1.ServiceDetailRequestProtocolHTTPS,
+			Subscription: v1.ServiceSubscriptionRequest{ID: subs[i].ID.Value},
+		})
+		require.NoError(t, err)
+		for j := range i {
+			name := fmt.Sprintf("route%d", j)
+			_, err := NewRouteOp(client, service.ID.Value).Create(ctx, &v1.RouteDetail{Name: v1.NewOptName(v1.Name(name)), Path: v1.NewOptString("/" + name)})
+			require.NoError(t, err)
+			want = append(want, fmt.Sprintf("svc%d/%s", i, name))
+		}
+	}
+
+	var sequential []string
+	for _, concurrency := range []int{0, 3} {
+		t.Run(fmt.Sprintf("concurrency=%d", concurrency), func(t *testing.T) {
+			walker := NewWalker(client)
+			walker.Concurrency = concurrency
+
+			var got []string
+			for sr, err := range walker.AllRoutes(ctx) {
+				require.NoError(t, err)
+				got = append(got, fmt.Sprintf("%s/%s", sr.Service.Name, sr.Route.Name.Value))
+			}
+			assert.ElementsMatch(t, want, got)
+			// 並行して取得してもサービスの一覧の順に返す
+			if sequential == nil {
+				sequential = got
+			}
+			assert.Equal(t, sequential, got)
+
+			// 途中で終了できる
+			n := 0
+			for range walker.AllRoutes(ctx) {
+				if n++; n == 2 {
+					break
+				}
+			}
+			assert.Equal(t, 2, n)
+		})
+	}
+}
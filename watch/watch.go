@@ -0,0 +1,327 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package watch アカウントのリソースを定期的に取得し、前回との差分をイベントとして通知する
+package watch
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	apigw "github.com/sacloud/apigw-api-go"
+	v1 "github.com/sacloud/apigw-api-go/apis/v1"
+)
+
+// DefaultInterval Watcher.Intervalを指定しなかった場合のポーリング間隔
+const DefaultInterval = time.Minute
+
+// EventType イベントの種類
+type EventType int
+
+const (
+	// Created リソースが作成された
+	Created EventType = iota + 1
+	// Updated リソースが更新された
+	Updated
+	// Deleted リソースが削除された
+	Deleted
+	// Failed 一覧の取得に失敗した。次のポーリングでは失敗する前の状態との差分を通知する
+	Failed
+)
+
+func (t EventType) String() string {
+	switch t {
+	case Created:
+		return "created"
+	case Updated:
+		return "updated"
+	case Deleted:
+		return "deleted"
+	case Failed:
+		return "failed"
+	}
+	return "unknown"
+}
+
+// Event リソースの変更。
+// Old・Newはリソースの種類に応じて*v1.ServiceDetailResponse、*v1.Route、*v1.User、
+// *v1.Group、*v1.Domain、*v1.Certificateのいずれかで、Createdの場合はOld、Deletedの場合はNewがnil。
+// 型を指定して取り出すにはAsを使う
+type Event struct {
+	Type EventType
+	// Resource リソースの種類。"service"など
+	Resource string
+	ID       uuid.UUID
+	Name     string
+	// ServiceID ルートの場合、ルートが属するサービスのID
+	ServiceID uuid.UUID
+	Old       any
+	New       any
+	// Err Failedの場合の取得エラー
+	Err error
+	// Time 変更を検出した時刻
+	Time time.Time
+}
+
+// As Old・Newを*Tとして返す。TはEventの説明にあるリソースの型で、
+// Createdの場合はold、Deletedの場合はnewがnilとなる。リソースの型がTと異なる場合やFailedの場合はokがfalse
+//
+//	if old, cur, ok := watch.As[v1.Group](e); ok { ... }
+func As[T any](e Event) (old, new *T, ok bool) {
+	if e.Old == nil && e.New == nil {
+		return nil, nil, false
+	}
+	if e.Old != nil {
+		if old, ok = e.Old.(*T); !ok {
+			return nil, nil, false
+		}
+	}
+	if e.New != nil {
+		if new, ok = e.New.(*T); !ok {
+			return nil, nil, false
+		}
+	}
+	return old, new, true
+}
+
+// Intervals リソースの種類ごとのポーリング間隔。
+// 0の場合はWatcher.Interval、負の場合はその種類を監視しない
+type Intervals struct {
+	Service     time.Duration
+	Route       time.Duration
+	User        time.Duration
+	Group       time.Duration
+	Domain      time.Duration
+	Certificate time.Duration
+}
+
+// Watcher サービス・ルート・ユーザー・グループ・ドメイン・証明書の一覧を定期的に取得し、
+// IDとUpdatedAtで前回の一覧と比較して変更をイベントとして通知する
+type Watcher struct {
+	client *v1.Client
+
+	// Interval ポーリング間隔の既定値。0以下の場合はDefaultInterval
+	Interval time.Duration
+	// Intervals リソースの種類ごとのポーリング間隔
+	Intervals Intervals
+	// Initial trueの場合、最初の取得で見つかったリソースもCreatedとして通知する
+	Initial bool
+}
+
+// NewWatcher Watcherを生成する
+func NewWatcher(client *v1.Client) *Watcher {
+	return &Watcher{client: client}
+}
+
+// entry 一覧の1件のリソース
+type entry struct {
+	id        uuid.UUID
+	name      string
+	serviceID uuid.UUID
+	updatedAt v1.OptDateTime
+	object    any
+}
+
+// source 1種類のリソースの一覧の取得方法。
+// listは取得した一覧と個別の取得エラーを返し、一覧自体を取得できなかった場合はokがfalse
+type source struct {
+	resource string
+	interval time.Duration
+	list     func(ctx context.Context, prev []entry) (entries []entry, errs []error, ok bool)
+}
+
+// Watch ctxがキャンセルされるまで監視し、イベントを送信するチャネルを返す。
+// チャネルは監視の終了後に閉じる。受信が遅れている間は次のポーリングを行わない
+func (w *Watcher) Watch(ctx context.Context) <-chan Event {
+	ch := make(chan Event)
+	var wg sync.WaitGroup
+	for _, s := range w.sources() {
+		if s.interval < 0 {
+			continue
+		}
+		if s.interval == 0 {
+			s.interval = w.Interval
+		}
+		if s.interval <= 0 {
+			s.interval = DefaultInterval
+		}
+		wg.Go(func() { w.poll(ctx, s, ch) })
+	}
+	go func() {
+		wg.Wait()
+		close(ch)
+	}()
+	return ch
+}
+
+func (w *Watcher) poll(ctx context.Context, s source, ch chan<- Event) {
+	send := func(e Event) bool {
+		e.Resource = s.resource
+		select {
+		case ch <- e:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	var prev []entry
+	initialized := w.Initial
+	for {
+		entries, errs, ok := s.list(ctx, prev)
+		if ctx.Err() != nil {
+			return
+		}
+		now := time.Now()
+		for _, err := range errs {
+			if !send(Event{Type: Failed, Err: err, Time: now}) {
+				return
+			}
+		}
+		// 取得に失敗した場合は前回の一覧を維持し、次に成功したときにまとめて通知する
+		if ok {
+			if initialized {
+				for _, e := range diff(prev, entries) {
+					e.Time = now
+					if !send(e) {
+						return
+					}
+				}
+			}
+			prev, initialized = entries, true
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// diff 一覧の差分を、作成・更新は新しい一覧の順、削除は前回の一覧の順に返す
+func diff(prev, next []entry) []Event {
+	old := make(map[uuid.UUID]entry, len(prev))
+	for _, e := range prev {
+		old[e.id] = e
+	}
+	var events []Event
+	for _, n := range next {
+		event := Event{ID: n.id, Name: n.name, ServiceID: n.serviceID, New: n.object}
+		o, found := old[n.id]
+		delete(old, n.id)
+		switch {
+		case !found:
+			event.Type = Created
+		case changed(o, n):
+			event.Type = Updated
+			event.Old = o.object
+		default:
+			continue
+		}
+		events = append(events, event)
+	}
+	for _, o := range prev {
+		if _, deleted := old[o.id]; deleted {
+			events = append(events, Event{Type: Deleted, ID: o.id, Name: o.name, ServiceID: o.serviceID, Old: o.object})
+		}
+	}
+	return events
+}
+
+// changed UpdatedAtが異なる場合に更新されたとみなす。
+// UpdatedAtは秒単位のため、同じ場合やない場合は内容を比較する
+func changed(o, n entry) bool {
+	if o.updatedAt.Set && n.updatedAt.Set && !o.updatedAt.Value.Equal(n.updatedAt.Value) {
+		return true
+	}
+	return !reflect.DeepEqual(o.object, n.object)
+}
+
+func listOf[T any](resource string, interval time.Duration, list func(context.Context) ([]T, error), describe func(*T) entry) source {
+	return source{
+		resource: resource,
+		interval: interval,
+		list: func(ctx context.Context, _ []entry) ([]entry, []error, bool) {
+			items, err := list(ctx)
+			if err != nil {
+				return nil, []error{err}, false
+			}
+			entries := make([]entry, 0, len(items))
+			for i := range items {
+				e := describe(&items[i])
+				e.object = &items[i]
+				entries = append(entries, e)
+			}
+			return entries, nil, true
+		},
+	}
+}
+
+func (w *Watcher) sources() []source {
+	return []source{
+		listOf("service", w.Intervals.Service, apigw.NewServiceOp(w.client).List, func(s *v1.ServiceDetailResponse) entry {
+			return entry{id: s.ID.Value, name: string(s.Name), updatedAt: s.UpdatedAt}
+		}),
+		{resource: "route", interval: w.Intervals.Route, list: w.listRoutes},
+		listOf("user", w.Intervals.User, apigw.NewUserOp(w.client).List, func(u *v1.User) entry {
+			return entry{id: u.ID.Value, name: string(u.Name), updatedAt: u.UpdatedAt}
+		}),
+		listOf("group", w.Intervals.Group, apigw.NewGroupOp(w.client).List, func(g *v1.Group) entry {
+			return entry{id: g.ID.Value, name: string(g.Name.Value), updatedAt: g.UpdatedAt}
+		}),
+		listOf("domain", w.Intervals.Domain, apigw.NewDomainOp(w.client).List, func(d *v1.Domain) entry {
+			return entry{id: d.ID.Value, name: d.DomainName, updatedAt: d.UpdatedAt}
+		}),
+		listOf("certificate", w.Intervals.Certificate, apigw.NewCertificateOp(w.client).List, func(c *v1.Certificate) entry {
+			return entry{id: c.ID.Value, name: string(c.Name.Value), updatedAt: c.UpdatedAt}
+		}),
+	}
+}
+
+// listRoutes 全てのサービスのルートを取得する。
+// あるサービスのルートを取得できなかった場合は、そのサービスの前回のルートを維持する
+func (w *Watcher) listRoutes(ctx context.Context, prev []entry) ([]entry, []error, bool) {
+	var entries []entry
+	var errs []error
+	for sr, err := range apigw.NewWalker(w.client).AllRoutes(ctx) {
+		if err != nil {
+			if sr.Service == nil {
+				return nil, []error{err}, false
+			}
+			errs = append(errs, fmt.Errorf("routes of service %q: %w", sr.Service.Name, err))
+			for _, e := range prev {
+				if e.serviceID == sr.Service.ID.Value {
+					entries = append(entries, e)
+				}
+			}
+			continue
+		}
+		route := sr.Route
+		entries = append(entries, entry{
+			id:        route.ID.Value,
+			name:      string(route.Name.Value),
+			serviceID: sr.Service.ID.Value,
+			updatedAt: route.UpdatedAt,
+			object:    &route,
+		})
+	}
+	return entries, errs, true
+}
@@ -0,0 +1,150 @@
+// Copyright 2025- The sacloud/apigw-api-go authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package watch_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	apigw "github.com/sacloud/apigw-api-go"
+	"github.com/sacloud/apigw-api-go/apigwtest"
+	v1 "github.com/sacloud/apigw-api-go/apis/v1"
+	"github.com/sacloud/apigw-api-go/watch"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// receive 次のイベントを受信する
+func receive(t *testing.T, events <-chan watch.Event) watch.Event {
+	t.Helper()
+	select {
+	case e := <-events:
+		return e
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "no event")
+	}
+	return watch.Event{}
+}
+
+// next 失敗以外の次のイベントを受信する
+func next(t *testing.T, events <-chan watch.Event) watch.Event {
+	t.Helper()
+	for {
+		if e := receive(t, events); e.Type != watch.Failed {
+			return e
+		}
+	}
+}
+
+func TestWatcher(t *testing.T) {
+	var down atomic.Bool
+	// listed 最初の一覧の取得が終わると閉じる
+	listed := make(chan struct{})
+	var once sync.Once
+	_, client := apigwtest.NewClient(t, func(next apigw.Doer) apigw.Doer {
+		return apigw.DoerFunc(func(req *http.Request) (*http.Response, error) {
+			if down.Load() && req.Method == http.MethodGet {
+				return nil, errors.New("connection refused")
+			}
+			res, err := next.Do(req)
+			if req.Method == http.MethodGet && req.URL.Path == "/groups" {
+				once.Do(func() { close(listed) })
+			}
+			return res, err
+		})
+	})
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	w := watch.NewWatcher(client)
+	w.Interval = 10 * time.Millisecond
+	w.Intervals = watch.Intervals{Service: -1, Route: -1, User: -1, Domain: -1, Certificate: -1}
+	events := w.Watch(ctx)
+
+	groupOp := apigw.NewGroupOp(client)
+	// 最初の一覧は比較の基準にする
+	<-listed
+	created, err := groupOp.Create(ctx, &v1.Group{Name: v1.NewOptName("admins")})
+	require.NoError(t, err)
+	e := next(t, events)
+	assert.Equal(t, watch.Created, e.Type)
+	assert.Equal(t, "group", e.Resource)
+	assert.Equal(t, "admins", e.Name)
+	assert.Nil(t, e.Old)
+
+	require.NoError(t, groupOp.Update(ctx, &v1.Group{Name: v1.NewOptName("operators")}, created.ID.Value))
+	e = next(t, events)
+	assert.Equal(t, watch.Updated, e.Type)
+	assert.Equal(t, created.ID.Value, e.ID)
+	old, cur, ok := watch.As[v1.Group](e)
+	require.True(t, ok)
+	assert.Equal(t, v1.Name("admins"), old.Name.Value)
+	assert.Equal(t, v1.Name("operators"), cur.Name.Value)
+	_, _, ok = watch.As[v1.User](e)
+	assert.False(t, ok)
+
+	// 取得に失敗している間の変更は、回復後に通知する
+	down.Store(true)
+	e = receive(t, events)
+	assert.Equal(t, watch.Failed, e.Type)
+	assert.ErrorContains(t, e.Err, "connection refused")
+	require.NoError(t, groupOp.Delete(ctx, created.ID.Value))
+	down.Store(false)
+	e = next(t, events)
+	assert.Equal(t, watch.Deleted, e.Type)
+	assert.Equal(t, "operators", e.Name)
+	assert.Nil(t, e.New)
+
+	cancel()
+	for range events {
+	}
+}
+
+func TestWatcher_Initial(t *testing.T) {
+	fake, client := apigwtest.NewClient(t)
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	subOp := apigw.NewSubscriptionOp(client)
+	require.NoError(t, subOp.Create(ctx, fake.Plans()[0].ID.Value, "sub"))
+	subs, err := subOp.List(ctx)
+	require.NoError(t, err)
+	service, err := apigw.NewServiceOp(client).Create(ctx, &v1.ServiceDetailRequest{
+		Name:         "backend",
+		Host:         "backend.example.com",
+		Protocol:     v1.ServiceDetailRequestProtocolHTTPS,
+		Subscription: v1.ServiceSubscriptionRequest{ID: subs[0].ID.Value},
+	})
+	require.NoError(t, err)
+	_, err = apigw.NewRouteOp(client, service.ID.Value).Create(ctx, &v1.RouteDetail{
+		Name: v1.NewOptName("api"),
+		Path: v1.NewOptString("/api"),
+	})
+	require.NoError(t, err)
+
+	w := watch.NewWatcher(client)
+	w.Initial = true
+	w.Intervals = watch.Intervals{Service: -1, User: -1, Group: -1, Domain: -1, Certificate: -1}
+	e := next(t, w.Watch(ctx))
+	assert.Equal(t, watch.Created, e.Type)
+	assert.Equal(t, "route", e.Resource)
+	assert.Equal(t, "api", e.Name)
+	assert.Equal(t, service.ID.Value, e.ServiceID)
+}